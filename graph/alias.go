@@ -0,0 +1,35 @@
+package graph
+
+import (
+	"github.com/SarunasBucius/nutri-price-server/graph/model"
+	internalmodel "github.com/SarunasBucius/nutri-price-server/internal/model"
+)
+
+func toProductAndVarietyName(name string, varietyName *string) internalmodel.ProductAndVarietyName {
+	alias := internalmodel.ProductAndVarietyName{Name: name}
+	if varietyName != nil {
+		alias.VarietyName = *varietyName
+	}
+	return alias
+}
+
+func toProductAliasesUpdate(update internalmodel.ProductAliasesUpdate) *model.ProductAliasesUpdate {
+	return &model.ProductAliasesUpdate{
+		Aliases:               toProductAliases(update.Aliases),
+		UnconfirmedReceiptIds: update.UnconfirmedReceiptIDs,
+	}
+}
+
+func toProductAliases(aliases []internalmodel.ProductAlias) []*model.ProductAlias {
+	result := make([]*model.ProductAlias, 0, len(aliases))
+	for _, alias := range aliases {
+		result = append(result, &model.ProductAlias{
+			ID:          alias.ID,
+			ParsedName:  alias.ParsedName,
+			Name:        alias.Name,
+			VarietyName: alias.VarietyName,
+			Purchases:   int32(alias.Purchases),
+		})
+	}
+	return result
+}
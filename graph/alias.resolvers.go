@@ -0,0 +1,52 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.70
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/SarunasBucius/nutri-price-server/graph/model"
+)
+
+// UpdateProductAlias is the resolver for the updateProductAlias field.
+func (r *mutationResolver) UpdateProductAlias(ctx context.Context, id string, name string, varietyName *string) (*model.ProductAliasesUpdate, error) {
+	update, err := r.AliasService.UpdateProductAlias(ctx, id, toProductAndVarietyName(name, varietyName))
+	if err != nil {
+		return nil, fmt.Errorf("update product alias: %w", err)
+	}
+	return toProductAliasesUpdate(update), nil
+}
+
+// ReassignProductAliases is the resolver for the reassignProductAliases field.
+func (r *mutationResolver) ReassignProductAliases(ctx context.Context, ids []string, name string, varietyName *string) (*model.ProductAliasesUpdate, error) {
+	update, err := r.AliasService.ReassignProductAliases(ctx, ids, toProductAndVarietyName(name, varietyName))
+	if err != nil {
+		return nil, fmt.Errorf("reassign product aliases: %w", err)
+	}
+	return toProductAliasesUpdate(update), nil
+}
+
+// DeleteProductAlias is the resolver for the deleteProductAlias field.
+func (r *mutationResolver) DeleteProductAlias(ctx context.Context, id string) (string, error) {
+	if err := r.AliasService.DeleteProductAlias(ctx, id); err != nil {
+		return "", fmt.Errorf("delete product alias: %w", err)
+	}
+	return id, nil
+}
+
+// ProductAliases is the resolver for the productAliases field.
+func (r *queryResolver) ProductAliases(ctx context.Context, search *string) ([]*model.ProductAlias, error) {
+	var searchText string
+	if search != nil {
+		searchText = *search
+	}
+
+	aliases, err := r.AliasService.GetProductAliases(ctx, searchText)
+	if err != nil {
+		return nil, fmt.Errorf("get product aliases: %w", err)
+	}
+	return toProductAliases(aliases), nil
+}
@@ -0,0 +1,64 @@
+package graph
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/SarunasBucius/nutri-price-server/graph/model"
+)
+
+// categoryPathSeparator separates category names in a category path, e.g. Dairy > Cheese > Hard cheese.
+const categoryPathSeparator = " > "
+
+// toCategoryTree links categories to their parents and returns the root categories. Categories must be ordered by name.
+func toCategoryTree(categories []*model.Category) []*model.Category {
+	categoriesByID := make(map[string]*model.Category, len(categories))
+	for _, category := range categories {
+		category.Children = make([]*model.Category, 0)
+		categoriesByID[category.ID] = category
+	}
+
+	roots := make([]*model.Category, 0)
+	for _, category := range categories {
+		parent, ok := categoriesByID[deref(category.ParentID)]
+		if !ok {
+			roots = append(roots, category)
+			continue
+		}
+		parent.Children = append(parent.Children, category)
+	}
+
+	var setPaths func(categories []*model.Category, parentPath string)
+	setPaths = func(categories []*model.Category, parentPath string) {
+		for _, category := range categories {
+			category.Path = category.Name
+			if parentPath != "" {
+				category.Path = parentPath + categoryPathSeparator + category.Name
+			}
+			setPaths(category.Children, category.Path)
+		}
+	}
+	setPaths(roots, "")
+
+	return roots
+}
+
+// normaliseTags lowercases and trims tags, dropping empty and duplicate ones.
+func normaliseTags(tags []string) []string {
+	normalised := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag != "" {
+			normalised = append(normalised, tag)
+		}
+	}
+	slices.Sort(normalised)
+	return slices.Compact(normalised)
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
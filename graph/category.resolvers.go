@@ -0,0 +1,171 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.70
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/SarunasBucius/nutri-price-server/graph/model"
+)
+
+// CreateCategory is the resolver for the createCategory field.
+func (r *mutationResolver) CreateCategory(ctx context.Context, name string, parentID *string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", fmt.Errorf("category name is required")
+	}
+
+	query := `
+	INSERT INTO categories (name, parent_id)
+	VALUES ($1, $2)
+	RETURNING id`
+	var id string
+	if err := r.DB.QueryRow(ctx, query, name, parentID).Scan(&id); err != nil {
+		return "", fmt.Errorf("insert category: %w", err)
+	}
+	return id, nil
+}
+
+// UpdateCategory is the resolver for the updateCategory field.
+func (r *mutationResolver) UpdateCategory(ctx context.Context, id string, name string, parentID *string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", fmt.Errorf("category name is required")
+	}
+
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		return "", fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if parentID != nil {
+		// Lock the category rows so a concurrent move can not create a cycle between the check and the update.
+		if _, err := tx.Exec(ctx, `LOCK TABLE categories IN SHARE ROW EXCLUSIVE MODE`); err != nil {
+			return "", fmt.Errorf("lock categories: %w", err)
+		}
+		query := `SELECT EXISTS (SELECT 1 FROM category_subtree($1) WHERE id::text = $2)`
+		var isDescendant bool
+		if err := tx.QueryRow(ctx, query, id, *parentID).Scan(&isDescendant); err != nil {
+			return "", fmt.Errorf("check category parent: %w", err)
+		}
+		if isDescendant {
+			return "", fmt.Errorf("category can not be moved under itself or its subcategory")
+		}
+	}
+
+	query := `
+	UPDATE categories
+	SET name = $1, parent_id = $2
+	WHERE id = $3`
+	tag, err := tx.Exec(ctx, query, name, parentID, id)
+	if err != nil {
+		return "", fmt.Errorf("update category: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return "", fmt.Errorf("category %s not found", id)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return "", fmt.Errorf("commit transaction: %w", err)
+	}
+	return id, nil
+}
+
+// DeleteCategory is the resolver for the deleteCategory field.
+func (r *mutationResolver) DeleteCategory(ctx context.Context, id string) (string, error) {
+	query := `
+	DELETE FROM categories
+	WHERE id = $1`
+	if _, err := r.DB.Exec(ctx, query, id); err != nil {
+		return "", fmt.Errorf("delete category: %w", err)
+	}
+	return id, nil
+}
+
+// SetProductCategory is the resolver for the setProductCategory field.
+func (r *mutationResolver) SetProductCategory(ctx context.Context, productID string, categoryID *string) (string, error) {
+	query := `
+	UPDATE products
+	SET category_id = $1
+	WHERE id = $2`
+	tag, err := r.DB.Exec(ctx, query, categoryID, productID)
+	if err != nil {
+		return "", fmt.Errorf("update product category: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return "", fmt.Errorf("product %s not found", productID)
+	}
+	return productID, nil
+}
+
+// SetProductTags is the resolver for the setProductTags field.
+func (r *mutationResolver) SetProductTags(ctx context.Context, productID string, tags []string) ([]string, error) {
+	normalisedTags := normaliseTags(tags)
+
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, "DELETE FROM product_tags WHERE product_id = $1", productID); err != nil {
+		return nil, fmt.Errorf("delete product tags: %w", err)
+	}
+
+	query := `
+	INSERT INTO product_tags (product_id, tag)
+	SELECT $1, UNNEST($2::TEXT[])`
+	if _, err := tx.Exec(ctx, query, productID, normalisedTags); err != nil {
+		return nil, fmt.Errorf("insert product tags: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
+	return normalisedTags, nil
+}
+
+// Categories is the resolver for the categories field.
+func (r *queryResolver) Categories(ctx context.Context) ([]*model.Category, error) {
+	query := `SELECT id, name, parent_id FROM categories ORDER BY name`
+	rows, err := r.DB.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("query categories: %w", err)
+	}
+	defer rows.Close()
+
+	var categories []*model.Category
+	for rows.Next() {
+		category := &model.Category{}
+		if err := rows.Scan(&category.ID, &category.Name, &category.ParentID); err != nil {
+			return nil, fmt.Errorf("scan category: %w", err)
+		}
+		categories = append(categories, category)
+	}
+
+	return toCategoryTree(categories), nil
+}
+
+// Tags is the resolver for the tags field.
+func (r *queryResolver) Tags(ctx context.Context) ([]string, error) {
+	rows, err := r.DB.Query(ctx, "SELECT DISTINCT tag FROM product_tags ORDER BY tag")
+	if err != nil {
+		return nil, fmt.Errorf("query tags: %w", err)
+	}
+	defer rows.Close()
+
+	tags := make([]string, 0)
+	for rows.Next() {
+		var tag string
+		if err := rows.Scan(&tag); err != nil {
+			return nil, fmt.Errorf("scan tag: %w", err)
+		}
+		tags = append(tags, tag)
+	}
+	return tags, nil
+}
@@ -0,0 +1,38 @@
+package graph
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/SarunasBucius/nutri-price-server/graph/model"
+	internalmodel "github.com/SarunasBucius/nutri-price-server/internal/model"
+)
+
+func toFoodFacts(facts []internalmodel.FoodFact, catalogue []*model.Nutrient) []*model.FoodFact {
+	result := make([]*model.FoodFact, 0, len(facts))
+	for _, fact := range facts {
+		nv := fact.NutritionalValue
+		result = append(result, &model.FoodFact{
+			ID:                 strconv.Itoa(fact.ID),
+			Source:             fact.Source,
+			SourceID:           fact.SourceID,
+			Barcode:            fact.Barcode,
+			Name:               fact.Name,
+			Brands:             fact.Brands,
+			Unit:               fact.Unit,
+			EnergyValueKcal:    nv.EnergyValueKCAL,
+			Fat:                nv.Fat,
+			SaturatedFat:       nv.SaturatedFat,
+			Carbohydrate:       nv.Carbohydrate,
+			CarbohydrateSugars: nv.CarbohydrateSugars,
+			Fibre:              nv.Fibre,
+			SolubleFibre:       nv.SolubleFibre,
+			InsolubleFibre:     nv.InsolubleFibre,
+			Protein:            nv.Protein,
+			Salt:               nv.Salt,
+			Nutrients:          toNutrientAmounts(nv.Nutrients, catalogue),
+			ImportedAt:         fact.ImportedAt.Format(time.RFC3339),
+		})
+	}
+	return result
+}
@@ -0,0 +1,47 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.70
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/SarunasBucius/nutri-price-server/graph/model"
+)
+
+// ApplyFoodFact is the resolver for the applyFoodFact field.
+func (r *mutationResolver) ApplyFoodFact(ctx context.Context, foodFactID string, productID string, varietyName string) (string, error) {
+	id, err := r.FoodDataService.ApplyFoodFact(ctx, foodFactID, productID, varietyName)
+	if err != nil {
+		return "", fmt.Errorf("apply food fact: %w", err)
+	}
+	return id, nil
+}
+
+// SetProductReferenceFood is the resolver for the setProductReferenceFood field.
+func (r *mutationResolver) SetProductReferenceFood(ctx context.Context, productID string, foodFactID *string) (string, error) {
+	if err := r.FoodDataService.SetProductReferenceFood(ctx, productID, deref(foodFactID)); err != nil {
+		return "", fmt.Errorf("set product reference food: %w", err)
+	}
+	return productID, nil
+}
+
+// SearchFoodFacts is the resolver for the searchFoodFacts field.
+func (r *queryResolver) SearchFoodFacts(ctx context.Context, search string, source *string, limit *int32) ([]*model.FoodFact, error) {
+	var limitValue int
+	if limit != nil {
+		limitValue = int(*limit)
+	}
+
+	facts, err := r.FoodDataService.SearchFoodFacts(ctx, search, deref(source), limitValue)
+	if err != nil {
+		return nil, fmt.Errorf("search food facts: %w", err)
+	}
+	catalogue, err := r.nutrientCatalogue(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return toFoodFacts(facts, catalogue), nil
+}
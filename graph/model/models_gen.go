@@ -12,24 +12,31 @@ type CalculatedDay struct {
 	Carbohydrate       float64             `json:"carbohydrate"`
 	CarbohydrateSugars float64             `json:"carbohydrateSugars"`
 	Fibre              float64             `json:"fibre"`
+	SolubleFibre       float64             `json:"solubleFibre"`
+	InsolubleFibre     float64             `json:"insolubleFibre"`
 	Protein            float64             `json:"protein"`
 	Salt               float64             `json:"salt"`
+	Nutrients          []*NutrientAmount   `json:"nutrients"`
 }
 
 type CalculatedProduct struct {
-	Product            string  `json:"product"`
-	VarietyName        string  `json:"varietyName"`
-	Price              float64 `json:"price"`
-	Unit               string  `json:"unit"`
-	Quantity           float64 `json:"quantity"`
-	EnergyValueKcal    float64 `json:"energyValueKcal"`
-	Fat                float64 `json:"fat"`
-	SaturatedFat       float64 `json:"saturatedFat"`
-	Carbohydrate       float64 `json:"carbohydrate"`
-	CarbohydrateSugars float64 `json:"carbohydrateSugars"`
-	Fibre              float64 `json:"fibre"`
-	Protein            float64 `json:"protein"`
-	Salt               float64 `json:"salt"`
+	Product            string            `json:"product"`
+	VarietyName        string            `json:"varietyName"`
+	Price              float64           `json:"price"`
+	Unit               string            `json:"unit"`
+	Quantity           float64           `json:"quantity"`
+	EnergyValueKcal    float64           `json:"energyValueKcal"`
+	Fat                float64           `json:"fat"`
+	SaturatedFat       float64           `json:"saturatedFat"`
+	Carbohydrate       float64           `json:"carbohydrate"`
+	CarbohydrateSugars float64           `json:"carbohydrateSugars"`
+	Fibre              float64           `json:"fibre"`
+	SolubleFibre       float64           `json:"solubleFibre"`
+	InsolubleFibre     float64           `json:"insolubleFibre"`
+	Protein            float64           `json:"protein"`
+	Salt               float64           `json:"salt"`
+	Nutrients          []*NutrientAmount `json:"nutrients"`
+	PriceSources       []*PriceSource    `json:"priceSources"`
 }
 
 type CalculatedRecipe struct {
@@ -43,8 +50,50 @@ type CalculatedRecipe struct {
 	Carbohydrate       float64              `json:"carbohydrate"`
 	CarbohydrateSugars float64              `json:"carbohydrateSugars"`
 	Fibre              float64              `json:"fibre"`
+	SolubleFibre       float64              `json:"solubleFibre"`
+	InsolubleFibre     float64              `json:"insolubleFibre"`
 	Protein            float64              `json:"protein"`
 	Salt               float64              `json:"salt"`
+	Nutrients          []*NutrientAmount    `json:"nutrients"`
+}
+
+type Category struct {
+	ID       string      `json:"id"`
+	Name     string      `json:"name"`
+	ParentID *string     `json:"parentId,omitempty"`
+	Path     string      `json:"path"`
+	Children []*Category `json:"children"`
+}
+
+type DiscountSavings struct {
+	Retailer      string  `json:"retailer"`
+	DiscountType  string  `json:"discountType"`
+	PurchaseCount int32   `json:"purchaseCount"`
+	FullPrice     float64 `json:"fullPrice"`
+	PaidPrice     float64 `json:"paidPrice"`
+	Saved         float64 `json:"saved"`
+}
+
+type FoodFact struct {
+	ID                 string            `json:"id"`
+	Source             string            `json:"source"`
+	SourceID           string            `json:"sourceId"`
+	Barcode            string            `json:"barcode"`
+	Name               string            `json:"name"`
+	Brands             string            `json:"brands"`
+	Unit               string            `json:"unit"`
+	EnergyValueKcal    float64           `json:"energyValueKcal"`
+	Fat                float64           `json:"fat"`
+	SaturatedFat       float64           `json:"saturatedFat"`
+	Carbohydrate       float64           `json:"carbohydrate"`
+	CarbohydrateSugars float64           `json:"carbohydrateSugars"`
+	Fibre              float64           `json:"fibre"`
+	SolubleFibre       float64           `json:"solubleFibre"`
+	InsolubleFibre     float64           `json:"insolubleFibre"`
+	Protein            float64           `json:"protein"`
+	Salt               float64           `json:"salt"`
+	Nutrients          []*NutrientAmount `json:"nutrients"`
+	ImportedAt         string            `json:"importedAt"`
 }
 
 type Ingredient struct {
@@ -64,29 +113,58 @@ type IngredientInput struct {
 type Mutation struct {
 }
 
+type Nutrient struct {
+	Code           string   `json:"code"`
+	Name           string   `json:"name"`
+	Unit           string   `json:"unit"`
+	DailyReference *float64 `json:"dailyReference,omitempty"`
+}
+
+type NutrientAmount struct {
+	Code                string   `json:"code"`
+	Name                string   `json:"name"`
+	Unit                string   `json:"unit"`
+	Amount              float64  `json:"amount"`
+	DailyReferenceShare *float64 `json:"dailyReferenceShare,omitempty"`
+}
+
+type NutrientAmountInput struct {
+	Code   string  `json:"code"`
+	Amount float64 `json:"amount"`
+}
+
 type NutritionalValue struct {
-	ID                 string  `json:"id"`
-	Unit               string  `json:"unit"`
-	EnergyValueKcal    float64 `json:"energyValueKcal"`
-	Fat                float64 `json:"fat"`
-	SaturatedFat       float64 `json:"saturatedFat"`
-	Carbohydrate       float64 `json:"carbohydrate"`
-	CarbohydrateSugars float64 `json:"carbohydrateSugars"`
-	Fibre              float64 `json:"fibre"`
-	Protein            float64 `json:"protein"`
-	Salt               float64 `json:"salt"`
+	ID                 string            `json:"id"`
+	Unit               string            `json:"unit"`
+	EnergyValueKcal    float64           `json:"energyValueKcal"`
+	Fat                float64           `json:"fat"`
+	SaturatedFat       float64           `json:"saturatedFat"`
+	Carbohydrate       float64           `json:"carbohydrate"`
+	CarbohydrateSugars float64           `json:"carbohydrateSugars"`
+	Fibre              float64           `json:"fibre"`
+	SolubleFibre       float64           `json:"solubleFibre"`
+	InsolubleFibre     float64           `json:"insolubleFibre"`
+	Protein            float64           `json:"protein"`
+	Salt               float64           `json:"salt"`
+	Nutrients          []*NutrientAmount `json:"nutrients"`
+	Source             string            `json:"source"`
+	SourceID           string            `json:"sourceId"`
+	SourcedAt          *string           `json:"sourcedAt,omitempty"`
 }
 
 type NutritionalValueInput struct {
-	Unit               string  `json:"unit"`
-	EnergyValueKcal    float64 `json:"energyValueKcal"`
-	Fat                float64 `json:"fat"`
-	SaturatedFat       float64 `json:"saturatedFat"`
-	Carbohydrate       float64 `json:"carbohydrate"`
-	CarbohydrateSugars float64 `json:"carbohydrateSugars"`
-	Fibre              float64 `json:"fibre"`
-	Protein            float64 `json:"protein"`
-	Salt               float64 `json:"salt"`
+	Unit               string                 `json:"unit"`
+	EnergyValueKcal    float64                `json:"energyValueKcal"`
+	Fat                float64                `json:"fat"`
+	SaturatedFat       float64                `json:"saturatedFat"`
+	Carbohydrate       float64                `json:"carbohydrate"`
+	CarbohydrateSugars float64                `json:"carbohydrateSugars"`
+	Fibre              float64                `json:"fibre"`
+	SolubleFibre       float64                `json:"solubleFibre"`
+	InsolubleFibre     float64                `json:"insolubleFibre"`
+	Protein            float64                `json:"protein"`
+	Salt               float64                `json:"salt"`
+	Nutrients          []*NutrientAmountInput `json:"nutrients,omitempty"`
 }
 
 type PlanRecipe struct {
@@ -112,14 +190,53 @@ type PreparedRecipeInput struct {
 	Portion      float64            `json:"portion"`
 }
 
+type PriceHistory struct {
+	Unit           string        `json:"unit"`
+	Bucket         string        `json:"bucket"`
+	Points         []*PricePoint `json:"points"`
+	Min            float64       `json:"min"`
+	Max            float64       `json:"max"`
+	Mean           float64       `json:"mean"`
+	Trend          float64       `json:"trend"`
+	TrendDirection string        `json:"trendDirection"`
+}
+
+type PricePoint struct {
+	PeriodStart string  `json:"periodStart"`
+	Purchases   int32   `json:"purchases"`
+	Min         float64 `json:"min"`
+	Max         float64 `json:"max"`
+	Mean        float64 `json:"mean"`
+}
+
+type PriceSource struct {
+	PurchaseID  int32   `json:"purchaseId"`
+	VarietyName string  `json:"varietyName"`
+	Retailer    string  `json:"retailer"`
+	Date        string  `json:"date"`
+	Price       float64 `json:"price"`
+	Unit        string  `json:"unit"`
+	Quantity    float64 `json:"quantity"`
+}
+
+type PriceStrategyInput struct {
+	Strategy string  `json:"strategy"`
+	Days     *int32  `json:"days,omitempty"`
+	Retailer *string `json:"retailer,omitempty"`
+}
+
 type Product struct {
-	ID   string `json:"id"`
-	Name string `json:"name"`
+	ID              string   `json:"id"`
+	Name            string   `json:"name"`
+	CategoryID      *string  `json:"categoryId,omitempty"`
+	ReferenceFoodID *string  `json:"referenceFoodId,omitempty"`
+	Tags            []string `json:"tags"`
 }
 
 type ProductAggregate struct {
-	Name      string     `json:"name"`
-	Varieties []*Variety `json:"varieties"`
+	Name          string     `json:"name"`
+	ReferenceFood *FoodFact  `json:"referenceFood,omitempty"`
+	Varieties     []*Variety `json:"varieties"`
 }
 
 type ProductAggregateInput struct {
@@ -129,23 +246,43 @@ type ProductAggregateInput struct {
 	Purchase         *PurchaseInput         `json:"purchase,omitempty"`
 }
 
+type ProductAlias struct {
+	ID          string `json:"id"`
+	ParsedName  string `json:"parsedName"`
+	Name        string `json:"name"`
+	VarietyName string `json:"varietyName"`
+	Purchases   int32  `json:"purchases"`
+}
+
+type ProductAliasesUpdate struct {
+	Aliases               []*ProductAlias `json:"aliases"`
+	UnconfirmedReceiptIds []string        `json:"unconfirmedReceiptIds"`
+}
+
 type Purchase struct {
-	ID       string  `json:"id"`
-	Date     string  `json:"date"`
-	Quantity float64 `json:"quantity"`
-	Price    float64 `json:"price"`
-	Retailer string  `json:"retailer"`
-	Unit     string  `json:"unit"`
-	Notes    string  `json:"notes"`
+	ID           string  `json:"id"`
+	Date         string  `json:"date"`
+	Quantity     float64 `json:"quantity"`
+	Price        float64 `json:"price"`
+	FullPrice    float64 `json:"fullPrice"`
+	Discount     float64 `json:"discount"`
+	DiscountType string  `json:"discountType"`
+	Deposit      float64 `json:"deposit"`
+	Retailer     string  `json:"retailer"`
+	Unit         string  `json:"unit"`
+	Notes        string  `json:"notes"`
 }
 
 type PurchaseInput struct {
-	Date     string  `json:"date"`
-	Quantity float64 `json:"quantity"`
-	Price    float64 `json:"price"`
-	Retailer string  `json:"retailer"`
-	Unit     string  `json:"unit"`
-	Notes    string  `json:"notes"`
+	Date         string   `json:"date"`
+	Quantity     float64  `json:"quantity"`
+	Price        float64  `json:"price"`
+	FullPrice    *float64 `json:"fullPrice,omitempty"`
+	Discount     *float64 `json:"discount,omitempty"`
+	DiscountType *string  `json:"discountType,omitempty"`
+	Retailer     string   `json:"retailer"`
+	Unit         string   `json:"unit"`
+	Notes        string   `json:"notes"`
 }
 
 type Query struct {
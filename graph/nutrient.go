@@ -0,0 +1,67 @@
+package graph
+
+import (
+	"context"
+
+	"github.com/SarunasBucius/nutri-price-server/graph/model"
+)
+
+// nutrientCatalogue returns the nutrients that nutritional values can list, ordered by name.
+func (r *Resolver) nutrientCatalogue(ctx context.Context) ([]*model.Nutrient, error) {
+	nutrients, err := r.NutritionalValueService.GetNutrients(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	catalogue := make([]*model.Nutrient, 0, len(nutrients))
+	for _, nutrient := range nutrients {
+		catalogue = append(catalogue, &model.Nutrient{
+			Code:           nutrient.Code,
+			Name:           nutrient.Name,
+			Unit:           nutrient.Unit,
+			DailyReference: nutrient.DailyReference,
+		})
+	}
+	return catalogue, nil
+}
+
+// toNutrientsMap converts nutrient amount inputs to validated amounts by nutrient code.
+// It returns nil when no inputs are given, so stored nutrients are kept.
+func (r *Resolver) toNutrientsMap(ctx context.Context, inputs []*model.NutrientAmountInput) (map[string]float64, error) {
+	if inputs == nil {
+		return nil, nil
+	}
+
+	amounts := make(map[string]float64, len(inputs))
+	for _, input := range inputs {
+		amounts[input.Code] = input.Amount
+	}
+	if err := r.NutritionalValueService.ValidateNutrients(ctx, amounts); err != nil {
+		return nil, err
+	}
+	return amounts, nil
+}
+
+// toNutrientAmounts lists nutrient amounts in the catalogue order. Shares of daily reference values are set
+// for nutrients that have one.
+func toNutrientAmounts(amounts map[string]float64, catalogue []*model.Nutrient) []*model.NutrientAmount {
+	result := make([]*model.NutrientAmount, 0, len(amounts))
+	for _, nutrient := range catalogue {
+		amount, ok := amounts[nutrient.Code]
+		if !ok {
+			continue
+		}
+		nutrientAmount := &model.NutrientAmount{
+			Code:   nutrient.Code,
+			Name:   nutrient.Name,
+			Unit:   nutrient.Unit,
+			Amount: amount,
+		}
+		if nutrient.DailyReference != nil && *nutrient.DailyReference > 0 {
+			share := amount / *nutrient.DailyReference
+			nutrientAmount.DailyReferenceShare = &share
+		}
+		result = append(result, nutrientAmount)
+	}
+	return result
+}
@@ -0,0 +1,16 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.70
+
+import (
+	"context"
+
+	"github.com/SarunasBucius/nutri-price-server/graph/model"
+)
+
+// Nutrients is the resolver for the nutrients field.
+func (r *queryResolver) Nutrients(ctx context.Context) ([]*model.Nutrient, error) {
+	return r.nutrientCatalogue(ctx)
+}
@@ -0,0 +1,30 @@
+package graph
+
+import (
+	"github.com/SarunasBucius/nutri-price-server/graph/model"
+	internalmodel "github.com/SarunasBucius/nutri-price-server/internal/model"
+)
+
+func toPriceHistory(history internalmodel.PriceHistory) *model.PriceHistory {
+	points := make([]*model.PricePoint, 0, len(history.Points))
+	for _, point := range history.Points {
+		points = append(points, &model.PricePoint{
+			PeriodStart: point.PeriodStart,
+			Purchases:   int32(point.Purchases),
+			Min:         point.Min,
+			Max:         point.Max,
+			Mean:        point.Mean,
+		})
+	}
+
+	return &model.PriceHistory{
+		Unit:           history.Unit,
+		Bucket:         history.Bucket,
+		Points:         points,
+		Min:            history.Min,
+		Max:            history.Max,
+		Mean:           history.Mean,
+		Trend:          history.Trend,
+		TrendDirection: history.TrendDirection,
+	}
+}
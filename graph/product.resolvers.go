@@ -6,11 +6,15 @@ package graph
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/99designs/gqlgen/graphql"
 	"github.com/SarunasBucius/nutri-price-server/graph/model"
+	internalmodel "github.com/SarunasBucius/nutri-price-server/internal/model"
+	"github.com/SarunasBucius/nutri-price-server/internal/repository"
+	"github.com/SarunasBucius/nutri-price-server/internal/service/product/pricehistory"
 	pgx "github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 )
@@ -37,9 +41,14 @@ func (r *mutationResolver) CreateProduct(ctx context.Context, input model.Produc
 	}
 
 	if input.NutritionalValue != nil {
+		nutrients, err := r.toNutrientsMap(ctx, input.NutritionalValue.Nutrients)
+		if err != nil {
+			return "", err
+		}
+
 		query = `
-				INSERT INTO nutritional_values_v2 (product_id, variety_name, unit, energy_value_kcal, fat, saturated_fat, carbohydrate, carbohydrate_sugars, fibre, protein, salt)
-				VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11) ON CONFLICT (variety_name, product_id) 
+				INSERT INTO nutritional_values_v2 (product_id, variety_name, unit, energy_value_kcal, fat, saturated_fat, carbohydrate, carbohydrate_sugars, fibre, soluble_fibre, insoluble_fibre, protein, salt, nutrients)
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, COALESCE($14::JSONB, '{}')) ON CONFLICT (variety_name, product_id) 
 				DO UPDATE SET     
 					unit = EXCLUDED.unit,
 					energy_value_kcal = EXCLUDED.energy_value_kcal,
@@ -48,26 +57,34 @@ func (r *mutationResolver) CreateProduct(ctx context.Context, input model.Produc
 					carbohydrate = EXCLUDED.carbohydrate,
 					carbohydrate_sugars = EXCLUDED.carbohydrate_sugars,
 					fibre = EXCLUDED.fibre,
+					soluble_fibre = EXCLUDED.soluble_fibre,
+					insoluble_fibre = EXCLUDED.insoluble_fibre,
 					protein = EXCLUDED.protein,
-					salt = EXCLUDED.salt`
+					salt = EXCLUDED.salt,
+					nutrients = COALESCE($14::JSONB, nutritional_values_v2.nutrients),
+					source = '',
+					source_id = '',
+					sourced_at = NULL`
 		nv := input.NutritionalValue
 		if _, err := r.DB.Exec(ctx, query, productID, varietyName, nv.Unit,
 			nv.EnergyValueKcal, nv.Fat,
 			nv.SaturatedFat, nv.Carbohydrate,
 			nv.CarbohydrateSugars, nv.Fibre,
-			nv.Protein, nv.Salt); err != nil {
+			nv.SolubleFibre, nv.InsolubleFibre,
+			nv.Protein, nv.Salt, nutrients); err != nil {
 			return "", fmt.Errorf("insert nutritional value: %w", err)
 		}
 	}
 
 	if input.Purchase != nil {
 		query = `
-		INSERT INTO purchases (product_id, variety_name, retailer, purchase_date, quantity, unit, price, notes)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+		INSERT INTO purchases (product_id, variety_name, retailer, purchase_date, quantity, unit, price, notes, discount, discount_type, full_price)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, COALESCE($9::numeric, 0), COALESCE($10::text, ''), COALESCE($11::numeric, $7 + COALESCE($9::numeric, 0)))`
 		purchase := input.Purchase
 		if _, err := r.DB.Exec(ctx, query, productID, varietyName, purchase.Retailer,
 			purchase.Date, purchase.Quantity, purchase.Unit,
-			purchase.Price, purchase.Notes); err != nil {
+			purchase.Price, purchase.Notes,
+			purchase.Discount, purchase.DiscountType, purchase.FullPrice); err != nil {
 			return "", fmt.Errorf("insert purchase: %w", err)
 		}
 	}
@@ -114,6 +131,31 @@ func (r *mutationResolver) UpdateProduct(ctx context.Context, id string, name st
 		return "", fmt.Errorf("update purchases product id: %w", err)
 	}
 
+	query = `
+		UPDATE product_codes
+		SET product_id = $1
+		WHERE product_id = $2`
+	if _, err := r.DB.Exec(ctx, query, existingProductID, id); err != nil {
+		return "", fmt.Errorf("update product codes product id: %w", err)
+	}
+
+	query = `
+		INSERT INTO product_tags (product_id, tag)
+		SELECT $1, tag FROM product_tags WHERE product_id = $2
+		ON CONFLICT DO NOTHING`
+	if _, err := r.DB.Exec(ctx, query, existingProductID, id); err != nil {
+		return "", fmt.Errorf("merge product tags: %w", err)
+	}
+
+	query = `
+		UPDATE products
+		SET category_id = COALESCE(category_id, (SELECT category_id FROM products WHERE id = $2)),
+			reference_food_id = COALESCE(reference_food_id, (SELECT reference_food_id FROM products WHERE id = $2))
+		WHERE id = $1`
+	if _, err := r.DB.Exec(ctx, query, existingProductID, id); err != nil {
+		return "", fmt.Errorf("merge product category and reference food: %w", err)
+	}
+
 	query = `
 		DELETE FROM products
 		WHERE id = $1`
@@ -154,6 +196,14 @@ func (r *mutationResolver) UpdateVariety(ctx context.Context, oldName string, va
 	if _, err := r.DB.Exec(ctx, query, varietyName, oldName); err != nil {
 		return "", fmt.Errorf("update purchases variety name: %w", err)
 	}
+
+	query = `
+	UPDATE product_codes
+	SET variety_name = $1
+	WHERE variety_name = $2`
+	if _, err := r.DB.Exec(ctx, query, varietyName, oldName); err != nil {
+		return "", fmt.Errorf("update product codes variety name: %w", err)
+	}
 	return varietyName, nil
 }
 
@@ -161,10 +211,14 @@ func (r *mutationResolver) UpdateVariety(ctx context.Context, oldName string, va
 func (r *mutationResolver) UpdatePurchase(ctx context.Context, id string, input model.PurchaseInput) (string, error) {
 	query := `
 	UPDATE purchases
-	SET retailer = $1, purchase_date = $2, quantity = $3, unit = $4, price = $5, notes = $6
+	SET retailer = $1, purchase_date = $2, quantity = $3, unit = $4, price = $5, notes = $6,
+		discount = COALESCE($8::numeric, discount),
+		discount_type = COALESCE($9::text, discount_type),
+		full_price = COALESCE($10::numeric, $5 + COALESCE($8::numeric, discount))
 	WHERE id = $7`
 	if _, err := r.DB.Exec(ctx, query, input.Retailer, input.Date,
-		input.Quantity, input.Unit, input.Price, input.Notes, id); err != nil {
+		input.Quantity, input.Unit, input.Price, input.Notes, id,
+		input.Discount, input.DiscountType, input.FullPrice); err != nil {
 		return "", fmt.Errorf("update purchase: %w", err)
 	}
 	return id, nil
@@ -172,9 +226,14 @@ func (r *mutationResolver) UpdatePurchase(ctx context.Context, id string, input
 
 // UpsertNutritionalValue is the resolver for the upsertNutritionalValue field.
 func (r *mutationResolver) UpsertNutritionalValue(ctx context.Context, productID string, varietyName string, input model.NutritionalValueInput) (string, error) {
+	nutrients, err := r.toNutrientsMap(ctx, input.Nutrients)
+	if err != nil {
+		return "", err
+	}
+
 	query := `
-	INSERT INTO nutritional_values_v2 (product_id, variety_name, unit, energy_value_kcal, fat, saturated_fat, carbohydrate, carbohydrate_sugars, fibre, protein, salt)
-	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11) ON CONFLICT (variety_name, product_id) 
+	INSERT INTO nutritional_values_v2 (product_id, variety_name, unit, energy_value_kcal, fat, saturated_fat, carbohydrate, carbohydrate_sugars, fibre, soluble_fibre, insoluble_fibre, protein, salt, nutrients)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, COALESCE($14::JSONB, '{}')) ON CONFLICT (variety_name, product_id) 
 	DO UPDATE SET     
 		unit = EXCLUDED.unit,
 		energy_value_kcal = EXCLUDED.energy_value_kcal,
@@ -183,8 +242,14 @@ func (r *mutationResolver) UpsertNutritionalValue(ctx context.Context, productID
 		carbohydrate = EXCLUDED.carbohydrate,
 		carbohydrate_sugars = EXCLUDED.carbohydrate_sugars,
 		fibre = EXCLUDED.fibre,
+		soluble_fibre = EXCLUDED.soluble_fibre,
+		insoluble_fibre = EXCLUDED.insoluble_fibre,
 		protein = EXCLUDED.protein,
-		salt = EXCLUDED.salt
+		salt = EXCLUDED.salt,
+		nutrients = COALESCE($14::JSONB, nutritional_values_v2.nutrients),
+		source = '',
+		source_id = '',
+		sourced_at = NULL
 	RETURNING id`
 
 	var id string
@@ -192,7 +257,8 @@ func (r *mutationResolver) UpsertNutritionalValue(ctx context.Context, productID
 		input.EnergyValueKcal, input.Fat,
 		input.SaturatedFat, input.Carbohydrate,
 		input.CarbohydrateSugars, input.Fibre,
-		input.Protein, input.Salt).Scan(&id); err != nil {
+		input.SolubleFibre, input.InsolubleFibre,
+		input.Protein, input.Salt, nutrients).Scan(&id); err != nil {
 		return "", fmt.Errorf("upsert nutritional value: %w", err)
 	}
 	return id, nil
@@ -250,8 +316,13 @@ func (r *mutationResolver) DeleteNutritionalValue(ctx context.Context, id string
 }
 
 // Products is the resolver for the products field.
-func (r *queryResolver) Products(ctx context.Context) ([]*model.Product, error) {
-	rows, err := r.DB.Query(ctx, "SELECT id, name FROM products ORDER BY name")
+func (r *queryResolver) Products(ctx context.Context, categoryID *string, tag *string) ([]*model.Product, error) {
+	query := `
+	SELECT id, name, category_id, reference_food_id, ARRAY(SELECT tag FROM product_tags WHERE product_id = products.id ORDER BY tag)
+	FROM products
+	WHERE ` + repository.ProductFilterCondition(1, 2) + `
+	ORDER BY name`
+	rows, err := r.DB.Query(ctx, query, deref(categoryID), deref(tag))
 	if err != nil {
 		return nil, fmt.Errorf("query db: %w", err)
 	}
@@ -259,15 +330,14 @@ func (r *queryResolver) Products(ctx context.Context) ([]*model.Product, error)
 
 	summaries := make([]*model.Product, 0)
 	for rows.Next() {
-		var id string
-		var name string
-		if err := rows.Scan(&id, &name); err != nil {
+		product := &model.Product{}
+		if err := rows.Scan(&product.ID, &product.Name, &product.CategoryID, &product.ReferenceFoodID, &product.Tags); err != nil {
 			return nil, fmt.Errorf("scan db: %w", err)
 		}
-		summaries = append(summaries, &model.Product{
-			ID:   id,
-			Name: name,
-		})
+		summaries = append(summaries, product)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("read products: %w", err)
 	}
 	return summaries, nil
 }
@@ -283,11 +353,24 @@ func (r *queryResolver) ProductAggregate(ctx context.Context, id string) (*model
 	}
 
 	product := &model.ProductAggregate{}
-	err := r.DB.QueryRow(ctx, "SELECT name FROM products WHERE id=$1", id).Scan(&product.Name)
+	var referenceFoodID *string
+	err := r.DB.QueryRow(ctx, "SELECT name, reference_food_id FROM products WHERE id=$1", id).Scan(&product.Name, &referenceFoodID)
 	if err != nil {
 		return nil, fmt.Errorf("query product name: %w", err)
 	}
 
+	if _, ok := fieldsSet["referenceFood"]; ok && referenceFoodID != nil {
+		fact, err := r.FoodDataService.GetFoodFact(ctx, *referenceFoodID)
+		if err != nil {
+			return nil, fmt.Errorf("get reference food: %w", err)
+		}
+		catalogue, err := r.nutrientCatalogue(ctx)
+		if err != nil {
+			return nil, err
+		}
+		product.ReferenceFood = toFoodFacts([]internalmodel.FoodFact{fact}, catalogue)[0]
+	}
+
 	query := `
 		SELECT variety_name FROM purchases WHERE product_id = $1
 		UNION
@@ -312,8 +395,14 @@ func (r *queryResolver) ProductAggregate(ctx context.Context, id string) (*model
 
 	nvs := make(map[string]*model.NutritionalValue)
 	if _, ok := fieldsSet["nutritionalValue"]; ok {
+		catalogue, err := r.nutrientCatalogue(ctx)
+		if err != nil {
+			return nil, err
+		}
+
 		query := `
-		SELECT id, variety_name, unit, energy_value_kcal, fat, saturated_fat, carbohydrate, carbohydrate_sugars, fibre, protein, salt
+		SELECT id, variety_name, unit, energy_value_kcal, fat, saturated_fat, carbohydrate, carbohydrate_sugars,
+			fibre, soluble_fibre, insoluble_fibre, protein, salt, nutrients, source, source_id, sourced_at
 		FROM nutritional_values_v2
 		WHERE product_id=$1`
 		rows, err := r.DB.Query(ctx, query, id)
@@ -325,11 +414,19 @@ func (r *queryResolver) ProductAggregate(ctx context.Context, id string) (*model
 		for rows.Next() {
 			nv := &model.NutritionalValue{}
 			var varietyName string
+			var nutrients map[string]float64
+			var sourcedAt *time.Time
 			if err := rows.Scan(&nv.ID, &varietyName, &nv.Unit, &nv.EnergyValueKcal,
 				&nv.Fat, &nv.SaturatedFat, &nv.Carbohydrate, &nv.CarbohydrateSugars,
-				&nv.Fibre, &nv.Protein, &nv.Salt); err != nil {
+				&nv.Fibre, &nv.SolubleFibre, &nv.InsolubleFibre, &nv.Protein, &nv.Salt, &nutrients,
+				&nv.Source, &nv.SourceID, &sourcedAt); err != nil {
 				return nil, fmt.Errorf("scan nutritional value: %w", err)
 			}
+			nv.Nutrients = toNutrientAmounts(nutrients, catalogue)
+			if sourcedAt != nil {
+				sourced := sourcedAt.Format(time.RFC3339)
+				nv.SourcedAt = &sourced
+			}
 			nvs[varietyName] = nv
 		}
 	}
@@ -337,7 +434,8 @@ func (r *queryResolver) ProductAggregate(ctx context.Context, id string) (*model
 	purchases := make(map[string][]*model.Purchase)
 	if _, ok := fieldsSet["purchases"]; ok {
 		query := `
-		SELECT id, variety_name, retailer, purchase_date, quantity, unit, price, notes
+		SELECT id, variety_name, retailer, purchase_date, quantity, unit, price, full_price, discount, discount_type, notes,
+			COALESCE((SELECT SUM(amount) FROM deposits WHERE deposits.purchase_id = purchases.id), 0)
 		FROM purchases
 		WHERE product_id=$1`
 		rows, err := r.DB.Query(ctx, query, id)
@@ -351,7 +449,8 @@ func (r *queryResolver) ProductAggregate(ctx context.Context, id string) (*model
 			var date *pgtype.Date
 			var varietyName string
 			if err := rows.Scan(&purchase.ID, &varietyName, &purchase.Retailer, &date,
-				&purchase.Quantity, &purchase.Unit, &purchase.Price, &purchase.Notes); err != nil {
+				&purchase.Quantity, &purchase.Unit, &purchase.Price, &purchase.FullPrice,
+				&purchase.Discount, &purchase.DiscountType, &purchase.Notes, &purchase.Deposit); err != nil {
 				return nil, fmt.Errorf("scan purchase: %w", err)
 			}
 			if date != nil {
@@ -371,6 +470,92 @@ func (r *queryResolver) ProductAggregate(ctx context.Context, id string) (*model
 	return product, nil
 }
 
+// DiscountSavings is the resolver for the discountSavings field.
+func (r *queryResolver) DiscountSavings(ctx context.Context, dateFrom string, dateTo string, categoryID *string, tag *string) ([]*model.DiscountSavings, error) {
+	query := `
+	SELECT retailer, discount_type, COUNT(*), SUM(full_price), SUM(price), SUM(full_price - price)
+	FROM purchases
+	JOIN products ON products.id = purchases.product_id
+	WHERE discount_type != '' AND purchase_date BETWEEN $1 AND $2
+		AND ` + repository.ProductFilterCondition(3, 4) + `
+	GROUP BY retailer, discount_type
+	ORDER BY retailer, discount_type`
+	rows, err := r.DB.Query(ctx, query, dateFrom, dateTo, deref(categoryID), deref(tag))
+	if err != nil {
+		return nil, fmt.Errorf("query discount savings: %w", err)
+	}
+	defer rows.Close()
+
+	savings := make([]*model.DiscountSavings, 0)
+	for rows.Next() {
+		saving := &model.DiscountSavings{}
+		if err := rows.Scan(&saving.Retailer, &saving.DiscountType, &saving.PurchaseCount,
+			&saving.FullPrice, &saving.PaidPrice, &saving.Saved); err != nil {
+			return nil, fmt.Errorf("scan discount savings: %w", err)
+		}
+		savings = append(savings, saving)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("read discount savings: %w", err)
+	}
+	return savings, nil
+}
+
+// PriceHistory is the resolver for the priceHistory field.
+func (r *queryResolver) PriceHistory(ctx context.Context, productID string, varietyName *string, unit string, bucket string, retailer *string) (*model.PriceHistory, error) {
+	var productName string
+	if err := r.DB.QueryRow(ctx, "SELECT name FROM products WHERE id=$1", productID).Scan(&productName); err != nil {
+		return nil, fmt.Errorf("query product name: %w", err)
+	}
+
+	names := []string{productName}
+	if varietyName != nil {
+		names = []string{*varietyName, productName}
+	}
+	conversion := internalmodel.UnitConversion{Product: names[0]}
+	query := `
+	SELECT density, piece_weight
+	FROM unit_conversions
+	WHERE product = ANY($1)
+	ORDER BY product = $2 DESC
+	LIMIT 1`
+	err := r.DB.QueryRow(ctx, query, names, names[0]).Scan(&conversion.Density, &conversion.PieceWeight)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("query unit conversion: %w", err)
+	}
+
+	query = `
+	SELECT id, variety_name, retailer, unit, quantity, price, purchase_date
+	FROM purchases
+	WHERE product_id = $1
+		AND purchase_date IS NOT NULL
+		AND ($2::TEXT IS NULL OR variety_name = $2)
+		AND ($3::TEXT IS NULL OR LOWER(retailer) = LOWER($3))`
+	rows, err := r.DB.Query(ctx, query, productID, varietyName, retailer)
+	if err != nil {
+		return nil, fmt.Errorf("query purchases: %w", err)
+	}
+	defer rows.Close()
+
+	var purchases []internalmodel.PurchasedProduct
+	for rows.Next() {
+		var p internalmodel.PurchasedProduct
+		if err := rows.Scan(&p.ID, &p.VarietyName, &p.Retailer, &p.Quantity.Unit, &p.Quantity.Amount, &p.Price, &p.Date); err != nil {
+			return nil, fmt.Errorf("scan purchase: %w", err)
+		}
+		purchases = append(purchases, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("read purchases: %w", err)
+	}
+
+	history, err := pricehistory.Build(purchases, unit, bucket, conversion)
+	if err != nil {
+		return nil, fmt.Errorf("build price history: %w", err)
+	}
+	return toPriceHistory(history), nil
+}
+
 // Mutation returns MutationResolver implementation.
 func (r *Resolver) Mutation() MutationResolver { return &mutationResolver{r} }
 
@@ -132,10 +132,16 @@ func (ec *executionContext) fieldContext_CalculatedDay_recipes(_ context.Context
 				return ec.fieldContext_CalculatedRecipe_carbohydrateSugars(ctx, field)
 			case "fibre":
 				return ec.fieldContext_CalculatedRecipe_fibre(ctx, field)
+			case "solubleFibre":
+				return ec.fieldContext_CalculatedRecipe_solubleFibre(ctx, field)
+			case "insolubleFibre":
+				return ec.fieldContext_CalculatedRecipe_insolubleFibre(ctx, field)
 			case "protein":
 				return ec.fieldContext_CalculatedRecipe_protein(ctx, field)
 			case "salt":
 				return ec.fieldContext_CalculatedRecipe_salt(ctx, field)
+			case "nutrients":
+				return ec.fieldContext_CalculatedRecipe_nutrients(ctx, field)
 			}
 			return nil, fmt.Errorf("no field named %q was found under type CalculatedRecipe", field.Name)
 		},
@@ -451,6 +457,94 @@ func (ec *executionContext) fieldContext_CalculatedDay_fibre(_ context.Context,
 	return fc, nil
 }
 
+func (ec *executionContext) _CalculatedDay_solubleFibre(ctx context.Context, field graphql.CollectedField, obj *model.CalculatedDay) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_CalculatedDay_solubleFibre(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.SolubleFibre, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(float64)
+	fc.Result = res
+	return ec.marshalNFloat2float64(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_CalculatedDay_solubleFibre(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "CalculatedDay",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Float does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _CalculatedDay_insolubleFibre(ctx context.Context, field graphql.CollectedField, obj *model.CalculatedDay) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_CalculatedDay_insolubleFibre(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.InsolubleFibre, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(float64)
+	fc.Result = res
+	return ec.marshalNFloat2float64(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_CalculatedDay_insolubleFibre(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "CalculatedDay",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Float does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
 func (ec *executionContext) _CalculatedDay_protein(ctx context.Context, field graphql.CollectedField, obj *model.CalculatedDay) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_CalculatedDay_protein(ctx, field)
 	if err != nil {
@@ -539,6 +633,62 @@ func (ec *executionContext) fieldContext_CalculatedDay_salt(_ context.Context, f
 	return fc, nil
 }
 
+func (ec *executionContext) _CalculatedDay_nutrients(ctx context.Context, field graphql.CollectedField, obj *model.CalculatedDay) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_CalculatedDay_nutrients(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Nutrients, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]*model.NutrientAmount)
+	fc.Result = res
+	return ec.marshalNNutrientAmount2ᚕᚖgithubᚗcomᚋSarunasBuciusᚋnutriᚑpriceᚑserverᚋgraphᚋmodelᚐNutrientAmountᚄ(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_CalculatedDay_nutrients(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "CalculatedDay",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "code":
+				return ec.fieldContext_NutrientAmount_code(ctx, field)
+			case "name":
+				return ec.fieldContext_NutrientAmount_name(ctx, field)
+			case "unit":
+				return ec.fieldContext_NutrientAmount_unit(ctx, field)
+			case "amount":
+				return ec.fieldContext_NutrientAmount_amount(ctx, field)
+			case "dailyReferenceShare":
+				return ec.fieldContext_NutrientAmount_dailyReferenceShare(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type NutrientAmount", field.Name)
+		},
+	}
+	return fc, nil
+}
+
 func (ec *executionContext) _CalculatedProduct_product(ctx context.Context, field graphql.CollectedField, obj *model.CalculatedProduct) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_CalculatedProduct_product(ctx, field)
 	if err != nil {
@@ -1023,8 +1173,8 @@ func (ec *executionContext) fieldContext_CalculatedProduct_fibre(_ context.Conte
 	return fc, nil
 }
 
-func (ec *executionContext) _CalculatedProduct_protein(ctx context.Context, field graphql.CollectedField, obj *model.CalculatedProduct) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_CalculatedProduct_protein(ctx, field)
+func (ec *executionContext) _CalculatedProduct_solubleFibre(ctx context.Context, field graphql.CollectedField, obj *model.CalculatedProduct) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_CalculatedProduct_solubleFibre(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -1037,7 +1187,7 @@ func (ec *executionContext) _CalculatedProduct_protein(ctx context.Context, fiel
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Protein, nil
+		return obj.SolubleFibre, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -1054,7 +1204,7 @@ func (ec *executionContext) _CalculatedProduct_protein(ctx context.Context, fiel
 	return ec.marshalNFloat2float64(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_CalculatedProduct_protein(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_CalculatedProduct_solubleFibre(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
 		Object:     "CalculatedProduct",
 		Field:      field,
@@ -1067,8 +1217,8 @@ func (ec *executionContext) fieldContext_CalculatedProduct_protein(_ context.Con
 	return fc, nil
 }
 
-func (ec *executionContext) _CalculatedProduct_salt(ctx context.Context, field graphql.CollectedField, obj *model.CalculatedProduct) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_CalculatedProduct_salt(ctx, field)
+func (ec *executionContext) _CalculatedProduct_insolubleFibre(ctx context.Context, field graphql.CollectedField, obj *model.CalculatedProduct) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_CalculatedProduct_insolubleFibre(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -1081,7 +1231,7 @@ func (ec *executionContext) _CalculatedProduct_salt(ctx context.Context, field g
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Salt, nil
+		return obj.InsolubleFibre, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -1098,7 +1248,7 @@ func (ec *executionContext) _CalculatedProduct_salt(ctx context.Context, field g
 	return ec.marshalNFloat2float64(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_CalculatedProduct_salt(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_CalculatedProduct_insolubleFibre(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
 		Object:     "CalculatedProduct",
 		Field:      field,
@@ -1111,8 +1261,8 @@ func (ec *executionContext) fieldContext_CalculatedProduct_salt(_ context.Contex
 	return fc, nil
 }
 
-func (ec *executionContext) _CalculatedRecipe_recipeName(ctx context.Context, field graphql.CollectedField, obj *model.CalculatedRecipe) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_CalculatedRecipe_recipeName(ctx, field)
+func (ec *executionContext) _CalculatedProduct_protein(ctx context.Context, field graphql.CollectedField, obj *model.CalculatedProduct) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_CalculatedProduct_protein(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -1125,7 +1275,7 @@ func (ec *executionContext) _CalculatedRecipe_recipeName(ctx context.Context, fi
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.RecipeName, nil
+		return obj.Protein, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -1137,26 +1287,26 @@ func (ec *executionContext) _CalculatedRecipe_recipeName(ctx context.Context, fi
 		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(float64)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalNFloat2float64(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_CalculatedRecipe_recipeName(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_CalculatedProduct_protein(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "CalculatedRecipe",
+		Object:     "CalculatedProduct",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type Float does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _CalculatedRecipe_products(ctx context.Context, field graphql.CollectedField, obj *model.CalculatedRecipe) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_CalculatedRecipe_products(ctx, field)
+func (ec *executionContext) _CalculatedProduct_salt(ctx context.Context, field graphql.CollectedField, obj *model.CalculatedProduct) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_CalculatedProduct_salt(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -1169,7 +1319,7 @@ func (ec *executionContext) _CalculatedRecipe_products(ctx context.Context, fiel
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Products, nil
+		return obj.Salt, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -1181,54 +1331,26 @@ func (ec *executionContext) _CalculatedRecipe_products(ctx context.Context, fiel
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]*model.CalculatedProduct)
+	res := resTmp.(float64)
 	fc.Result = res
-	return ec.marshalNCalculatedProduct2ᚕᚖgithubᚗcomᚋSarunasBuciusᚋnutriᚑpriceᚑserverᚋgraphᚋmodelᚐCalculatedProductᚄ(ctx, field.Selections, res)
+	return ec.marshalNFloat2float64(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_CalculatedRecipe_products(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_CalculatedProduct_salt(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "CalculatedRecipe",
+		Object:     "CalculatedProduct",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "product":
-				return ec.fieldContext_CalculatedProduct_product(ctx, field)
-			case "varietyName":
-				return ec.fieldContext_CalculatedProduct_varietyName(ctx, field)
-			case "price":
-				return ec.fieldContext_CalculatedProduct_price(ctx, field)
-			case "unit":
-				return ec.fieldContext_CalculatedProduct_unit(ctx, field)
-			case "quantity":
-				return ec.fieldContext_CalculatedProduct_quantity(ctx, field)
-			case "energyValueKcal":
-				return ec.fieldContext_CalculatedProduct_energyValueKcal(ctx, field)
-			case "fat":
-				return ec.fieldContext_CalculatedProduct_fat(ctx, field)
-			case "saturatedFat":
-				return ec.fieldContext_CalculatedProduct_saturatedFat(ctx, field)
-			case "carbohydrate":
-				return ec.fieldContext_CalculatedProduct_carbohydrate(ctx, field)
-			case "carbohydrateSugars":
-				return ec.fieldContext_CalculatedProduct_carbohydrateSugars(ctx, field)
-			case "fibre":
-				return ec.fieldContext_CalculatedProduct_fibre(ctx, field)
-			case "protein":
-				return ec.fieldContext_CalculatedProduct_protein(ctx, field)
-			case "salt":
-				return ec.fieldContext_CalculatedProduct_salt(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type CalculatedProduct", field.Name)
+			return nil, errors.New("field of type Float does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _CalculatedRecipe_portion(ctx context.Context, field graphql.CollectedField, obj *model.CalculatedRecipe) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_CalculatedRecipe_portion(ctx, field)
+func (ec *executionContext) _CalculatedProduct_nutrients(ctx context.Context, field graphql.CollectedField, obj *model.CalculatedProduct) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_CalculatedProduct_nutrients(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -1241,7 +1363,7 @@ func (ec *executionContext) _CalculatedRecipe_portion(ctx context.Context, field
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Portion, nil
+		return obj.Nutrients, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -1253,26 +1375,38 @@ func (ec *executionContext) _CalculatedRecipe_portion(ctx context.Context, field
 		}
 		return graphql.Null
 	}
-	res := resTmp.(float64)
+	res := resTmp.([]*model.NutrientAmount)
 	fc.Result = res
-	return ec.marshalNFloat2float64(ctx, field.Selections, res)
+	return ec.marshalNNutrientAmount2ᚕᚖgithubᚗcomᚋSarunasBuciusᚋnutriᚑpriceᚑserverᚋgraphᚋmodelᚐNutrientAmountᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_CalculatedRecipe_portion(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_CalculatedProduct_nutrients(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "CalculatedRecipe",
+		Object:     "CalculatedProduct",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Float does not have child fields")
+			switch field.Name {
+			case "code":
+				return ec.fieldContext_NutrientAmount_code(ctx, field)
+			case "name":
+				return ec.fieldContext_NutrientAmount_name(ctx, field)
+			case "unit":
+				return ec.fieldContext_NutrientAmount_unit(ctx, field)
+			case "amount":
+				return ec.fieldContext_NutrientAmount_amount(ctx, field)
+			case "dailyReferenceShare":
+				return ec.fieldContext_NutrientAmount_dailyReferenceShare(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type NutrientAmount", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _CalculatedRecipe_price(ctx context.Context, field graphql.CollectedField, obj *model.CalculatedRecipe) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_CalculatedRecipe_price(ctx, field)
+func (ec *executionContext) _CalculatedProduct_priceSources(ctx context.Context, field graphql.CollectedField, obj *model.CalculatedProduct) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_CalculatedProduct_priceSources(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -1285,7 +1419,7 @@ func (ec *executionContext) _CalculatedRecipe_price(ctx context.Context, field g
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Price, nil
+		return obj.PriceSources, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -1297,26 +1431,42 @@ func (ec *executionContext) _CalculatedRecipe_price(ctx context.Context, field g
 		}
 		return graphql.Null
 	}
-	res := resTmp.(float64)
+	res := resTmp.([]*model.PriceSource)
 	fc.Result = res
-	return ec.marshalNFloat2float64(ctx, field.Selections, res)
+	return ec.marshalNPriceSource2ᚕᚖgithubᚗcomᚋSarunasBuciusᚋnutriᚑpriceᚑserverᚋgraphᚋmodelᚐPriceSourceᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_CalculatedRecipe_price(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_CalculatedProduct_priceSources(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "CalculatedRecipe",
+		Object:     "CalculatedProduct",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Float does not have child fields")
+			switch field.Name {
+			case "purchaseId":
+				return ec.fieldContext_PriceSource_purchaseId(ctx, field)
+			case "varietyName":
+				return ec.fieldContext_PriceSource_varietyName(ctx, field)
+			case "retailer":
+				return ec.fieldContext_PriceSource_retailer(ctx, field)
+			case "date":
+				return ec.fieldContext_PriceSource_date(ctx, field)
+			case "price":
+				return ec.fieldContext_PriceSource_price(ctx, field)
+			case "unit":
+				return ec.fieldContext_PriceSource_unit(ctx, field)
+			case "quantity":
+				return ec.fieldContext_PriceSource_quantity(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type PriceSource", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _CalculatedRecipe_energyValueKcal(ctx context.Context, field graphql.CollectedField, obj *model.CalculatedRecipe) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_CalculatedRecipe_energyValueKcal(ctx, field)
+func (ec *executionContext) _CalculatedRecipe_recipeName(ctx context.Context, field graphql.CollectedField, obj *model.CalculatedRecipe) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_CalculatedRecipe_recipeName(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -1329,7 +1479,7 @@ func (ec *executionContext) _CalculatedRecipe_energyValueKcal(ctx context.Contex
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.EnergyValueKcal, nil
+		return obj.RecipeName, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -1341,26 +1491,26 @@ func (ec *executionContext) _CalculatedRecipe_energyValueKcal(ctx context.Contex
 		}
 		return graphql.Null
 	}
-	res := resTmp.(float64)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalNFloat2float64(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_CalculatedRecipe_energyValueKcal(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_CalculatedRecipe_recipeName(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
 		Object:     "CalculatedRecipe",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Float does not have child fields")
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _CalculatedRecipe_fat(ctx context.Context, field graphql.CollectedField, obj *model.CalculatedRecipe) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_CalculatedRecipe_fat(ctx, field)
+func (ec *executionContext) _CalculatedRecipe_products(ctx context.Context, field graphql.CollectedField, obj *model.CalculatedRecipe) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_CalculatedRecipe_products(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -1373,7 +1523,7 @@ func (ec *executionContext) _CalculatedRecipe_fat(ctx context.Context, field gra
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Fat, nil
+		return obj.Products, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -1385,33 +1535,245 @@ func (ec *executionContext) _CalculatedRecipe_fat(ctx context.Context, field gra
 		}
 		return graphql.Null
 	}
-	res := resTmp.(float64)
+	res := resTmp.([]*model.CalculatedProduct)
 	fc.Result = res
-	return ec.marshalNFloat2float64(ctx, field.Selections, res)
+	return ec.marshalNCalculatedProduct2ᚕᚖgithubᚗcomᚋSarunasBuciusᚋnutriᚑpriceᚑserverᚋgraphᚋmodelᚐCalculatedProductᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_CalculatedRecipe_fat(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_CalculatedRecipe_products(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
 		Object:     "CalculatedRecipe",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Float does not have child fields")
-		},
-	}
-	return fc, nil
-}
-
-func (ec *executionContext) _CalculatedRecipe_saturatedFat(ctx context.Context, field graphql.CollectedField, obj *model.CalculatedRecipe) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_CalculatedRecipe_saturatedFat(ctx, field)
-	if err != nil {
-		return graphql.Null
-	}
-	ctx = graphql.WithFieldContext(ctx, fc)
-	defer func() {
-		if r := recover(); r != nil {
-			ec.Error(ctx, ec.Recover(ctx, r))
+			switch field.Name {
+			case "product":
+				return ec.fieldContext_CalculatedProduct_product(ctx, field)
+			case "varietyName":
+				return ec.fieldContext_CalculatedProduct_varietyName(ctx, field)
+			case "price":
+				return ec.fieldContext_CalculatedProduct_price(ctx, field)
+			case "unit":
+				return ec.fieldContext_CalculatedProduct_unit(ctx, field)
+			case "quantity":
+				return ec.fieldContext_CalculatedProduct_quantity(ctx, field)
+			case "energyValueKcal":
+				return ec.fieldContext_CalculatedProduct_energyValueKcal(ctx, field)
+			case "fat":
+				return ec.fieldContext_CalculatedProduct_fat(ctx, field)
+			case "saturatedFat":
+				return ec.fieldContext_CalculatedProduct_saturatedFat(ctx, field)
+			case "carbohydrate":
+				return ec.fieldContext_CalculatedProduct_carbohydrate(ctx, field)
+			case "carbohydrateSugars":
+				return ec.fieldContext_CalculatedProduct_carbohydrateSugars(ctx, field)
+			case "fibre":
+				return ec.fieldContext_CalculatedProduct_fibre(ctx, field)
+			case "solubleFibre":
+				return ec.fieldContext_CalculatedProduct_solubleFibre(ctx, field)
+			case "insolubleFibre":
+				return ec.fieldContext_CalculatedProduct_insolubleFibre(ctx, field)
+			case "protein":
+				return ec.fieldContext_CalculatedProduct_protein(ctx, field)
+			case "salt":
+				return ec.fieldContext_CalculatedProduct_salt(ctx, field)
+			case "nutrients":
+				return ec.fieldContext_CalculatedProduct_nutrients(ctx, field)
+			case "priceSources":
+				return ec.fieldContext_CalculatedProduct_priceSources(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type CalculatedProduct", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _CalculatedRecipe_portion(ctx context.Context, field graphql.CollectedField, obj *model.CalculatedRecipe) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_CalculatedRecipe_portion(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Portion, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(float64)
+	fc.Result = res
+	return ec.marshalNFloat2float64(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_CalculatedRecipe_portion(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "CalculatedRecipe",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Float does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _CalculatedRecipe_price(ctx context.Context, field graphql.CollectedField, obj *model.CalculatedRecipe) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_CalculatedRecipe_price(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Price, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(float64)
+	fc.Result = res
+	return ec.marshalNFloat2float64(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_CalculatedRecipe_price(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "CalculatedRecipe",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Float does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _CalculatedRecipe_energyValueKcal(ctx context.Context, field graphql.CollectedField, obj *model.CalculatedRecipe) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_CalculatedRecipe_energyValueKcal(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.EnergyValueKcal, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(float64)
+	fc.Result = res
+	return ec.marshalNFloat2float64(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_CalculatedRecipe_energyValueKcal(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "CalculatedRecipe",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Float does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _CalculatedRecipe_fat(ctx context.Context, field graphql.CollectedField, obj *model.CalculatedRecipe) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_CalculatedRecipe_fat(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Fat, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(float64)
+	fc.Result = res
+	return ec.marshalNFloat2float64(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_CalculatedRecipe_fat(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "CalculatedRecipe",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Float does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _CalculatedRecipe_saturatedFat(ctx context.Context, field graphql.CollectedField, obj *model.CalculatedRecipe) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_CalculatedRecipe_saturatedFat(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
 			ret = graphql.Null
 		}
 	}()
@@ -1579,8 +1941,8 @@ func (ec *executionContext) fieldContext_CalculatedRecipe_fibre(_ context.Contex
 	return fc, nil
 }
 
-func (ec *executionContext) _CalculatedRecipe_protein(ctx context.Context, field graphql.CollectedField, obj *model.CalculatedRecipe) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_CalculatedRecipe_protein(ctx, field)
+func (ec *executionContext) _CalculatedRecipe_solubleFibre(ctx context.Context, field graphql.CollectedField, obj *model.CalculatedRecipe) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_CalculatedRecipe_solubleFibre(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -1593,7 +1955,7 @@ func (ec *executionContext) _CalculatedRecipe_protein(ctx context.Context, field
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Protein, nil
+		return obj.SolubleFibre, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -1610,7 +1972,7 @@ func (ec *executionContext) _CalculatedRecipe_protein(ctx context.Context, field
 	return ec.marshalNFloat2float64(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_CalculatedRecipe_protein(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_CalculatedRecipe_solubleFibre(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
 		Object:     "CalculatedRecipe",
 		Field:      field,
@@ -1623,8 +1985,8 @@ func (ec *executionContext) fieldContext_CalculatedRecipe_protein(_ context.Cont
 	return fc, nil
 }
 
-func (ec *executionContext) _CalculatedRecipe_salt(ctx context.Context, field graphql.CollectedField, obj *model.CalculatedRecipe) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_CalculatedRecipe_salt(ctx, field)
+func (ec *executionContext) _CalculatedRecipe_insolubleFibre(ctx context.Context, field graphql.CollectedField, obj *model.CalculatedRecipe) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_CalculatedRecipe_insolubleFibre(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -1637,7 +1999,7 @@ func (ec *executionContext) _CalculatedRecipe_salt(ctx context.Context, field gr
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Salt, nil
+		return obj.InsolubleFibre, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -1654,7 +2016,7 @@ func (ec *executionContext) _CalculatedRecipe_salt(ctx context.Context, field gr
 	return ec.marshalNFloat2float64(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_CalculatedRecipe_salt(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_CalculatedRecipe_insolubleFibre(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
 		Object:     "CalculatedRecipe",
 		Field:      field,
@@ -1667,8 +2029,8 @@ func (ec *executionContext) fieldContext_CalculatedRecipe_salt(_ context.Context
 	return fc, nil
 }
 
-func (ec *executionContext) _Ingredient_product(ctx context.Context, field graphql.CollectedField, obj *model.Ingredient) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Ingredient_product(ctx, field)
+func (ec *executionContext) _CalculatedRecipe_protein(ctx context.Context, field graphql.CollectedField, obj *model.CalculatedRecipe) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_CalculatedRecipe_protein(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -1681,7 +2043,7 @@ func (ec *executionContext) _Ingredient_product(ctx context.Context, field graph
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Product, nil
+		return obj.Protein, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -1693,26 +2055,26 @@ func (ec *executionContext) _Ingredient_product(ctx context.Context, field graph
 		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(float64)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalNFloat2float64(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Ingredient_product(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_CalculatedRecipe_protein(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Ingredient",
+		Object:     "CalculatedRecipe",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type Float does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Ingredient_quantity(ctx context.Context, field graphql.CollectedField, obj *model.Ingredient) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Ingredient_quantity(ctx, field)
+func (ec *executionContext) _CalculatedRecipe_salt(ctx context.Context, field graphql.CollectedField, obj *model.CalculatedRecipe) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_CalculatedRecipe_salt(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -1725,7 +2087,7 @@ func (ec *executionContext) _Ingredient_quantity(ctx context.Context, field grap
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Quantity, nil
+		return obj.Salt, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -1739,24 +2101,486 @@ func (ec *executionContext) _Ingredient_quantity(ctx context.Context, field grap
 	}
 	res := resTmp.(float64)
 	fc.Result = res
-	return ec.marshalNFloat2float64(ctx, field.Selections, res)
+	return ec.marshalNFloat2float64(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_CalculatedRecipe_salt(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "CalculatedRecipe",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Float does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _CalculatedRecipe_nutrients(ctx context.Context, field graphql.CollectedField, obj *model.CalculatedRecipe) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_CalculatedRecipe_nutrients(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Nutrients, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]*model.NutrientAmount)
+	fc.Result = res
+	return ec.marshalNNutrientAmount2ᚕᚖgithubᚗcomᚋSarunasBuciusᚋnutriᚑpriceᚑserverᚋgraphᚋmodelᚐNutrientAmountᚄ(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_CalculatedRecipe_nutrients(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "CalculatedRecipe",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "code":
+				return ec.fieldContext_NutrientAmount_code(ctx, field)
+			case "name":
+				return ec.fieldContext_NutrientAmount_name(ctx, field)
+			case "unit":
+				return ec.fieldContext_NutrientAmount_unit(ctx, field)
+			case "amount":
+				return ec.fieldContext_NutrientAmount_amount(ctx, field)
+			case "dailyReferenceShare":
+				return ec.fieldContext_NutrientAmount_dailyReferenceShare(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type NutrientAmount", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Ingredient_product(ctx context.Context, field graphql.CollectedField, obj *model.Ingredient) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Ingredient_product(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Product, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Ingredient_product(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Ingredient",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Ingredient_quantity(ctx context.Context, field graphql.CollectedField, obj *model.Ingredient) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Ingredient_quantity(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Quantity, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(float64)
+	fc.Result = res
+	return ec.marshalNFloat2float64(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Ingredient_quantity(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Ingredient",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Float does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Ingredient_unit(ctx context.Context, field graphql.CollectedField, obj *model.Ingredient) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Ingredient_unit(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Unit, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Ingredient_unit(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Ingredient",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Ingredient_notes(ctx context.Context, field graphql.CollectedField, obj *model.Ingredient) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Ingredient_notes(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Notes, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Ingredient_notes(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Ingredient",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _PreparedRecipeAggregate_recipeName(ctx context.Context, field graphql.CollectedField, obj *model.PreparedRecipeAggregate) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PreparedRecipeAggregate_recipeName(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.RecipeName, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_PreparedRecipeAggregate_recipeName(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "PreparedRecipeAggregate",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _PreparedRecipeAggregate_steps(ctx context.Context, field graphql.CollectedField, obj *model.PreparedRecipeAggregate) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PreparedRecipeAggregate_steps(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Steps, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]string)
+	fc.Result = res
+	return ec.marshalNString2ᚕstringᚄ(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_PreparedRecipeAggregate_steps(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "PreparedRecipeAggregate",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _PreparedRecipeAggregate_notes(ctx context.Context, field graphql.CollectedField, obj *model.PreparedRecipeAggregate) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PreparedRecipeAggregate_notes(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Notes, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_PreparedRecipeAggregate_notes(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "PreparedRecipeAggregate",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _PreparedRecipeAggregate_ingredients(ctx context.Context, field graphql.CollectedField, obj *model.PreparedRecipeAggregate) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PreparedRecipeAggregate_ingredients(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Ingredients, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]*model.Ingredient)
+	fc.Result = res
+	return ec.marshalNIngredient2ᚕᚖgithubᚗcomᚋSarunasBuciusᚋnutriᚑpriceᚑserverᚋgraphᚋmodelᚐIngredientᚄ(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_PreparedRecipeAggregate_ingredients(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "PreparedRecipeAggregate",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "product":
+				return ec.fieldContext_Ingredient_product(ctx, field)
+			case "quantity":
+				return ec.fieldContext_Ingredient_quantity(ctx, field)
+			case "unit":
+				return ec.fieldContext_Ingredient_unit(ctx, field)
+			case "notes":
+				return ec.fieldContext_Ingredient_notes(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Ingredient", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _PreparedRecipeAggregate_preparedDate(ctx context.Context, field graphql.CollectedField, obj *model.PreparedRecipeAggregate) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PreparedRecipeAggregate_preparedDate(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.PreparedDate, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Ingredient_quantity(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_PreparedRecipeAggregate_preparedDate(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Ingredient",
+		Object:     "PreparedRecipeAggregate",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Float does not have child fields")
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Ingredient_unit(ctx context.Context, field graphql.CollectedField, obj *model.Ingredient) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Ingredient_unit(ctx, field)
+func (ec *executionContext) _PreparedRecipeAggregate_portion(ctx context.Context, field graphql.CollectedField, obj *model.PreparedRecipeAggregate) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PreparedRecipeAggregate_portion(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -1769,7 +2593,7 @@ func (ec *executionContext) _Ingredient_unit(ctx context.Context, field graphql.
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Unit, nil
+		return obj.Portion, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -1781,26 +2605,26 @@ func (ec *executionContext) _Ingredient_unit(ctx context.Context, field graphql.
 		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(float64)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalNFloat2float64(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Ingredient_unit(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_PreparedRecipeAggregate_portion(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Ingredient",
+		Object:     "PreparedRecipeAggregate",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type Float does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Ingredient_notes(ctx context.Context, field graphql.CollectedField, obj *model.Ingredient) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Ingredient_notes(ctx, field)
+func (ec *executionContext) _PriceSource_purchaseId(ctx context.Context, field graphql.CollectedField, obj *model.PriceSource) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PriceSource_purchaseId(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -1813,7 +2637,7 @@ func (ec *executionContext) _Ingredient_notes(ctx context.Context, field graphql
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Notes, nil
+		return obj.PurchaseID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -1825,26 +2649,26 @@ func (ec *executionContext) _Ingredient_notes(ctx context.Context, field graphql
 		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(int32)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalNInt2int32(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Ingredient_notes(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_PriceSource_purchaseId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Ingredient",
+		Object:     "PriceSource",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type Int does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _PreparedRecipeAggregate_recipeName(ctx context.Context, field graphql.CollectedField, obj *model.PreparedRecipeAggregate) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_PreparedRecipeAggregate_recipeName(ctx, field)
+func (ec *executionContext) _PriceSource_varietyName(ctx context.Context, field graphql.CollectedField, obj *model.PriceSource) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PriceSource_varietyName(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -1857,7 +2681,7 @@ func (ec *executionContext) _PreparedRecipeAggregate_recipeName(ctx context.Cont
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.RecipeName, nil
+		return obj.VarietyName, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -1874,9 +2698,9 @@ func (ec *executionContext) _PreparedRecipeAggregate_recipeName(ctx context.Cont
 	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_PreparedRecipeAggregate_recipeName(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_PriceSource_varietyName(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "PreparedRecipeAggregate",
+		Object:     "PriceSource",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -1887,8 +2711,8 @@ func (ec *executionContext) fieldContext_PreparedRecipeAggregate_recipeName(_ co
 	return fc, nil
 }
 
-func (ec *executionContext) _PreparedRecipeAggregate_steps(ctx context.Context, field graphql.CollectedField, obj *model.PreparedRecipeAggregate) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_PreparedRecipeAggregate_steps(ctx, field)
+func (ec *executionContext) _PriceSource_retailer(ctx context.Context, field graphql.CollectedField, obj *model.PriceSource) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PriceSource_retailer(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -1901,7 +2725,7 @@ func (ec *executionContext) _PreparedRecipeAggregate_steps(ctx context.Context,
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Steps, nil
+		return obj.Retailer, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -1913,14 +2737,14 @@ func (ec *executionContext) _PreparedRecipeAggregate_steps(ctx context.Context,
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]string)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalNString2ᚕstringᚄ(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_PreparedRecipeAggregate_steps(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_PriceSource_retailer(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "PreparedRecipeAggregate",
+		Object:     "PriceSource",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -1931,8 +2755,8 @@ func (ec *executionContext) fieldContext_PreparedRecipeAggregate_steps(_ context
 	return fc, nil
 }
 
-func (ec *executionContext) _PreparedRecipeAggregate_notes(ctx context.Context, field graphql.CollectedField, obj *model.PreparedRecipeAggregate) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_PreparedRecipeAggregate_notes(ctx, field)
+func (ec *executionContext) _PriceSource_date(ctx context.Context, field graphql.CollectedField, obj *model.PriceSource) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PriceSource_date(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -1945,7 +2769,7 @@ func (ec *executionContext) _PreparedRecipeAggregate_notes(ctx context.Context,
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Notes, nil
+		return obj.Date, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -1962,9 +2786,9 @@ func (ec *executionContext) _PreparedRecipeAggregate_notes(ctx context.Context,
 	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_PreparedRecipeAggregate_notes(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_PriceSource_date(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "PreparedRecipeAggregate",
+		Object:     "PriceSource",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -1975,8 +2799,8 @@ func (ec *executionContext) fieldContext_PreparedRecipeAggregate_notes(_ context
 	return fc, nil
 }
 
-func (ec *executionContext) _PreparedRecipeAggregate_ingredients(ctx context.Context, field graphql.CollectedField, obj *model.PreparedRecipeAggregate) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_PreparedRecipeAggregate_ingredients(ctx, field)
+func (ec *executionContext) _PriceSource_price(ctx context.Context, field graphql.CollectedField, obj *model.PriceSource) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PriceSource_price(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -1989,7 +2813,7 @@ func (ec *executionContext) _PreparedRecipeAggregate_ingredients(ctx context.Con
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Ingredients, nil
+		return obj.Price, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -2001,36 +2825,26 @@ func (ec *executionContext) _PreparedRecipeAggregate_ingredients(ctx context.Con
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]*model.Ingredient)
+	res := resTmp.(float64)
 	fc.Result = res
-	return ec.marshalNIngredient2ᚕᚖgithubᚗcomᚋSarunasBuciusᚋnutriᚑpriceᚑserverᚋgraphᚋmodelᚐIngredientᚄ(ctx, field.Selections, res)
+	return ec.marshalNFloat2float64(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_PreparedRecipeAggregate_ingredients(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_PriceSource_price(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "PreparedRecipeAggregate",
+		Object:     "PriceSource",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "product":
-				return ec.fieldContext_Ingredient_product(ctx, field)
-			case "quantity":
-				return ec.fieldContext_Ingredient_quantity(ctx, field)
-			case "unit":
-				return ec.fieldContext_Ingredient_unit(ctx, field)
-			case "notes":
-				return ec.fieldContext_Ingredient_notes(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type Ingredient", field.Name)
+			return nil, errors.New("field of type Float does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _PreparedRecipeAggregate_preparedDate(ctx context.Context, field graphql.CollectedField, obj *model.PreparedRecipeAggregate) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_PreparedRecipeAggregate_preparedDate(ctx, field)
+func (ec *executionContext) _PriceSource_unit(ctx context.Context, field graphql.CollectedField, obj *model.PriceSource) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PriceSource_unit(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -2043,7 +2857,7 @@ func (ec *executionContext) _PreparedRecipeAggregate_preparedDate(ctx context.Co
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.PreparedDate, nil
+		return obj.Unit, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -2060,9 +2874,9 @@ func (ec *executionContext) _PreparedRecipeAggregate_preparedDate(ctx context.Co
 	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_PreparedRecipeAggregate_preparedDate(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_PriceSource_unit(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "PreparedRecipeAggregate",
+		Object:     "PriceSource",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -2073,8 +2887,8 @@ func (ec *executionContext) fieldContext_PreparedRecipeAggregate_preparedDate(_
 	return fc, nil
 }
 
-func (ec *executionContext) _PreparedRecipeAggregate_portion(ctx context.Context, field graphql.CollectedField, obj *model.PreparedRecipeAggregate) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_PreparedRecipeAggregate_portion(ctx, field)
+func (ec *executionContext) _PriceSource_quantity(ctx context.Context, field graphql.CollectedField, obj *model.PriceSource) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PriceSource_quantity(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -2087,7 +2901,7 @@ func (ec *executionContext) _PreparedRecipeAggregate_portion(ctx context.Context
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Portion, nil
+		return obj.Quantity, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -2104,9 +2918,9 @@ func (ec *executionContext) _PreparedRecipeAggregate_portion(ctx context.Context
 	return ec.marshalNFloat2float64(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_PreparedRecipeAggregate_portion(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_PriceSource_quantity(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "PreparedRecipeAggregate",
+		Object:     "PriceSource",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -2495,6 +3309,47 @@ func (ec *executionContext) unmarshalInputPreparedRecipeInput(ctx context.Contex
 	return it, nil
 }
 
+func (ec *executionContext) unmarshalInputPriceStrategyInput(ctx context.Context, obj any) (model.PriceStrategyInput, error) {
+	var it model.PriceStrategyInput
+	asMap := map[string]any{}
+	for k, v := range obj.(map[string]any) {
+		asMap[k] = v
+	}
+
+	fieldsInOrder := [...]string{"strategy", "days", "retailer"}
+	for _, k := range fieldsInOrder {
+		v, ok := asMap[k]
+		if !ok {
+			continue
+		}
+		switch k {
+		case "strategy":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("strategy"))
+			data, err := ec.unmarshalNString2string(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.Strategy = data
+		case "days":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("days"))
+			data, err := ec.unmarshalOInt2ᚖint32(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.Days = data
+		case "retailer":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("retailer"))
+			data, err := ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.Retailer = data
+		}
+	}
+
+	return it, nil
+}
+
 func (ec *executionContext) unmarshalInputRecipeInput(ctx context.Context, obj any) (model.RecipeInput, error) {
 	var it model.RecipeInput
 	asMap := map[string]any{}
@@ -2614,6 +3469,16 @@ func (ec *executionContext) _CalculatedDay(ctx context.Context, sel ast.Selectio
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
+		case "solubleFibre":
+			out.Values[i] = ec._CalculatedDay_solubleFibre(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "insolubleFibre":
+			out.Values[i] = ec._CalculatedDay_insolubleFibre(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
 		case "protein":
 			out.Values[i] = ec._CalculatedDay_protein(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
@@ -2624,6 +3489,11 @@ func (ec *executionContext) _CalculatedDay(ctx context.Context, sel ast.Selectio
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
+		case "nutrients":
+			out.Values[i] = ec._CalculatedDay_nutrients(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -2713,6 +3583,16 @@ func (ec *executionContext) _CalculatedProduct(ctx context.Context, sel ast.Sele
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
+		case "solubleFibre":
+			out.Values[i] = ec._CalculatedProduct_solubleFibre(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "insolubleFibre":
+			out.Values[i] = ec._CalculatedProduct_insolubleFibre(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
 		case "protein":
 			out.Values[i] = ec._CalculatedProduct_protein(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
@@ -2723,6 +3603,16 @@ func (ec *executionContext) _CalculatedProduct(ctx context.Context, sel ast.Sele
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
+		case "nutrients":
+			out.Values[i] = ec._CalculatedProduct_nutrients(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "priceSources":
+			out.Values[i] = ec._CalculatedProduct_priceSources(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -2807,6 +3697,16 @@ func (ec *executionContext) _CalculatedRecipe(ctx context.Context, sel ast.Selec
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
+		case "solubleFibre":
+			out.Values[i] = ec._CalculatedRecipe_solubleFibre(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "insolubleFibre":
+			out.Values[i] = ec._CalculatedRecipe_insolubleFibre(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
 		case "protein":
 			out.Values[i] = ec._CalculatedRecipe_protein(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
@@ -2817,6 +3717,11 @@ func (ec *executionContext) _CalculatedRecipe(ctx context.Context, sel ast.Selec
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
+		case "nutrients":
+			out.Values[i] = ec._CalculatedRecipe_nutrients(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -2958,6 +3863,75 @@ func (ec *executionContext) _PreparedRecipeAggregate(ctx context.Context, sel as
 	return out
 }
 
+var priceSourceImplementors = []string{"PriceSource"}
+
+func (ec *executionContext) _PriceSource(ctx context.Context, sel ast.SelectionSet, obj *model.PriceSource) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, priceSourceImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("PriceSource")
+		case "purchaseId":
+			out.Values[i] = ec._PriceSource_purchaseId(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "varietyName":
+			out.Values[i] = ec._PriceSource_varietyName(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "retailer":
+			out.Values[i] = ec._PriceSource_retailer(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "date":
+			out.Values[i] = ec._PriceSource_date(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "price":
+			out.Values[i] = ec._PriceSource_price(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "unit":
+			out.Values[i] = ec._PriceSource_unit(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "quantity":
+			out.Values[i] = ec._PriceSource_quantity(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch(ctx)
+	if out.Invalids > 0 {
+		return graphql.Null
+	}
+
+	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
+
+	for label, dfs := range deferred {
+		ec.processDeferredGroup(graphql.DeferredGroup{
+			Label:    label,
+			Path:     graphql.GetPath(ctx),
+			FieldSet: dfs,
+			Context:  ctx,
+		})
+	}
+
+	return out
+}
+
 var recipeAggregateImplementors = []string{"RecipeAggregate"}
 
 func (ec *executionContext) _RecipeAggregate(ctx context.Context, sel ast.SelectionSet, obj *model.RecipeAggregate) graphql.Marshaler {
@@ -3256,6 +4230,60 @@ func (ec *executionContext) unmarshalNPreparedRecipeInput2githubᚗcomᚋSarunas
 	return res, graphql.ErrorOnPath(ctx, err)
 }
 
+func (ec *executionContext) marshalNPriceSource2ᚕᚖgithubᚗcomᚋSarunasBuciusᚋnutriᚑpriceᚑserverᚋgraphᚋmodelᚐPriceSourceᚄ(ctx context.Context, sel ast.SelectionSet, v []*model.PriceSource) graphql.Marshaler {
+	ret := make(graphql.Array, len(v))
+	var wg sync.WaitGroup
+	isLen1 := len(v) == 1
+	if !isLen1 {
+		wg.Add(len(v))
+	}
+	for i := range v {
+		i := i
+		fc := &graphql.FieldContext{
+			Index:  &i,
+			Result: &v[i],
+		}
+		ctx := graphql.WithFieldContext(ctx, fc)
+		f := func(i int) {
+			defer func() {
+				if r := recover(); r != nil {
+					ec.Error(ctx, ec.Recover(ctx, r))
+					ret = nil
+				}
+			}()
+			if !isLen1 {
+				defer wg.Done()
+			}
+			ret[i] = ec.marshalNPriceSource2ᚖgithubᚗcomᚋSarunasBuciusᚋnutriᚑpriceᚑserverᚋgraphᚋmodelᚐPriceSource(ctx, sel, v[i])
+		}
+		if isLen1 {
+			f(i)
+		} else {
+			go f(i)
+		}
+
+	}
+	wg.Wait()
+
+	for _, e := range ret {
+		if e == graphql.Null {
+			return graphql.Null
+		}
+	}
+
+	return ret
+}
+
+func (ec *executionContext) marshalNPriceSource2ᚖgithubᚗcomᚋSarunasBuciusᚋnutriᚑpriceᚑserverᚋgraphᚋmodelᚐPriceSource(ctx context.Context, sel ast.SelectionSet, v *model.PriceSource) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			ec.Errorf(ctx, "the requested element is null which the schema does not allow")
+		}
+		return graphql.Null
+	}
+	return ec._PriceSource(ctx, sel, v)
+}
+
 func (ec *executionContext) marshalNRecipeAggregate2githubᚗcomᚋSarunasBuciusᚋnutriᚑpriceᚑserverᚋgraphᚋmodelᚐRecipeAggregate(ctx context.Context, sel ast.SelectionSet, v model.RecipeAggregate) graphql.Marshaler {
 	return ec._RecipeAggregate(ctx, sel, &v)
 }
@@ -3275,4 +4303,12 @@ func (ec *executionContext) unmarshalNRecipeInput2githubᚗcomᚋSarunasBucius
 	return res, graphql.ErrorOnPath(ctx, err)
 }
 
+func (ec *executionContext) unmarshalOPriceStrategyInput2ᚖgithubᚗcomᚋSarunasBuciusᚋnutriᚑpriceᚑserverᚋgraphᚋmodelᚐPriceStrategyInput(ctx context.Context, v any) (*model.PriceStrategyInput, error) {
+	if v == nil {
+		return nil, nil
+	}
+	res, err := ec.unmarshalInputPriceStrategyInput(ctx, v)
+	return &res, graphql.ErrorOnPath(ctx, err)
+}
+
 // endregion ***************************** type.gotpl *****************************
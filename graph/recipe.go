@@ -0,0 +1,137 @@
+package graph
+
+import (
+	"github.com/SarunasBucius/nutri-price-server/graph/model"
+	internalmodel "github.com/SarunasBucius/nutri-price-server/internal/model"
+)
+
+func toPriceStrategy(input *model.PriceStrategyInput) internalmodel.PriceStrategy {
+	if input == nil {
+		return internalmodel.PriceStrategy{}
+	}
+
+	strategy := internalmodel.PriceStrategy{Name: input.Strategy}
+	if input.Days != nil {
+		strategy.Days = int(*input.Days)
+	}
+	if input.Retailer != nil {
+		strategy.Retailer = *input.Retailer
+	}
+	return strategy
+}
+
+// toDaysIngredients flattens products of the day's recipes to ingredients in the same order.
+func toDaysIngredients(day *model.CalculatedDay) internalmodel.Ingredients {
+	var ingredients internalmodel.Ingredients
+	for _, recipe := range day.Recipes {
+		for _, product := range recipe.Products {
+			ingredients = append(ingredients, internalmodel.Ingredient{
+				Product: product.Product,
+				Unit:    product.Unit,
+				Amount:  product.Quantity,
+			})
+		}
+	}
+	return ingredients
+}
+
+// setDaysPrices sets prices of the day's recipe products from prices of ingredients returned by toDaysIngredients.
+func setDaysPrices(day *model.CalculatedDay, prices []internalmodel.CalculatedProductPrice) {
+	var i int
+	for _, recipe := range day.Recipes {
+		for _, product := range recipe.Products {
+			product.Price = prices[i].Price
+			product.PriceSources = toPriceSources(prices[i].Sources)
+			i++
+		}
+	}
+}
+
+// setDaysNutritionalValues sets nutritional values of the day's recipe products from nutritional values per 100 units
+// and nutrient amounts of the products, and sums nutritional values and prices of recipes and the day.
+func setDaysNutritionalValues(day *model.CalculatedDay, nvs map[string]model.NutritionalValue, nutrients map[string]map[string]float64, catalogue []*model.Nutrient) {
+	dayNutrients := make(map[string]float64)
+	for i := range day.Recipes {
+		recipeNutrients := make(map[string]float64)
+		for j, p := range day.Recipes[i].Products {
+			productNutrients := make(map[string]float64)
+			if nv, ok := nvs[p.Product]; ok {
+				q := p.Quantity
+				p.EnergyValueKcal = nv.EnergyValueKcal / 100 * q
+				p.Fat = nv.Fat / 100 * q
+				p.SaturatedFat = nv.SaturatedFat / 100 * q
+				p.Carbohydrate = nv.Carbohydrate / 100 * q
+				p.CarbohydrateSugars = nv.CarbohydrateSugars / 100 * q
+				p.Fibre = nv.Fibre / 100 * q
+				p.SolubleFibre = nv.SolubleFibre / 100 * q
+				p.InsolubleFibre = nv.InsolubleFibre / 100 * q
+				p.Protein = nv.Protein / 100 * q
+				p.Salt = nv.Salt / 100 * q
+				for code, amount := range nutrients[p.Product] {
+					productNutrients[code] = amount / 100 * q
+					recipeNutrients[code] += productNutrients[code]
+					dayNutrients[code] += productNutrients[code]
+				}
+			}
+			p.Nutrients = toNutrientAmounts(productNutrients, catalogue)
+			day.Recipes[i].Products[j] = p
+		}
+		// TODO: truncate values
+		var totalNV model.NutritionalValue
+		var totalPrice float64
+		for _, p := range day.Recipes[i].Products {
+			totalPrice += p.Price
+			totalNV.EnergyValueKcal += p.EnergyValueKcal
+			totalNV.Fat += p.Fat
+			totalNV.SaturatedFat += p.SaturatedFat
+			totalNV.Carbohydrate += p.Carbohydrate
+			totalNV.CarbohydrateSugars += p.CarbohydrateSugars
+			totalNV.Fibre += p.Fibre
+			totalNV.SolubleFibre += p.SolubleFibre
+			totalNV.InsolubleFibre += p.InsolubleFibre
+			totalNV.Protein += p.Protein
+			totalNV.Salt += p.Salt
+		}
+		day.Recipes[i].Price = totalPrice
+		day.Recipes[i].EnergyValueKcal = totalNV.EnergyValueKcal
+		day.Recipes[i].Fat = totalNV.Fat
+		day.Recipes[i].SaturatedFat = totalNV.SaturatedFat
+		day.Recipes[i].Carbohydrate = totalNV.Carbohydrate
+		day.Recipes[i].CarbohydrateSugars = totalNV.CarbohydrateSugars
+		day.Recipes[i].Fibre = totalNV.Fibre
+		day.Recipes[i].SolubleFibre = totalNV.SolubleFibre
+		day.Recipes[i].InsolubleFibre = totalNV.InsolubleFibre
+		day.Recipes[i].Protein = totalNV.Protein
+		day.Recipes[i].Salt = totalNV.Salt
+		day.Recipes[i].Nutrients = toNutrientAmounts(recipeNutrients, catalogue)
+
+		day.Price += totalPrice
+		day.EnergyValueKcal += totalNV.EnergyValueKcal
+		day.Fat += totalNV.Fat
+		day.SaturatedFat += totalNV.SaturatedFat
+		day.Carbohydrate += totalNV.Carbohydrate
+		day.CarbohydrateSugars += totalNV.CarbohydrateSugars
+		day.Fibre += totalNV.Fibre
+		day.SolubleFibre += totalNV.SolubleFibre
+		day.InsolubleFibre += totalNV.InsolubleFibre
+		day.Protein += totalNV.Protein
+		day.Salt += totalNV.Salt
+	}
+	day.Nutrients = toNutrientAmounts(dayNutrients, catalogue)
+}
+
+func toPriceSources(sources []internalmodel.PriceSource) []*model.PriceSource {
+	result := make([]*model.PriceSource, 0, len(sources))
+	for _, source := range sources {
+		result = append(result, &model.PriceSource{
+			PurchaseID:  int32(source.PurchaseID),
+			VarietyName: source.VarietyName,
+			Retailer:    source.Retailer,
+			Date:        source.Date,
+			Price:       source.Price,
+			Unit:        source.Quantity.Unit,
+			Quantity:    source.Quantity.Amount,
+		})
+	}
+	return result
+}
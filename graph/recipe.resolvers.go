@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"sort"
 	"strconv"
+	"time"
 
 	"github.com/SarunasBucius/nutri-price-server/graph/model"
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -205,7 +206,7 @@ func (r *queryResolver) PreparedRecipe(ctx context.Context, recipeName string, d
 }
 
 // CalculateDaysConsumption is the resolver for the calculateDaysConsumption field.
-func (r *queryResolver) CalculateDaysConsumption(ctx context.Context, date string) (*model.CalculatedDay, error) {
+func (r *queryResolver) CalculateDaysConsumption(ctx context.Context, date string, priceStrategy *model.PriceStrategyInput) (*model.CalculatedDay, error) {
 	res, err := r.DynamoDB.Query(ctx, &dynamodb.QueryInput{
 		TableName:              aws.String("PreparedRecipes"),
 		KeyConditionExpression: aws.String("PreparedDate = :date"),
@@ -263,10 +264,17 @@ func (r *queryResolver) CalculateDaysConsumption(ctx context.Context, date strin
 		})
 	}
 
+	catalogue, err := r.nutrientCatalogue(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	nvs := make(map[string]model.NutritionalValue, len(ingredients))
+	nutrients := make(map[string]map[string]float64, len(ingredients))
 	for unit, products := range ingredients {
 		query := `
-		SELECT nutritional_values_v2.id, products.name, unit, energy_value_kcal, fat, saturated_fat, carbohydrate, carbohydrate_sugars, fibre, protein, salt
+		SELECT nutritional_values_v2.id, products.name, unit, energy_value_kcal, fat, saturated_fat, carbohydrate, carbohydrate_sugars,
+			fibre, soluble_fibre, insoluble_fibre, protein, salt, nutrients
 		FROM nutritional_values_v2
 		JOIN products ON nutritional_values_v2.product_id = products.id
 		WHERE products.name=ANY($1) AND nutritional_values_v2.unit=$2`
@@ -279,60 +287,59 @@ func (r *queryResolver) CalculateDaysConsumption(ctx context.Context, date strin
 		for rows.Next() {
 			nv := model.NutritionalValue{}
 			var productName string
+			var productNutrients map[string]float64
 			if err := rows.Scan(&nv.ID, &productName, &nv.Unit, &nv.EnergyValueKcal,
 				&nv.Fat, &nv.SaturatedFat, &nv.Carbohydrate, &nv.CarbohydrateSugars,
-				&nv.Fibre, &nv.Protein, &nv.Salt); err != nil {
+				&nv.Fibre, &nv.SolubleFibre, &nv.InsolubleFibre, &nv.Protein, &nv.Salt, &productNutrients); err != nil {
 				return nil, fmt.Errorf("scan nutritional value: %w", err)
 			}
 			nvs[productName] = nv
+			nutrients[productName] = productNutrients
 		}
-	}
 
-	for i := range calculatedDay.Recipes {
-		for j, p := range calculatedDay.Recipes[i].Products {
-			if nv, ok := nvs[p.Product]; ok {
-				q := p.Quantity
-				p.EnergyValueKcal = nv.EnergyValueKcal / 100 * q
-				p.Fat = nv.Fat / 100 * q
-				p.SaturatedFat = nv.SaturatedFat / 100 * q
-				p.Carbohydrate = nv.Carbohydrate / 100 * q
-				p.CarbohydrateSugars = nv.CarbohydrateSugars / 100 * q
-				p.Fibre = nv.Fibre / 100 * q
-				p.Protein = nv.Protein / 100 * q
-				p.Salt = nv.Salt / 100 * q
+		query = `
+		SELECT products.name, unit, energy_value_kcal, fat, saturated_fat, carbohydrate, carbohydrate_sugars,
+			fibre, soluble_fibre, insoluble_fibre, protein, salt, nutrients
+		FROM products
+		JOIN food_facts ON food_facts.id = products.reference_food_id
+		WHERE products.name=ANY($1) AND food_facts.unit=$2`
+		rows, err = r.DB.Query(ctx, query, products, unit)
+		if err != nil {
+			return nil, fmt.Errorf("query reference foods: %w", err)
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			nv := model.NutritionalValue{}
+			var productName string
+			var productNutrients map[string]float64
+			if err := rows.Scan(&productName, &nv.Unit, &nv.EnergyValueKcal,
+				&nv.Fat, &nv.SaturatedFat, &nv.Carbohydrate, &nv.CarbohydrateSugars,
+				&nv.Fibre, &nv.SolubleFibre, &nv.InsolubleFibre, &nv.Protein, &nv.Salt, &productNutrients); err != nil {
+				return nil, fmt.Errorf("scan reference food: %w", err)
+			}
+			if _, ok := nvs[productName]; ok {
+				continue
 			}
-			calculatedDay.Recipes[i].Products[j] = p
+			nvs[productName] = nv
+			nutrients[productName] = productNutrients
 		}
-		// TODO: calc prices, truncate values
-		var totalNV model.NutritionalValue
-		for _, p := range calculatedDay.Recipes[i].Products {
-			totalNV.EnergyValueKcal += p.EnergyValueKcal
-			totalNV.Fat += p.Fat
-			totalNV.SaturatedFat += p.SaturatedFat
-			totalNV.Carbohydrate += p.Carbohydrate
-			totalNV.CarbohydrateSugars += p.CarbohydrateSugars
-			totalNV.Fibre += p.Fibre
-			totalNV.Protein += p.Protein
-			totalNV.Salt += p.Salt
+		if err := rows.Err(); err != nil {
+			return nil, fmt.Errorf("read reference foods: %w", err)
 		}
-		calculatedDay.Recipes[i].EnergyValueKcal = totalNV.EnergyValueKcal
-		calculatedDay.Recipes[i].Fat = totalNV.Fat
-		calculatedDay.Recipes[i].SaturatedFat = totalNV.SaturatedFat
-		calculatedDay.Recipes[i].Carbohydrate = totalNV.Carbohydrate
-		calculatedDay.Recipes[i].CarbohydrateSugars = totalNV.CarbohydrateSugars
-		calculatedDay.Recipes[i].Fibre = totalNV.Fibre
-		calculatedDay.Recipes[i].Protein = totalNV.Protein
-		calculatedDay.Recipes[i].Salt = totalNV.Salt
-
-		calculatedDay.EnergyValueKcal += totalNV.EnergyValueKcal
-		calculatedDay.Fat += totalNV.Fat
-		calculatedDay.SaturatedFat += totalNV.SaturatedFat
-		calculatedDay.Carbohydrate += totalNV.Carbohydrate
-		calculatedDay.CarbohydrateSugars += totalNV.CarbohydrateSugars
-		calculatedDay.Fibre += totalNV.Fibre
-		calculatedDay.Protein += totalNV.Protein
-		calculatedDay.Salt += totalNV.Salt
 	}
 
+	dishDate, err := time.Parse(time.DateOnly, date)
+	if err != nil {
+		return nil, fmt.Errorf("parse date: %w", err)
+	}
+	prices, err := r.RecipeService.GetIngredientPrices(ctx, toDaysIngredients(&calculatedDay), toPriceStrategy(priceStrategy), dishDate)
+	if err != nil {
+		return nil, fmt.Errorf("get ingredient prices: %w", err)
+	}
+	setDaysPrices(&calculatedDay, prices)
+
+	setDaysNutritionalValues(&calculatedDay, nvs, nutrients, catalogue)
+
 	return &calculatedDay, nil
 }
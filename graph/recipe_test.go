@@ -0,0 +1,50 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/SarunasBucius/nutri-price-server/graph/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetDaysNutritionalValues(t *testing.T) {
+	day := &model.CalculatedDay{
+		Recipes: []*model.CalculatedRecipe{
+			{RecipeName: "porridge", Products: []*model.CalculatedProduct{
+				{Product: "oats", Unit: "g", Quantity: 50, Price: 0.2},
+				{Product: "apple", Unit: "g", Quantity: 200, Price: 0.5},
+			}},
+			{RecipeName: "salad", Products: []*model.CalculatedProduct{
+				{Product: "apple", Unit: "g", Quantity: 100, Price: 0.25},
+				{Product: "unknown", Unit: "g", Quantity: 100, Price: 1},
+			}},
+		},
+	}
+	nvs := map[string]model.NutritionalValue{
+		"oats":  {EnergyValueKcal: 380, Fibre: 10, SolubleFibre: 4, InsolubleFibre: 6},
+		"apple": {EnergyValueKcal: 50, Fibre: 2, SolubleFibre: 0.5, InsolubleFibre: 1.5},
+	}
+	nutrients := map[string]map[string]float64{"oats": {"iron": 4}}
+	catalogue := []*model.Nutrient{{Code: "iron", Name: "Iron", Unit: "mg"}}
+
+	setDaysNutritionalValues(day, nvs, nutrients, catalogue)
+
+	porridge, salad := day.Recipes[0], day.Recipes[1]
+	require.InDelta(t, 2, porridge.Products[0].SolubleFibre, 1e-9)
+	require.InDelta(t, 3, porridge.Products[0].InsolubleFibre, 1e-9)
+	require.InDelta(t, 9, porridge.Fibre, 1e-9)
+	require.InDelta(t, 3, porridge.SolubleFibre, 1e-9)
+	require.InDelta(t, 6, porridge.InsolubleFibre, 1e-9)
+	require.InDelta(t, 0.7, porridge.Price, 1e-9)
+	require.Zero(t, salad.Products[1].Fibre)
+	require.InDelta(t, 0.5, salad.SolubleFibre, 1e-9)
+	require.InDelta(t, 1.5, salad.InsolubleFibre, 1e-9)
+
+	require.InDelta(t, 11, day.Fibre, 1e-9)
+	require.InDelta(t, 3.5, day.SolubleFibre, 1e-9)
+	require.InDelta(t, 7.5, day.InsolubleFibre, 1e-9)
+	require.InDelta(t, 340, day.EnergyValueKcal, 1e-9)
+	require.InDelta(t, 1.95, day.Price, 1e-9)
+	require.Len(t, day.Nutrients, 1)
+	require.InDelta(t, 2, day.Nutrients[0].Amount, 1e-9)
+}
@@ -1,6 +1,10 @@
 package graph
 
 import (
+	"github.com/SarunasBucius/nutri-price-server/internal/service/alias"
+	"github.com/SarunasBucius/nutri-price-server/internal/service/fooddata"
+	"github.com/SarunasBucius/nutri-price-server/internal/service/nutritionalvalue"
+	"github.com/SarunasBucius/nutri-price-server/internal/service/recipe"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
@@ -11,6 +15,10 @@ import (
 
 //go:generate go run github.com/99designs/gqlgen generate
 type Resolver struct {
-	DB       *pgxpool.Pool
-	DynamoDB *dynamodb.Client
+	DB                      *pgxpool.Pool
+	DynamoDB                *dynamodb.Client
+	AliasService            *alias.Service
+	RecipeService           *recipe.Service
+	FoodDataService         *fooddata.Service
+	NutritionalValueService *nutritionalvalue.Service
 }
@@ -50,11 +50,14 @@ type ComplexityRoot struct {
 		EnergyValueKcal    func(childComplexity int) int
 		Fat                func(childComplexity int) int
 		Fibre              func(childComplexity int) int
+		InsolubleFibre     func(childComplexity int) int
+		Nutrients          func(childComplexity int) int
 		Price              func(childComplexity int) int
 		Protein            func(childComplexity int) int
 		Recipes            func(childComplexity int) int
 		Salt               func(childComplexity int) int
 		SaturatedFat       func(childComplexity int) int
+		SolubleFibre       func(childComplexity int) int
 	}
 
 	CalculatedProduct struct {
@@ -63,12 +66,16 @@ type ComplexityRoot struct {
 		EnergyValueKcal    func(childComplexity int) int
 		Fat                func(childComplexity int) int
 		Fibre              func(childComplexity int) int
+		InsolubleFibre     func(childComplexity int) int
+		Nutrients          func(childComplexity int) int
 		Price              func(childComplexity int) int
+		PriceSources       func(childComplexity int) int
 		Product            func(childComplexity int) int
 		Protein            func(childComplexity int) int
 		Quantity           func(childComplexity int) int
 		Salt               func(childComplexity int) int
 		SaturatedFat       func(childComplexity int) int
+		SolubleFibre       func(childComplexity int) int
 		Unit               func(childComplexity int) int
 		VarietyName        func(childComplexity int) int
 	}
@@ -79,6 +86,8 @@ type ComplexityRoot struct {
 		EnergyValueKcal    func(childComplexity int) int
 		Fat                func(childComplexity int) int
 		Fibre              func(childComplexity int) int
+		InsolubleFibre     func(childComplexity int) int
+		Nutrients          func(childComplexity int) int
 		Portion            func(childComplexity int) int
 		Price              func(childComplexity int) int
 		Products           func(childComplexity int) int
@@ -86,6 +95,46 @@ type ComplexityRoot struct {
 		RecipeName         func(childComplexity int) int
 		Salt               func(childComplexity int) int
 		SaturatedFat       func(childComplexity int) int
+		SolubleFibre       func(childComplexity int) int
+	}
+
+	Category struct {
+		Children func(childComplexity int) int
+		ID       func(childComplexity int) int
+		Name     func(childComplexity int) int
+		ParentID func(childComplexity int) int
+		Path     func(childComplexity int) int
+	}
+
+	DiscountSavings struct {
+		DiscountType  func(childComplexity int) int
+		FullPrice     func(childComplexity int) int
+		PaidPrice     func(childComplexity int) int
+		PurchaseCount func(childComplexity int) int
+		Retailer      func(childComplexity int) int
+		Saved         func(childComplexity int) int
+	}
+
+	FoodFact struct {
+		Barcode            func(childComplexity int) int
+		Brands             func(childComplexity int) int
+		Carbohydrate       func(childComplexity int) int
+		CarbohydrateSugars func(childComplexity int) int
+		EnergyValueKcal    func(childComplexity int) int
+		Fat                func(childComplexity int) int
+		Fibre              func(childComplexity int) int
+		ID                 func(childComplexity int) int
+		ImportedAt         func(childComplexity int) int
+		InsolubleFibre     func(childComplexity int) int
+		Name               func(childComplexity int) int
+		Nutrients          func(childComplexity int) int
+		Protein            func(childComplexity int) int
+		Salt               func(childComplexity int) int
+		SaturatedFat       func(childComplexity int) int
+		SolubleFibre       func(childComplexity int) int
+		Source             func(childComplexity int) int
+		SourceID           func(childComplexity int) int
+		Unit               func(childComplexity int) int
 	}
 
 	Ingredient struct {
@@ -96,18 +145,43 @@ type ComplexityRoot struct {
 	}
 
 	Mutation struct {
-		CreateProduct          func(childComplexity int, input model.ProductAggregateInput) int
-		DeleteNutritionalValue func(childComplexity int, id string) int
-		DeleteProduct          func(childComplexity int, id string) int
-		DeletePurchase         func(childComplexity int, id string) int
-		DeleteVariety          func(childComplexity int, varietyName string) int
-		PlanRecipes            func(childComplexity int, date string, planRecipes []*model.PlanRecipe) int
-		UpdatePreparedRecipe   func(childComplexity int, recipe model.PreparedRecipeInput) int
-		UpdateProduct          func(childComplexity int, id string, name string) int
-		UpdatePurchase         func(childComplexity int, id string, input model.PurchaseInput) int
-		UpdateRecipe           func(childComplexity int, recipe model.RecipeInput) int
-		UpdateVariety          func(childComplexity int, oldName string, varietyName string) int
-		UpsertNutritionalValue func(childComplexity int, productID string, varietyName string, input model.NutritionalValueInput) int
+		ApplyFoodFact           func(childComplexity int, foodFactID string, productID string, varietyName string) int
+		CreateCategory          func(childComplexity int, name string, parentID *string) int
+		CreateProduct           func(childComplexity int, input model.ProductAggregateInput) int
+		DeleteCategory          func(childComplexity int, id string) int
+		DeleteNutritionalValue  func(childComplexity int, id string) int
+		DeleteProduct           func(childComplexity int, id string) int
+		DeleteProductAlias      func(childComplexity int, id string) int
+		DeletePurchase          func(childComplexity int, id string) int
+		DeleteVariety           func(childComplexity int, varietyName string) int
+		PlanRecipes             func(childComplexity int, date string, planRecipes []*model.PlanRecipe) int
+		ReassignProductAliases  func(childComplexity int, ids []string, name string, varietyName *string) int
+		SetProductCategory      func(childComplexity int, productID string, categoryID *string) int
+		SetProductReferenceFood func(childComplexity int, productID string, foodFactID *string) int
+		SetProductTags          func(childComplexity int, productID string, tags []string) int
+		UpdateCategory          func(childComplexity int, id string, name string, parentID *string) int
+		UpdatePreparedRecipe    func(childComplexity int, recipe model.PreparedRecipeInput) int
+		UpdateProduct           func(childComplexity int, id string, name string) int
+		UpdateProductAlias      func(childComplexity int, id string, name string, varietyName *string) int
+		UpdatePurchase          func(childComplexity int, id string, input model.PurchaseInput) int
+		UpdateRecipe            func(childComplexity int, recipe model.RecipeInput) int
+		UpdateVariety           func(childComplexity int, oldName string, varietyName string) int
+		UpsertNutritionalValue  func(childComplexity int, productID string, varietyName string, input model.NutritionalValueInput) int
+	}
+
+	Nutrient struct {
+		Code           func(childComplexity int) int
+		DailyReference func(childComplexity int) int
+		Name           func(childComplexity int) int
+		Unit           func(childComplexity int) int
+	}
+
+	NutrientAmount struct {
+		Amount              func(childComplexity int) int
+		Code                func(childComplexity int) int
+		DailyReferenceShare func(childComplexity int) int
+		Name                func(childComplexity int) int
+		Unit                func(childComplexity int) int
 	}
 
 	NutritionalValue struct {
@@ -117,9 +191,15 @@ type ComplexityRoot struct {
 		Fat                func(childComplexity int) int
 		Fibre              func(childComplexity int) int
 		ID                 func(childComplexity int) int
+		InsolubleFibre     func(childComplexity int) int
+		Nutrients          func(childComplexity int) int
 		Protein            func(childComplexity int) int
 		Salt               func(childComplexity int) int
 		SaturatedFat       func(childComplexity int) int
+		SolubleFibre       func(childComplexity int) int
+		Source             func(childComplexity int) int
+		SourceID           func(childComplexity int) int
+		SourcedAt          func(childComplexity int) int
 		Unit               func(childComplexity int) int
 	}
 
@@ -132,34 +212,91 @@ type ComplexityRoot struct {
 		Steps        func(childComplexity int) int
 	}
 
+	PriceHistory struct {
+		Bucket         func(childComplexity int) int
+		Max            func(childComplexity int) int
+		Mean           func(childComplexity int) int
+		Min            func(childComplexity int) int
+		Points         func(childComplexity int) int
+		Trend          func(childComplexity int) int
+		TrendDirection func(childComplexity int) int
+		Unit           func(childComplexity int) int
+	}
+
+	PricePoint struct {
+		Max         func(childComplexity int) int
+		Mean        func(childComplexity int) int
+		Min         func(childComplexity int) int
+		PeriodStart func(childComplexity int) int
+		Purchases   func(childComplexity int) int
+	}
+
+	PriceSource struct {
+		Date        func(childComplexity int) int
+		Price       func(childComplexity int) int
+		PurchaseID  func(childComplexity int) int
+		Quantity    func(childComplexity int) int
+		Retailer    func(childComplexity int) int
+		Unit        func(childComplexity int) int
+		VarietyName func(childComplexity int) int
+	}
+
 	Product struct {
-		ID   func(childComplexity int) int
-		Name func(childComplexity int) int
+		CategoryID      func(childComplexity int) int
+		ID              func(childComplexity int) int
+		Name            func(childComplexity int) int
+		ReferenceFoodID func(childComplexity int) int
+		Tags            func(childComplexity int) int
 	}
 
 	ProductAggregate struct {
-		Name      func(childComplexity int) int
-		Varieties func(childComplexity int) int
+		Name          func(childComplexity int) int
+		ReferenceFood func(childComplexity int) int
+		Varieties     func(childComplexity int) int
+	}
+
+	ProductAlias struct {
+		ID          func(childComplexity int) int
+		Name        func(childComplexity int) int
+		ParsedName  func(childComplexity int) int
+		Purchases   func(childComplexity int) int
+		VarietyName func(childComplexity int) int
+	}
+
+	ProductAliasesUpdate struct {
+		Aliases               func(childComplexity int) int
+		UnconfirmedReceiptIds func(childComplexity int) int
 	}
 
 	Purchase struct {
-		Date     func(childComplexity int) int
-		ID       func(childComplexity int) int
-		Notes    func(childComplexity int) int
-		Price    func(childComplexity int) int
-		Quantity func(childComplexity int) int
-		Retailer func(childComplexity int) int
-		Unit     func(childComplexity int) int
+		Date         func(childComplexity int) int
+		Deposit      func(childComplexity int) int
+		Discount     func(childComplexity int) int
+		DiscountType func(childComplexity int) int
+		FullPrice    func(childComplexity int) int
+		ID           func(childComplexity int) int
+		Notes        func(childComplexity int) int
+		Price        func(childComplexity int) int
+		Quantity     func(childComplexity int) int
+		Retailer     func(childComplexity int) int
+		Unit         func(childComplexity int) int
 	}
 
 	Query struct {
-		CalculateDaysConsumption func(childComplexity int, date string) int
+		CalculateDaysConsumption func(childComplexity int, date string, priceStrategy *model.PriceStrategyInput) int
+		Categories               func(childComplexity int) int
+		DiscountSavings          func(childComplexity int, dateFrom string, dateTo string, categoryID *string, tag *string) int
+		Nutrients                func(childComplexity int) int
 		PreparedRecipe           func(childComplexity int, recipeName string, date string) int
 		PreparedRecipesByDate    func(childComplexity int, date string) int
+		PriceHistory             func(childComplexity int, productID string, varietyName *string, unit string, bucket string, retailer *string) int
 		ProductAggregate         func(childComplexity int, id string) int
-		Products                 func(childComplexity int) int
+		ProductAliases           func(childComplexity int, search *string) int
+		Products                 func(childComplexity int, categoryID *string, tag *string) int
 		Recipe                   func(childComplexity int, recipeName string) int
 		Recipes                  func(childComplexity int) int
+		SearchFoodFacts          func(childComplexity int, search string, source *string, limit *int32) int
+		Tags                     func(childComplexity int) int
 	}
 
 	RecipeAggregate struct {
@@ -238,6 +375,20 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.CalculatedDay.Fibre(childComplexity), true
 
+	case "CalculatedDay.insolubleFibre":
+		if e.complexity.CalculatedDay.InsolubleFibre == nil {
+			break
+		}
+
+		return e.complexity.CalculatedDay.InsolubleFibre(childComplexity), true
+
+	case "CalculatedDay.nutrients":
+		if e.complexity.CalculatedDay.Nutrients == nil {
+			break
+		}
+
+		return e.complexity.CalculatedDay.Nutrients(childComplexity), true
+
 	case "CalculatedDay.price":
 		if e.complexity.CalculatedDay.Price == nil {
 			break
@@ -273,6 +424,13 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.CalculatedDay.SaturatedFat(childComplexity), true
 
+	case "CalculatedDay.solubleFibre":
+		if e.complexity.CalculatedDay.SolubleFibre == nil {
+			break
+		}
+
+		return e.complexity.CalculatedDay.SolubleFibre(childComplexity), true
+
 	case "CalculatedProduct.carbohydrate":
 		if e.complexity.CalculatedProduct.Carbohydrate == nil {
 			break
@@ -308,6 +466,20 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.CalculatedProduct.Fibre(childComplexity), true
 
+	case "CalculatedProduct.insolubleFibre":
+		if e.complexity.CalculatedProduct.InsolubleFibre == nil {
+			break
+		}
+
+		return e.complexity.CalculatedProduct.InsolubleFibre(childComplexity), true
+
+	case "CalculatedProduct.nutrients":
+		if e.complexity.CalculatedProduct.Nutrients == nil {
+			break
+		}
+
+		return e.complexity.CalculatedProduct.Nutrients(childComplexity), true
+
 	case "CalculatedProduct.price":
 		if e.complexity.CalculatedProduct.Price == nil {
 			break
@@ -315,6 +487,13 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.CalculatedProduct.Price(childComplexity), true
 
+	case "CalculatedProduct.priceSources":
+		if e.complexity.CalculatedProduct.PriceSources == nil {
+			break
+		}
+
+		return e.complexity.CalculatedProduct.PriceSources(childComplexity), true
+
 	case "CalculatedProduct.product":
 		if e.complexity.CalculatedProduct.Product == nil {
 			break
@@ -350,6 +529,13 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.CalculatedProduct.SaturatedFat(childComplexity), true
 
+	case "CalculatedProduct.solubleFibre":
+		if e.complexity.CalculatedProduct.SolubleFibre == nil {
+			break
+		}
+
+		return e.complexity.CalculatedProduct.SolubleFibre(childComplexity), true
+
 	case "CalculatedProduct.unit":
 		if e.complexity.CalculatedProduct.Unit == nil {
 			break
@@ -399,6 +585,20 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.CalculatedRecipe.Fibre(childComplexity), true
 
+	case "CalculatedRecipe.insolubleFibre":
+		if e.complexity.CalculatedRecipe.InsolubleFibre == nil {
+			break
+		}
+
+		return e.complexity.CalculatedRecipe.InsolubleFibre(childComplexity), true
+
+	case "CalculatedRecipe.nutrients":
+		if e.complexity.CalculatedRecipe.Nutrients == nil {
+			break
+		}
+
+		return e.complexity.CalculatedRecipe.Nutrients(childComplexity), true
+
 	case "CalculatedRecipe.portion":
 		if e.complexity.CalculatedRecipe.Portion == nil {
 			break
@@ -448,6 +648,223 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.CalculatedRecipe.SaturatedFat(childComplexity), true
 
+	case "CalculatedRecipe.solubleFibre":
+		if e.complexity.CalculatedRecipe.SolubleFibre == nil {
+			break
+		}
+
+		return e.complexity.CalculatedRecipe.SolubleFibre(childComplexity), true
+
+	case "Category.children":
+		if e.complexity.Category.Children == nil {
+			break
+		}
+
+		return e.complexity.Category.Children(childComplexity), true
+
+	case "Category.id":
+		if e.complexity.Category.ID == nil {
+			break
+		}
+
+		return e.complexity.Category.ID(childComplexity), true
+
+	case "Category.name":
+		if e.complexity.Category.Name == nil {
+			break
+		}
+
+		return e.complexity.Category.Name(childComplexity), true
+
+	case "Category.parentId":
+		if e.complexity.Category.ParentID == nil {
+			break
+		}
+
+		return e.complexity.Category.ParentID(childComplexity), true
+
+	case "Category.path":
+		if e.complexity.Category.Path == nil {
+			break
+		}
+
+		return e.complexity.Category.Path(childComplexity), true
+
+	case "DiscountSavings.discountType":
+		if e.complexity.DiscountSavings.DiscountType == nil {
+			break
+		}
+
+		return e.complexity.DiscountSavings.DiscountType(childComplexity), true
+
+	case "DiscountSavings.fullPrice":
+		if e.complexity.DiscountSavings.FullPrice == nil {
+			break
+		}
+
+		return e.complexity.DiscountSavings.FullPrice(childComplexity), true
+
+	case "DiscountSavings.paidPrice":
+		if e.complexity.DiscountSavings.PaidPrice == nil {
+			break
+		}
+
+		return e.complexity.DiscountSavings.PaidPrice(childComplexity), true
+
+	case "DiscountSavings.purchaseCount":
+		if e.complexity.DiscountSavings.PurchaseCount == nil {
+			break
+		}
+
+		return e.complexity.DiscountSavings.PurchaseCount(childComplexity), true
+
+	case "DiscountSavings.retailer":
+		if e.complexity.DiscountSavings.Retailer == nil {
+			break
+		}
+
+		return e.complexity.DiscountSavings.Retailer(childComplexity), true
+
+	case "DiscountSavings.saved":
+		if e.complexity.DiscountSavings.Saved == nil {
+			break
+		}
+
+		return e.complexity.DiscountSavings.Saved(childComplexity), true
+
+	case "FoodFact.barcode":
+		if e.complexity.FoodFact.Barcode == nil {
+			break
+		}
+
+		return e.complexity.FoodFact.Barcode(childComplexity), true
+
+	case "FoodFact.brands":
+		if e.complexity.FoodFact.Brands == nil {
+			break
+		}
+
+		return e.complexity.FoodFact.Brands(childComplexity), true
+
+	case "FoodFact.carbohydrate":
+		if e.complexity.FoodFact.Carbohydrate == nil {
+			break
+		}
+
+		return e.complexity.FoodFact.Carbohydrate(childComplexity), true
+
+	case "FoodFact.carbohydrateSugars":
+		if e.complexity.FoodFact.CarbohydrateSugars == nil {
+			break
+		}
+
+		return e.complexity.FoodFact.CarbohydrateSugars(childComplexity), true
+
+	case "FoodFact.energyValueKcal":
+		if e.complexity.FoodFact.EnergyValueKcal == nil {
+			break
+		}
+
+		return e.complexity.FoodFact.EnergyValueKcal(childComplexity), true
+
+	case "FoodFact.fat":
+		if e.complexity.FoodFact.Fat == nil {
+			break
+		}
+
+		return e.complexity.FoodFact.Fat(childComplexity), true
+
+	case "FoodFact.fibre":
+		if e.complexity.FoodFact.Fibre == nil {
+			break
+		}
+
+		return e.complexity.FoodFact.Fibre(childComplexity), true
+
+	case "FoodFact.id":
+		if e.complexity.FoodFact.ID == nil {
+			break
+		}
+
+		return e.complexity.FoodFact.ID(childComplexity), true
+
+	case "FoodFact.importedAt":
+		if e.complexity.FoodFact.ImportedAt == nil {
+			break
+		}
+
+		return e.complexity.FoodFact.ImportedAt(childComplexity), true
+
+	case "FoodFact.insolubleFibre":
+		if e.complexity.FoodFact.InsolubleFibre == nil {
+			break
+		}
+
+		return e.complexity.FoodFact.InsolubleFibre(childComplexity), true
+
+	case "FoodFact.name":
+		if e.complexity.FoodFact.Name == nil {
+			break
+		}
+
+		return e.complexity.FoodFact.Name(childComplexity), true
+
+	case "FoodFact.nutrients":
+		if e.complexity.FoodFact.Nutrients == nil {
+			break
+		}
+
+		return e.complexity.FoodFact.Nutrients(childComplexity), true
+
+	case "FoodFact.protein":
+		if e.complexity.FoodFact.Protein == nil {
+			break
+		}
+
+		return e.complexity.FoodFact.Protein(childComplexity), true
+
+	case "FoodFact.salt":
+		if e.complexity.FoodFact.Salt == nil {
+			break
+		}
+
+		return e.complexity.FoodFact.Salt(childComplexity), true
+
+	case "FoodFact.saturatedFat":
+		if e.complexity.FoodFact.SaturatedFat == nil {
+			break
+		}
+
+		return e.complexity.FoodFact.SaturatedFat(childComplexity), true
+
+	case "FoodFact.solubleFibre":
+		if e.complexity.FoodFact.SolubleFibre == nil {
+			break
+		}
+
+		return e.complexity.FoodFact.SolubleFibre(childComplexity), true
+
+	case "FoodFact.source":
+		if e.complexity.FoodFact.Source == nil {
+			break
+		}
+
+		return e.complexity.FoodFact.Source(childComplexity), true
+
+	case "FoodFact.sourceId":
+		if e.complexity.FoodFact.SourceID == nil {
+			break
+		}
+
+		return e.complexity.FoodFact.SourceID(childComplexity), true
+
+	case "FoodFact.unit":
+		if e.complexity.FoodFact.Unit == nil {
+			break
+		}
+
+		return e.complexity.FoodFact.Unit(childComplexity), true
+
 	case "Ingredient.notes":
 		if e.complexity.Ingredient.Notes == nil {
 			break
@@ -476,6 +893,30 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Ingredient.Unit(childComplexity), true
 
+	case "Mutation.applyFoodFact":
+		if e.complexity.Mutation.ApplyFoodFact == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_applyFoodFact_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.ApplyFoodFact(childComplexity, args["foodFactId"].(string), args["productId"].(string), args["varietyName"].(string)), true
+
+	case "Mutation.createCategory":
+		if e.complexity.Mutation.CreateCategory == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_createCategory_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.CreateCategory(childComplexity, args["name"].(string), args["parentId"].(*string)), true
+
 	case "Mutation.createProduct":
 		if e.complexity.Mutation.CreateProduct == nil {
 			break
@@ -488,6 +929,18 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Mutation.CreateProduct(childComplexity, args["input"].(model.ProductAggregateInput)), true
 
+	case "Mutation.deleteCategory":
+		if e.complexity.Mutation.DeleteCategory == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_deleteCategory_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.DeleteCategory(childComplexity, args["id"].(string)), true
+
 	case "Mutation.deleteNutritionalValue":
 		if e.complexity.Mutation.DeleteNutritionalValue == nil {
 			break
@@ -512,6 +965,18 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Mutation.DeleteProduct(childComplexity, args["id"].(string)), true
 
+	case "Mutation.deleteProductAlias":
+		if e.complexity.Mutation.DeleteProductAlias == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_deleteProductAlias_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.DeleteProductAlias(childComplexity, args["id"].(string)), true
+
 	case "Mutation.deletePurchase":
 		if e.complexity.Mutation.DeletePurchase == nil {
 			break
@@ -548,77 +1013,212 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Mutation.PlanRecipes(childComplexity, args["date"].(string), args["planRecipes"].([]*model.PlanRecipe)), true
 
-	case "Mutation.updatePreparedRecipe":
-		if e.complexity.Mutation.UpdatePreparedRecipe == nil {
+	case "Mutation.reassignProductAliases":
+		if e.complexity.Mutation.ReassignProductAliases == nil {
 			break
 		}
 
-		args, err := ec.field_Mutation_updatePreparedRecipe_args(context.TODO(), rawArgs)
+		args, err := ec.field_Mutation_reassignProductAliases_args(context.TODO(), rawArgs)
 		if err != nil {
 			return 0, false
 		}
 
-		return e.complexity.Mutation.UpdatePreparedRecipe(childComplexity, args["recipe"].(model.PreparedRecipeInput)), true
+		return e.complexity.Mutation.ReassignProductAliases(childComplexity, args["ids"].([]string), args["name"].(string), args["varietyName"].(*string)), true
 
-	case "Mutation.updateProduct":
-		if e.complexity.Mutation.UpdateProduct == nil {
+	case "Mutation.setProductCategory":
+		if e.complexity.Mutation.SetProductCategory == nil {
 			break
 		}
 
-		args, err := ec.field_Mutation_updateProduct_args(context.TODO(), rawArgs)
+		args, err := ec.field_Mutation_setProductCategory_args(context.TODO(), rawArgs)
 		if err != nil {
 			return 0, false
 		}
 
-		return e.complexity.Mutation.UpdateProduct(childComplexity, args["id"].(string), args["name"].(string)), true
+		return e.complexity.Mutation.SetProductCategory(childComplexity, args["productId"].(string), args["categoryId"].(*string)), true
 
-	case "Mutation.updatePurchase":
-		if e.complexity.Mutation.UpdatePurchase == nil {
+	case "Mutation.setProductReferenceFood":
+		if e.complexity.Mutation.SetProductReferenceFood == nil {
 			break
 		}
 
-		args, err := ec.field_Mutation_updatePurchase_args(context.TODO(), rawArgs)
+		args, err := ec.field_Mutation_setProductReferenceFood_args(context.TODO(), rawArgs)
 		if err != nil {
 			return 0, false
 		}
 
-		return e.complexity.Mutation.UpdatePurchase(childComplexity, args["id"].(string), args["input"].(model.PurchaseInput)), true
+		return e.complexity.Mutation.SetProductReferenceFood(childComplexity, args["productId"].(string), args["foodFactId"].(*string)), true
 
-	case "Mutation.updateRecipe":
-		if e.complexity.Mutation.UpdateRecipe == nil {
+	case "Mutation.setProductTags":
+		if e.complexity.Mutation.SetProductTags == nil {
 			break
 		}
 
-		args, err := ec.field_Mutation_updateRecipe_args(context.TODO(), rawArgs)
+		args, err := ec.field_Mutation_setProductTags_args(context.TODO(), rawArgs)
 		if err != nil {
 			return 0, false
 		}
 
-		return e.complexity.Mutation.UpdateRecipe(childComplexity, args["recipe"].(model.RecipeInput)), true
+		return e.complexity.Mutation.SetProductTags(childComplexity, args["productId"].(string), args["tags"].([]string)), true
 
-	case "Mutation.updateVariety":
-		if e.complexity.Mutation.UpdateVariety == nil {
+	case "Mutation.updateCategory":
+		if e.complexity.Mutation.UpdateCategory == nil {
 			break
 		}
 
-		args, err := ec.field_Mutation_updateVariety_args(context.TODO(), rawArgs)
+		args, err := ec.field_Mutation_updateCategory_args(context.TODO(), rawArgs)
 		if err != nil {
 			return 0, false
 		}
 
-		return e.complexity.Mutation.UpdateVariety(childComplexity, args["oldName"].(string), args["varietyName"].(string)), true
+		return e.complexity.Mutation.UpdateCategory(childComplexity, args["id"].(string), args["name"].(string), args["parentId"].(*string)), true
 
-	case "Mutation.upsertNutritionalValue":
-		if e.complexity.Mutation.UpsertNutritionalValue == nil {
+	case "Mutation.updatePreparedRecipe":
+		if e.complexity.Mutation.UpdatePreparedRecipe == nil {
 			break
 		}
 
-		args, err := ec.field_Mutation_upsertNutritionalValue_args(context.TODO(), rawArgs)
+		args, err := ec.field_Mutation_updatePreparedRecipe_args(context.TODO(), rawArgs)
 		if err != nil {
 			return 0, false
 		}
 
-		return e.complexity.Mutation.UpsertNutritionalValue(childComplexity, args["productID"].(string), args["varietyName"].(string), args["input"].(model.NutritionalValueInput)), true
+		return e.complexity.Mutation.UpdatePreparedRecipe(childComplexity, args["recipe"].(model.PreparedRecipeInput)), true
+
+	case "Mutation.updateProduct":
+		if e.complexity.Mutation.UpdateProduct == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_updateProduct_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.UpdateProduct(childComplexity, args["id"].(string), args["name"].(string)), true
+
+	case "Mutation.updateProductAlias":
+		if e.complexity.Mutation.UpdateProductAlias == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_updateProductAlias_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.UpdateProductAlias(childComplexity, args["id"].(string), args["name"].(string), args["varietyName"].(*string)), true
+
+	case "Mutation.updatePurchase":
+		if e.complexity.Mutation.UpdatePurchase == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_updatePurchase_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.UpdatePurchase(childComplexity, args["id"].(string), args["input"].(model.PurchaseInput)), true
+
+	case "Mutation.updateRecipe":
+		if e.complexity.Mutation.UpdateRecipe == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_updateRecipe_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.UpdateRecipe(childComplexity, args["recipe"].(model.RecipeInput)), true
+
+	case "Mutation.updateVariety":
+		if e.complexity.Mutation.UpdateVariety == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_updateVariety_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.UpdateVariety(childComplexity, args["oldName"].(string), args["varietyName"].(string)), true
+
+	case "Mutation.upsertNutritionalValue":
+		if e.complexity.Mutation.UpsertNutritionalValue == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_upsertNutritionalValue_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.UpsertNutritionalValue(childComplexity, args["productID"].(string), args["varietyName"].(string), args["input"].(model.NutritionalValueInput)), true
+
+	case "Nutrient.code":
+		if e.complexity.Nutrient.Code == nil {
+			break
+		}
+
+		return e.complexity.Nutrient.Code(childComplexity), true
+
+	case "Nutrient.dailyReference":
+		if e.complexity.Nutrient.DailyReference == nil {
+			break
+		}
+
+		return e.complexity.Nutrient.DailyReference(childComplexity), true
+
+	case "Nutrient.name":
+		if e.complexity.Nutrient.Name == nil {
+			break
+		}
+
+		return e.complexity.Nutrient.Name(childComplexity), true
+
+	case "Nutrient.unit":
+		if e.complexity.Nutrient.Unit == nil {
+			break
+		}
+
+		return e.complexity.Nutrient.Unit(childComplexity), true
+
+	case "NutrientAmount.amount":
+		if e.complexity.NutrientAmount.Amount == nil {
+			break
+		}
+
+		return e.complexity.NutrientAmount.Amount(childComplexity), true
+
+	case "NutrientAmount.code":
+		if e.complexity.NutrientAmount.Code == nil {
+			break
+		}
+
+		return e.complexity.NutrientAmount.Code(childComplexity), true
+
+	case "NutrientAmount.dailyReferenceShare":
+		if e.complexity.NutrientAmount.DailyReferenceShare == nil {
+			break
+		}
+
+		return e.complexity.NutrientAmount.DailyReferenceShare(childComplexity), true
+
+	case "NutrientAmount.name":
+		if e.complexity.NutrientAmount.Name == nil {
+			break
+		}
+
+		return e.complexity.NutrientAmount.Name(childComplexity), true
+
+	case "NutrientAmount.unit":
+		if e.complexity.NutrientAmount.Unit == nil {
+			break
+		}
+
+		return e.complexity.NutrientAmount.Unit(childComplexity), true
 
 	case "NutritionalValue.carbohydrate":
 		if e.complexity.NutritionalValue.Carbohydrate == nil {
@@ -662,6 +1262,20 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.NutritionalValue.ID(childComplexity), true
 
+	case "NutritionalValue.insolubleFibre":
+		if e.complexity.NutritionalValue.InsolubleFibre == nil {
+			break
+		}
+
+		return e.complexity.NutritionalValue.InsolubleFibre(childComplexity), true
+
+	case "NutritionalValue.nutrients":
+		if e.complexity.NutritionalValue.Nutrients == nil {
+			break
+		}
+
+		return e.complexity.NutritionalValue.Nutrients(childComplexity), true
+
 	case "NutritionalValue.protein":
 		if e.complexity.NutritionalValue.Protein == nil {
 			break
@@ -683,6 +1297,34 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.NutritionalValue.SaturatedFat(childComplexity), true
 
+	case "NutritionalValue.solubleFibre":
+		if e.complexity.NutritionalValue.SolubleFibre == nil {
+			break
+		}
+
+		return e.complexity.NutritionalValue.SolubleFibre(childComplexity), true
+
+	case "NutritionalValue.source":
+		if e.complexity.NutritionalValue.Source == nil {
+			break
+		}
+
+		return e.complexity.NutritionalValue.Source(childComplexity), true
+
+	case "NutritionalValue.sourceId":
+		if e.complexity.NutritionalValue.SourceID == nil {
+			break
+		}
+
+		return e.complexity.NutritionalValue.SourceID(childComplexity), true
+
+	case "NutritionalValue.sourcedAt":
+		if e.complexity.NutritionalValue.SourcedAt == nil {
+			break
+		}
+
+		return e.complexity.NutritionalValue.SourcedAt(childComplexity), true
+
 	case "NutritionalValue.unit":
 		if e.complexity.NutritionalValue.Unit == nil {
 			break
@@ -732,6 +1374,153 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.PreparedRecipeAggregate.Steps(childComplexity), true
 
+	case "PriceHistory.bucket":
+		if e.complexity.PriceHistory.Bucket == nil {
+			break
+		}
+
+		return e.complexity.PriceHistory.Bucket(childComplexity), true
+
+	case "PriceHistory.max":
+		if e.complexity.PriceHistory.Max == nil {
+			break
+		}
+
+		return e.complexity.PriceHistory.Max(childComplexity), true
+
+	case "PriceHistory.mean":
+		if e.complexity.PriceHistory.Mean == nil {
+			break
+		}
+
+		return e.complexity.PriceHistory.Mean(childComplexity), true
+
+	case "PriceHistory.min":
+		if e.complexity.PriceHistory.Min == nil {
+			break
+		}
+
+		return e.complexity.PriceHistory.Min(childComplexity), true
+
+	case "PriceHistory.points":
+		if e.complexity.PriceHistory.Points == nil {
+			break
+		}
+
+		return e.complexity.PriceHistory.Points(childComplexity), true
+
+	case "PriceHistory.trend":
+		if e.complexity.PriceHistory.Trend == nil {
+			break
+		}
+
+		return e.complexity.PriceHistory.Trend(childComplexity), true
+
+	case "PriceHistory.trendDirection":
+		if e.complexity.PriceHistory.TrendDirection == nil {
+			break
+		}
+
+		return e.complexity.PriceHistory.TrendDirection(childComplexity), true
+
+	case "PriceHistory.unit":
+		if e.complexity.PriceHistory.Unit == nil {
+			break
+		}
+
+		return e.complexity.PriceHistory.Unit(childComplexity), true
+
+	case "PricePoint.max":
+		if e.complexity.PricePoint.Max == nil {
+			break
+		}
+
+		return e.complexity.PricePoint.Max(childComplexity), true
+
+	case "PricePoint.mean":
+		if e.complexity.PricePoint.Mean == nil {
+			break
+		}
+
+		return e.complexity.PricePoint.Mean(childComplexity), true
+
+	case "PricePoint.min":
+		if e.complexity.PricePoint.Min == nil {
+			break
+		}
+
+		return e.complexity.PricePoint.Min(childComplexity), true
+
+	case "PricePoint.periodStart":
+		if e.complexity.PricePoint.PeriodStart == nil {
+			break
+		}
+
+		return e.complexity.PricePoint.PeriodStart(childComplexity), true
+
+	case "PricePoint.purchases":
+		if e.complexity.PricePoint.Purchases == nil {
+			break
+		}
+
+		return e.complexity.PricePoint.Purchases(childComplexity), true
+
+	case "PriceSource.date":
+		if e.complexity.PriceSource.Date == nil {
+			break
+		}
+
+		return e.complexity.PriceSource.Date(childComplexity), true
+
+	case "PriceSource.price":
+		if e.complexity.PriceSource.Price == nil {
+			break
+		}
+
+		return e.complexity.PriceSource.Price(childComplexity), true
+
+	case "PriceSource.purchaseId":
+		if e.complexity.PriceSource.PurchaseID == nil {
+			break
+		}
+
+		return e.complexity.PriceSource.PurchaseID(childComplexity), true
+
+	case "PriceSource.quantity":
+		if e.complexity.PriceSource.Quantity == nil {
+			break
+		}
+
+		return e.complexity.PriceSource.Quantity(childComplexity), true
+
+	case "PriceSource.retailer":
+		if e.complexity.PriceSource.Retailer == nil {
+			break
+		}
+
+		return e.complexity.PriceSource.Retailer(childComplexity), true
+
+	case "PriceSource.unit":
+		if e.complexity.PriceSource.Unit == nil {
+			break
+		}
+
+		return e.complexity.PriceSource.Unit(childComplexity), true
+
+	case "PriceSource.varietyName":
+		if e.complexity.PriceSource.VarietyName == nil {
+			break
+		}
+
+		return e.complexity.PriceSource.VarietyName(childComplexity), true
+
+	case "Product.categoryId":
+		if e.complexity.Product.CategoryID == nil {
+			break
+		}
+
+		return e.complexity.Product.CategoryID(childComplexity), true
+
 	case "Product.id":
 		if e.complexity.Product.ID == nil {
 			break
@@ -746,6 +1535,20 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Product.Name(childComplexity), true
 
+	case "Product.referenceFoodId":
+		if e.complexity.Product.ReferenceFoodID == nil {
+			break
+		}
+
+		return e.complexity.Product.ReferenceFoodID(childComplexity), true
+
+	case "Product.tags":
+		if e.complexity.Product.Tags == nil {
+			break
+		}
+
+		return e.complexity.Product.Tags(childComplexity), true
+
 	case "ProductAggregate.name":
 		if e.complexity.ProductAggregate.Name == nil {
 			break
@@ -753,6 +1556,13 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.ProductAggregate.Name(childComplexity), true
 
+	case "ProductAggregate.referenceFood":
+		if e.complexity.ProductAggregate.ReferenceFood == nil {
+			break
+		}
+
+		return e.complexity.ProductAggregate.ReferenceFood(childComplexity), true
+
 	case "ProductAggregate.varieties":
 		if e.complexity.ProductAggregate.Varieties == nil {
 			break
@@ -760,6 +1570,55 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.ProductAggregate.Varieties(childComplexity), true
 
+	case "ProductAlias.id":
+		if e.complexity.ProductAlias.ID == nil {
+			break
+		}
+
+		return e.complexity.ProductAlias.ID(childComplexity), true
+
+	case "ProductAlias.name":
+		if e.complexity.ProductAlias.Name == nil {
+			break
+		}
+
+		return e.complexity.ProductAlias.Name(childComplexity), true
+
+	case "ProductAlias.parsedName":
+		if e.complexity.ProductAlias.ParsedName == nil {
+			break
+		}
+
+		return e.complexity.ProductAlias.ParsedName(childComplexity), true
+
+	case "ProductAlias.purchases":
+		if e.complexity.ProductAlias.Purchases == nil {
+			break
+		}
+
+		return e.complexity.ProductAlias.Purchases(childComplexity), true
+
+	case "ProductAlias.varietyName":
+		if e.complexity.ProductAlias.VarietyName == nil {
+			break
+		}
+
+		return e.complexity.ProductAlias.VarietyName(childComplexity), true
+
+	case "ProductAliasesUpdate.aliases":
+		if e.complexity.ProductAliasesUpdate.Aliases == nil {
+			break
+		}
+
+		return e.complexity.ProductAliasesUpdate.Aliases(childComplexity), true
+
+	case "ProductAliasesUpdate.unconfirmedReceiptIds":
+		if e.complexity.ProductAliasesUpdate.UnconfirmedReceiptIds == nil {
+			break
+		}
+
+		return e.complexity.ProductAliasesUpdate.UnconfirmedReceiptIds(childComplexity), true
+
 	case "Purchase.date":
 		if e.complexity.Purchase.Date == nil {
 			break
@@ -767,6 +1626,34 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Purchase.Date(childComplexity), true
 
+	case "Purchase.deposit":
+		if e.complexity.Purchase.Deposit == nil {
+			break
+		}
+
+		return e.complexity.Purchase.Deposit(childComplexity), true
+
+	case "Purchase.discount":
+		if e.complexity.Purchase.Discount == nil {
+			break
+		}
+
+		return e.complexity.Purchase.Discount(childComplexity), true
+
+	case "Purchase.discountType":
+		if e.complexity.Purchase.DiscountType == nil {
+			break
+		}
+
+		return e.complexity.Purchase.DiscountType(childComplexity), true
+
+	case "Purchase.fullPrice":
+		if e.complexity.Purchase.FullPrice == nil {
+			break
+		}
+
+		return e.complexity.Purchase.FullPrice(childComplexity), true
+
 	case "Purchase.id":
 		if e.complexity.Purchase.ID == nil {
 			break
@@ -819,7 +1706,33 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 			return 0, false
 		}
 
-		return e.complexity.Query.CalculateDaysConsumption(childComplexity, args["date"].(string)), true
+		return e.complexity.Query.CalculateDaysConsumption(childComplexity, args["date"].(string), args["priceStrategy"].(*model.PriceStrategyInput)), true
+
+	case "Query.categories":
+		if e.complexity.Query.Categories == nil {
+			break
+		}
+
+		return e.complexity.Query.Categories(childComplexity), true
+
+	case "Query.discountSavings":
+		if e.complexity.Query.DiscountSavings == nil {
+			break
+		}
+
+		args, err := ec.field_Query_discountSavings_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.DiscountSavings(childComplexity, args["dateFrom"].(string), args["dateTo"].(string), args["categoryId"].(*string), args["tag"].(*string)), true
+
+	case "Query.nutrients":
+		if e.complexity.Query.Nutrients == nil {
+			break
+		}
+
+		return e.complexity.Query.Nutrients(childComplexity), true
 
 	case "Query.preparedRecipe":
 		if e.complexity.Query.PreparedRecipe == nil {
@@ -845,6 +1758,18 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Query.PreparedRecipesByDate(childComplexity, args["date"].(string)), true
 
+	case "Query.priceHistory":
+		if e.complexity.Query.PriceHistory == nil {
+			break
+		}
+
+		args, err := ec.field_Query_priceHistory_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.PriceHistory(childComplexity, args["productID"].(string), args["varietyName"].(*string), args["unit"].(string), args["bucket"].(string), args["retailer"].(*string)), true
+
 	case "Query.productAggregate":
 		if e.complexity.Query.ProductAggregate == nil {
 			break
@@ -857,12 +1782,29 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Query.ProductAggregate(childComplexity, args["id"].(string)), true
 
+	case "Query.productAliases":
+		if e.complexity.Query.ProductAliases == nil {
+			break
+		}
+
+		args, err := ec.field_Query_productAliases_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.ProductAliases(childComplexity, args["search"].(*string)), true
+
 	case "Query.products":
 		if e.complexity.Query.Products == nil {
 			break
 		}
 
-		return e.complexity.Query.Products(childComplexity), true
+		args, err := ec.field_Query_products_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.Products(childComplexity, args["categoryId"].(*string), args["tag"].(*string)), true
 
 	case "Query.recipe":
 		if e.complexity.Query.Recipe == nil {
@@ -883,6 +1825,25 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Query.Recipes(childComplexity), true
 
+	case "Query.searchFoodFacts":
+		if e.complexity.Query.SearchFoodFacts == nil {
+			break
+		}
+
+		args, err := ec.field_Query_searchFoodFacts_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.SearchFoodFacts(childComplexity, args["search"].(string), args["source"].(*string), args["limit"].(*int32)), true
+
+	case "Query.tags":
+		if e.complexity.Query.Tags == nil {
+			break
+		}
+
+		return e.complexity.Query.Tags(childComplexity), true
+
 	case "RecipeAggregate.ingredients":
 		if e.complexity.RecipeAggregate.Ingredients == nil {
 			break
@@ -948,9 +1909,11 @@ func (e *executableSchema) Exec(ctx context.Context) graphql.ResponseHandler {
 	ec := executionContext{opCtx, e, 0, 0, make(chan graphql.DeferredResult)}
 	inputUnmarshalMap := graphql.BuildUnmarshalerMap(
 		ec.unmarshalInputIngredientInput,
+		ec.unmarshalInputNutrientAmountInput,
 		ec.unmarshalInputNutritionalValueInput,
 		ec.unmarshalInputPlanRecipe,
 		ec.unmarshalInputPreparedRecipeInput,
+		ec.unmarshalInputPriceStrategyInput,
 		ec.unmarshalInputProductAggregateInput,
 		ec.unmarshalInputPurchaseInput,
 		ec.unmarshalInputRecipeInput,
@@ -1050,7 +2013,7 @@ func (ec *executionContext) introspectType(name string) (*introspection.Type, er
 	return introspection.WrapTypeFromDef(ec.Schema(), ec.Schema().Types[name]), nil
 }
 
-//go:embed "product.graphqls" "recipe.graphqls"
+//go:embed "alias.graphqls" "category.graphqls" "foodfact.graphqls" "nutrient.graphqls" "product.graphqls" "recipe.graphqls"
 var sourcesFS embed.FS
 
 func sourceData(filename string) string {
@@ -1062,6 +2025,10 @@ func sourceData(filename string) string {
 }
 
 var sources = []*ast.Source{
+	{Name: "alias.graphqls", Input: sourceData("alias.graphqls"), BuiltIn: false},
+	{Name: "category.graphqls", Input: sourceData("category.graphqls"), BuiltIn: false},
+	{Name: "foodfact.graphqls", Input: sourceData("foodfact.graphqls"), BuiltIn: false},
+	{Name: "nutrient.graphqls", Input: sourceData("nutrient.graphqls"), BuiltIn: false},
 	{Name: "product.graphqls", Input: sourceData("product.graphqls"), BuiltIn: false},
 	{Name: "recipe.graphqls", Input: sourceData("recipe.graphqls"), BuiltIn: false},
 }
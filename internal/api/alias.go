@@ -0,0 +1,80 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/SarunasBucius/nutri-price-server/internal/model"
+	"github.com/SarunasBucius/nutri-price-server/internal/utils/uerror"
+	"github.com/go-chi/chi/v5"
+)
+
+type AliasAPI struct {
+	Service IAliasService
+}
+
+func NewAliasAPI(aliasService IAliasService) *AliasAPI {
+	return &AliasAPI{Service: aliasService}
+}
+
+type IAliasService interface {
+	GetProductAliases(ctx context.Context, search string) ([]model.ProductAlias, error)
+	UpdateProductAlias(ctx context.Context, aliasID string, alias model.ProductAndVarietyName) (model.ProductAliasesUpdate, error)
+	ReassignProductAliases(ctx context.Context, aliasIDs []string, alias model.ProductAndVarietyName) (model.ProductAliasesUpdate, error)
+	DeleteProductAlias(ctx context.Context, aliasID string) error
+}
+
+func (a *AliasAPI) GetProductAliases(w http.ResponseWriter, r *http.Request) {
+	aliases, err := a.Service.GetProductAliases(r.Context(), r.URL.Query().Get("search"))
+	if err != nil {
+		errorResponse(r.Context(), w, err)
+		return
+	}
+
+	successResponse(r.Context(), w, map[string]any{"aliases": emptyIfNil(aliases)})
+}
+
+func (a *AliasAPI) UpdateProductAlias(w http.ResponseWriter, r *http.Request) {
+	var alias model.ProductAndVarietyName
+	if err := json.NewDecoder(r.Body).Decode(&alias); err != nil {
+		errorResponse(r.Context(), w, uerror.NewBadRequest("invalid request body", err))
+		return
+	}
+
+	update, err := a.Service.UpdateProductAlias(r.Context(), chi.URLParam(r, "aliasID"), alias)
+	if err != nil {
+		errorResponse(r.Context(), w, err)
+		return
+	}
+
+	successResponse(r.Context(), w, update)
+}
+
+func (a *AliasAPI) ReassignProductAliases(w http.ResponseWriter, r *http.Request) {
+	var request model.ReassignProductAliasesRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		errorResponse(r.Context(), w, uerror.NewBadRequest("invalid request body", err))
+		return
+	}
+
+	update, err := a.Service.ReassignProductAliases(r.Context(), request.AliasIDs, model.ProductAndVarietyName{
+		Name:        request.Name,
+		VarietyName: request.VarietyName,
+	})
+	if err != nil {
+		errorResponse(r.Context(), w, err)
+		return
+	}
+
+	successResponse(r.Context(), w, update)
+}
+
+func (a *AliasAPI) DeleteProductAlias(w http.ResponseWriter, r *http.Request) {
+	if err := a.Service.DeleteProductAlias(r.Context(), chi.URLParam(r, "aliasID")); err != nil {
+		errorResponse(r.Context(), w, err)
+		return
+	}
+
+	successResponse(r.Context(), w, newSuccessMessage("successfully deleted product alias"))
+}
@@ -0,0 +1,71 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/SarunasBucius/nutri-price-server/internal/model"
+	"github.com/SarunasBucius/nutri-price-server/internal/utils/uerror"
+	"github.com/go-chi/chi/v5"
+)
+
+type BudgetAPI struct {
+	Service IBudgetService
+}
+
+func NewBudgetAPI(budgetService IBudgetService) *BudgetAPI {
+	return &BudgetAPI{Service: budgetService}
+}
+
+type IBudgetService interface {
+	UpsertBudget(ctx context.Context, budget model.Budget) (model.Budget, error)
+	DeleteBudget(ctx context.Context, budgetID string) error
+	GetBudgetStatuses(ctx context.Context, month string) ([]model.BudgetStatus, error)
+	GetBudgetAlerts(ctx context.Context, month string) ([]model.BudgetAlert, error)
+}
+
+func (b *BudgetAPI) UpsertBudget(w http.ResponseWriter, r *http.Request) {
+	var budget model.Budget
+	if err := json.NewDecoder(r.Body).Decode(&budget); err != nil {
+		errorResponse(r.Context(), w, uerror.NewBadRequest("invalid request body", err))
+		return
+	}
+
+	budget, err := b.Service.UpsertBudget(r.Context(), budget)
+	if err != nil {
+		errorResponse(r.Context(), w, err)
+		return
+	}
+
+	successResponse(r.Context(), w, budget)
+}
+
+func (b *BudgetAPI) DeleteBudget(w http.ResponseWriter, r *http.Request) {
+	if err := b.Service.DeleteBudget(r.Context(), chi.URLParam(r, "budgetID")); err != nil {
+		errorResponse(r.Context(), w, err)
+		return
+	}
+
+	successResponse(r.Context(), w, newSuccessMessage("successfully deleted budget"))
+}
+
+func (b *BudgetAPI) GetBudgetStatuses(w http.ResponseWriter, r *http.Request) {
+	statuses, err := b.Service.GetBudgetStatuses(r.Context(), r.URL.Query().Get("month"))
+	if err != nil {
+		errorResponse(r.Context(), w, err)
+		return
+	}
+
+	successResponse(r.Context(), w, emptyIfNil(statuses))
+}
+
+func (b *BudgetAPI) GetBudgetAlerts(w http.ResponseWriter, r *http.Request) {
+	alerts, err := b.Service.GetBudgetAlerts(r.Context(), r.URL.Query().Get("month"))
+	if err != nil {
+		errorResponse(r.Context(), w, err)
+		return
+	}
+
+	successResponse(r.Context(), w, emptyIfNil(alerts))
+}
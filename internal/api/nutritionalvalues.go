@@ -26,6 +26,8 @@ type INutritionalValueService interface {
 	UpdateProductNutritionalValue(ctx context.Context, productNV model.ProductNutritionalValue) error
 	DeleteProductNutritionalValue(ctx context.Context, nvID int) error
 	GetNutritionalValuesUnits(ctx context.Context) ([]model.NutritionalValueUnits, error)
+	GetNutrients(ctx context.Context) ([]model.Nutrient, error)
+	UpsertNutrient(ctx context.Context, nutrient model.Nutrient) error
 }
 
 func (n *NutritionalValueAPI) InsertNutritionalValues(w http.ResponseWriter, r *http.Request) {
@@ -123,3 +125,29 @@ func (n *NutritionalValueAPI) DeleteNutritionalValues(w http.ResponseWriter, r *
 
 	successResponse(r.Context(), w, newSuccessMessage("successfully deleted product nutritional value"))
 }
+
+func (n *NutritionalValueAPI) GetNutrients(w http.ResponseWriter, r *http.Request) {
+	nutrients, err := n.Service.GetNutrients(r.Context())
+	if err != nil {
+		errorResponse(r.Context(), w, err)
+		return
+	}
+
+	successResponse(r.Context(), w, map[string]any{"nutrients": emptyIfNil(nutrients)})
+}
+
+func (n *NutritionalValueAPI) UpsertNutrient(w http.ResponseWriter, r *http.Request) {
+	var nutrient model.Nutrient
+	if err := json.NewDecoder(r.Body).Decode(&nutrient); err != nil {
+		errorResponse(r.Context(), w, uerror.NewBadRequest("invalid request body", err))
+		return
+	}
+	nutrient.Code = chi.URLParam(r, "code")
+
+	if err := n.Service.UpsertNutrient(r.Context(), nutrient); err != nil {
+		errorResponse(r.Context(), w, err)
+		return
+	}
+
+	successResponse(r.Context(), w, newSuccessMessage("successfully saved nutrient"))
+}
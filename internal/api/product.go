@@ -4,9 +4,12 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/SarunasBucius/nutri-price-server/internal/model"
 	"github.com/SarunasBucius/nutri-price-server/internal/utils/uerror"
+	"github.com/go-chi/chi/v5"
 )
 
 type ProductAPI struct {
@@ -18,7 +21,10 @@ func NewProductAPI(productService IProductService) *ProductAPI {
 }
 
 type IProductService interface {
-	ConfirmPurchasedProducts(ctx context.Context, retailer, receiptDate string, products []model.PurchasedProductNew) error
+	ConfirmPurchasedProducts(ctx context.Context, receiptID, retailer, receiptDate string, products []model.PurchasedProductNew) error
+	GetProductByBarcode(ctx context.Context, barcode string) (model.BarcodeProduct, error)
+	CompareBasket(ctx context.Context, request model.BasketComparisonRequest) (model.BasketComparison, error)
+	GetPriceIndex(ctx context.Context, request model.PriceIndexRequest) (model.PriceIndex, error)
 }
 
 func (p *ProductAPI) ConfirmPurchasedProducts(w http.ResponseWriter, r *http.Request) {
@@ -27,11 +33,82 @@ func (p *ProductAPI) ConfirmPurchasedProducts(w http.ResponseWriter, r *http.Req
 		errorResponse(r.Context(), w, uerror.NewBadRequest("invalid request body", err))
 		return
 	}
+	if purchasedProducts.ReceiptID != "" {
+		if err := validateReceiptID(purchasedProducts.ReceiptID); err != nil {
+			errorResponse(r.Context(), w, err)
+			return
+		}
+	}
 
-	if err := p.Service.ConfirmPurchasedProducts(r.Context(), purchasedProducts.Retailer, purchasedProducts.Date, purchasedProducts.Products); err != nil {
+	if err := p.Service.ConfirmPurchasedProducts(r.Context(), purchasedProducts.ReceiptID, purchasedProducts.Retailer, purchasedProducts.Date, purchasedProducts.Products); err != nil {
 		errorResponse(r.Context(), w, err)
 		return
 	}
 
 	successResponse(r.Context(), w, newSuccessMessage("successfully confirmed products"))
 }
+
+func (p *ProductAPI) GetProductByBarcode(w http.ResponseWriter, r *http.Request) {
+	product, err := p.Service.GetProductByBarcode(r.Context(), chi.URLParam(r, "barcode"))
+	if err != nil {
+		errorResponse(r.Context(), w, err)
+		return
+	}
+
+	successResponse(r.Context(), w, product)
+}
+
+func (p *ProductAPI) CompareBasket(w http.ResponseWriter, r *http.Request) {
+	var request model.BasketComparisonRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		errorResponse(r.Context(), w, uerror.NewBadRequest("invalid request body", err))
+		return
+	}
+
+	comparison, err := p.Service.CompareBasket(r.Context(), request)
+	if err != nil {
+		errorResponse(r.Context(), w, err)
+		return
+	}
+
+	successResponse(r.Context(), w, comparison)
+}
+
+func (p *ProductAPI) GetPriceIndex(w http.ResponseWriter, r *http.Request) {
+	filter, err := getProductFilter(r)
+	if err != nil {
+		errorResponse(r.Context(), w, err)
+		return
+	}
+
+	query := r.URL.Query()
+	request := model.PriceIndexRequest{
+		From:   query.Get("from"),
+		To:     query.Get("to"),
+		Filter: filter,
+	}
+	if products := query.Get("products"); products != "" {
+		request.Products = strings.Split(products, ",")
+	}
+
+	if minMonths := query.Get("minMonths"); minMonths != "" {
+		if request.MinMonths, err = strconv.Atoi(minMonths); err != nil {
+			errorResponse(r.Context(), w, uerror.NewBadRequest("invalid minMonths", err))
+			return
+		}
+	}
+	if limit := query.Get("limit"); limit != "" {
+		if request.Limit, err = strconv.Atoi(limit); err != nil {
+			errorResponse(r.Context(), w, uerror.NewBadRequest("invalid limit", err))
+			return
+		}
+	}
+
+	index, err := p.Service.GetPriceIndex(r.Context(), request)
+	if err != nil {
+		errorResponse(r.Context(), w, err)
+		return
+	}
+
+	successResponse(r.Context(), w, index)
+}
@@ -3,15 +3,26 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"io"
+	"mime"
 	"net/http"
-	"strings"
+	"strconv"
 
 	"github.com/SarunasBucius/nutri-price-server/internal/model"
 	"github.com/SarunasBucius/nutri-price-server/internal/utils/uerror"
 	"github.com/go-chi/chi/v5"
 )
 
+const (
+	maxReceiptFileSize = 10 << 20
+	maxImportFileSize  = 200 << 20
+	// defaultImportConcurrency is the number of receipts imported at a time unless set by the concurrency query parameter.
+	defaultImportConcurrency = 4
+	// maxImportConcurrency limits the concurrency query parameter, as every receipt imported at a time holds a database connection.
+	maxImportConcurrency = 16
+)
+
 type ReceiptAPI struct {
 	Service IReceiptService
 }
@@ -21,22 +32,43 @@ func NewReceiptAPI(receiptService IReceiptService) *ReceiptAPI {
 }
 
 type IReceiptService interface {
-	ProcessReceipt(ctx context.Context, receipt string) (model.ParseReceiptFromTextResponse, error)
-	ProcessReceiptFromDB(ctx context.Context, receiptDate string) (model.ParseReceiptFromTextResponse, error)
+	ProcessReceipt(ctx context.Context, receipt, retailerHint string) (model.ParseReceiptFromTextResponse, error)
+	ProcessEmailReceipt(ctx context.Context, rawEmail []byte, retailerHint string) (model.ParseReceiptFromTextResponse, error)
+	ProcessPDFReceipt(ctx context.Context, pdfReceipt []byte, retailerHint string) (model.ParseReceiptFromTextResponse, error)
+	ProcessReceiptFromDB(ctx context.Context, receiptID string) (model.ParseReceiptFromTextResponse, error)
+	ImportReceiptZip(ctx context.Context, archive []byte, retailerHint string, concurrency int) (model.ReceiptImportReport, error)
+	RunParserRegression(ctx context.Context) (model.ParserRegressionReport, error)
 	GetUnconfirmedReceiptSummaries(ctx context.Context) ([]model.UnconfirmedReceiptSummary, error)
-	GetUnconfirmedReceipt(ctx context.Context, retailer, date string) ([]model.PurchasedProductNew, error)
+	GetUnconfirmedReceipt(ctx context.Context, receiptID string) ([]model.UnconfirmedProduct, error)
 	GetLastReceiptDates(ctx context.Context) ([]model.LastReceiptDate, error)
-	GetProductsWithMissingInfo(ctx context.Context, dateFrom string) ([]model.ProductAndVarietyName, error)
+	GetProductsWithMissingInfo(ctx context.Context, dateFrom string, filter model.ProductFilter) ([]model.ProductAndVarietyName, error)
+	GetDepositBalance(ctx context.Context) (model.DepositBalance, error)
 }
 
 func (rc *ReceiptAPI) ParseReceiptFromText(w http.ResponseWriter, r *http.Request) {
-	receipt, err := getReceiptFromBody(r)
+	receipt, retailerHint, err := getReceiptFromBody(r)
+	if err != nil {
+		errorResponse(r.Context(), w, err)
+		return
+	}
+
+	processedReceipt, err := rc.Service.ProcessReceipt(r.Context(), receipt, retailerHint)
+	if err != nil {
+		errorResponse(r.Context(), w, err)
+		return
+	}
+
+	successResponse(r.Context(), w, processedReceipt)
+}
+
+func (rc *ReceiptAPI) ParseReceiptFromPDF(w http.ResponseWriter, r *http.Request) {
+	pdfReceipt, err := getUploadedFile(w, r, "receipt", maxReceiptFileSize)
 	if err != nil {
 		errorResponse(r.Context(), w, err)
 		return
 	}
 
-	processedReceipt, err := rc.Service.ProcessReceipt(r.Context(), receipt)
+	processedReceipt, err := rc.Service.ProcessPDFReceipt(r.Context(), pdfReceipt, r.URL.Query().Get("retailer"))
 	if err != nil {
 		errorResponse(r.Context(), w, err)
 		return
@@ -45,10 +77,71 @@ func (rc *ReceiptAPI) ParseReceiptFromText(w http.ResponseWriter, r *http.Reques
 	successResponse(r.Context(), w, processedReceipt)
 }
 
+func (rc *ReceiptAPI) ParseReceiptFromEmail(w http.ResponseWriter, r *http.Request) {
+	rawEmail, err := getUploadedFile(w, r, "receipt", maxReceiptFileSize)
+	if err != nil {
+		errorResponse(r.Context(), w, err)
+		return
+	}
+
+	processedReceipt, err := rc.Service.ProcessEmailReceipt(r.Context(), rawEmail, r.URL.Query().Get("retailer"))
+	if err != nil {
+		errorResponse(r.Context(), w, err)
+		return
+	}
+
+	successResponse(r.Context(), w, processedReceipt)
+}
+
+// ImportReceipts processes every receipt text, PDF and email file of the uploaded zip archive.
+func (rc *ReceiptAPI) ImportReceipts(w http.ResponseWriter, r *http.Request) {
+	concurrency := defaultImportConcurrency
+	if unparsedConcurrency := r.URL.Query().Get("concurrency"); unparsedConcurrency != "" {
+		parsedConcurrency, err := strconv.Atoi(unparsedConcurrency)
+		if err != nil || parsedConcurrency < 1 || parsedConcurrency > maxImportConcurrency {
+			message := fmt.Sprintf("concurrency must be a number from 1 to %d", maxImportConcurrency)
+			errorResponse(r.Context(), w, uerror.NewBadRequest(message, err))
+			return
+		}
+		concurrency = parsedConcurrency
+	}
+
+	archive, err := getUploadedFile(w, r, "archive", maxImportFileSize)
+	if err != nil {
+		errorResponse(r.Context(), w, err)
+		return
+	}
+
+	report, err := rc.Service.ImportReceiptZip(r.Context(), archive, r.URL.Query().Get("retailer"), concurrency)
+	if err != nil {
+		errorResponse(r.Context(), w, err)
+		return
+	}
+	report.Results = emptyIfNil(report.Results)
+
+	successResponse(r.Context(), w, report)
+}
+
+func (rc *ReceiptAPI) RunParserRegression(w http.ResponseWriter, r *http.Request) {
+	report, err := rc.Service.RunParserRegression(r.Context())
+	if err != nil {
+		errorResponse(r.Context(), w, err)
+		return
+	}
+
+	successResponse(r.Context(), w, report)
+}
+
 func (rc *ReceiptAPI) ParseReceiptInDB(w http.ResponseWriter, r *http.Request) {
-	receiptDate := r.URL.Query().Get("receiptDate")
+	receiptID := r.URL.Query().Get("receiptId")
+	if receiptID != "" {
+		if err := validateReceiptID(receiptID); err != nil {
+			errorResponse(r.Context(), w, err)
+			return
+		}
+	}
 
-	processedReceipt, err := rc.Service.ProcessReceiptFromDB(r.Context(), receiptDate)
+	processedReceipt, err := rc.Service.ProcessReceiptFromDB(r.Context(), receiptID)
 	if err != nil {
 		errorResponse(r.Context(), w, err)
 		return
@@ -57,23 +150,53 @@ func (rc *ReceiptAPI) ParseReceiptInDB(w http.ResponseWriter, r *http.Request) {
 	successResponse(r.Context(), w, processedReceipt)
 }
 
-func getReceiptFromBody(r *http.Request) (string, error) {
+// getReceiptFromBody returns receipt text and an optional retailer hint.
+// For text/plain requests the hint is read from the retailer query parameter.
+func getReceiptFromBody(r *http.Request) (string, string, error) {
 	if r.Header.Get("Content-Type") == "text/plain" {
 		body, err := io.ReadAll(r.Body)
 		if err != nil {
-			return "", uerror.NewBadRequest("unable to read text request body", err)
+			return "", "", uerror.NewBadRequest("unable to read text request body", err)
 		}
-		return string(body), nil
+		return string(body), r.URL.Query().Get("retailer"), nil
 	}
 
 	var receipt struct {
-		Receipt string `json:"receipt"`
+		Receipt  string `json:"receipt"`
+		Retailer string `json:"retailer"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&receipt); err != nil {
-		return "", uerror.NewBadRequest("invalid json request body", err)
+		return "", "", uerror.NewBadRequest("invalid json request body", err)
 	}
 
-	return receipt.Receipt, nil
+	return receipt.Receipt, receipt.Retailer, nil
+}
+
+// getUploadedFile returns the uploaded file, sent either as multipart form file formField
+// or as the request body, e.g. application/pdf or message/rfc822.
+func getUploadedFile(w http.ResponseWriter, r *http.Request, formField string, maxFileSize int64) ([]byte, error) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxFileSize)
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType != "multipart/form-data" {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			return nil, uerror.NewBadRequest("unable to read request body", err)
+		}
+		return body, nil
+	}
+
+	file, _, err := r.FormFile(formField)
+	if err != nil {
+		return nil, uerror.NewBadRequest(fmt.Sprintf("missing %s file in form", formField), err)
+	}
+	defer file.Close()
+
+	body, err := io.ReadAll(file)
+	if err != nil {
+		return nil, uerror.NewBadRequest(fmt.Sprintf("unable to read %s file", formField), err)
+	}
+	return body, nil
 }
 
 func (rc *ReceiptAPI) GetUnconfirmedReceiptSummaries(w http.ResponseWriter, r *http.Request) {
@@ -87,14 +210,13 @@ func (rc *ReceiptAPI) GetUnconfirmedReceiptSummaries(w http.ResponseWriter, r *h
 }
 
 func (rc *ReceiptAPI) GetUnconfirmedReceipt(w http.ResponseWriter, r *http.Request) {
-	retailerAndDate := chi.URLParam(r, "retailerAndDate")
-	retailerAndDateSplit := strings.Split(retailerAndDate, "_")
-	if len(retailerAndDateSplit) != 2 {
-		errorResponse(r.Context(), w, uerror.NewBadRequest("invalid retailerAndDate query parameter", nil))
+	receiptID := chi.URLParam(r, "receiptID")
+	if err := validateReceiptID(receiptID); err != nil {
+		errorResponse(r.Context(), w, err)
 		return
 	}
 
-	products, err := rc.Service.GetUnconfirmedReceipt(r.Context(), retailerAndDateSplit[0], retailerAndDateSplit[1])
+	products, err := rc.Service.GetUnconfirmedReceipt(r.Context(), receiptID)
 	if err != nil {
 		errorResponse(r.Context(), w, err)
 		return
@@ -120,7 +242,13 @@ func (rc *ReceiptAPI) GetProductsWithMissingInfo(w http.ResponseWriter, r *http.
 		return
 	}
 
-	products, err := rc.Service.GetProductsWithMissingInfo(r.Context(), dateFrom)
+	filter, err := getProductFilter(r)
+	if err != nil {
+		errorResponse(r.Context(), w, err)
+		return
+	}
+
+	products, err := rc.Service.GetProductsWithMissingInfo(r.Context(), dateFrom, filter)
 	if err != nil {
 		errorResponse(r.Context(), w, err)
 		return
@@ -128,3 +256,13 @@ func (rc *ReceiptAPI) GetProductsWithMissingInfo(w http.ResponseWriter, r *http.
 
 	successResponse(r.Context(), w, emptyIfNil(products))
 }
+
+func (rc *ReceiptAPI) GetDepositBalance(w http.ResponseWriter, r *http.Request) {
+	balance, err := rc.Service.GetDepositBalance(r.Context())
+	if err != nil {
+		errorResponse(r.Context(), w, err)
+		return
+	}
+
+	successResponse(r.Context(), w, balance)
+}
@@ -25,13 +25,16 @@ type IRecipeService interface {
 	GetRecipeSummaries(ctx context.Context) (model.RecipeSummaries, error)
 	GetRecipe(ctx context.Context, recipeID int) (model.Recipe, error)
 	UpdateRecipe(ctx context.Context, recipe model.RecipeUpdate) error
-	GetMealPrice(ctx context.Context, recipeIDs []int) (model.CalculatedMealPrice, error)
+	GetMealPrice(ctx context.Context, recipeIDs []int, strategy model.PriceStrategy) (model.CalculatedMealPrice, error)
 	GetMealNutritionalValue(ctx context.Context, recipeIDs []int) (model.CalculatedMealNutritionalValue, error)
 	DeleteRecipe(ctx context.Context, recipeID int) error
-	GetMealPriceByDate(ctx context.Context, date time.Time) (model.CalculatedMealPrice, error)
+	GetMealPriceByDate(ctx context.Context, date time.Time, strategy model.PriceStrategy) (model.CalculatedMealPrice, error)
 	GetMealNutritionalValueByDate(ctx context.Context, date time.Time) (model.CalculatedMealNutritionalValue, error)
 	CloneRecipes(ctx context.Context, recipeIDs []model.RecipeIDWithMultiplier, date string) error
 	GetRecipeNames(ctx context.Context) ([]model.RecipeIDAndName, error)
+	GetUnitConversions(ctx context.Context) ([]model.UnitConversion, error)
+	UpsertUnitConversion(ctx context.Context, conversion model.UnitConversion) error
+	DeleteUnitConversion(ctx context.Context, product string) error
 }
 
 func (rc *RecipeAPI) InsertRecipe(w http.ResponseWriter, r *http.Request) {
@@ -130,7 +133,13 @@ func (rc *RecipeAPI) GetMealPrice(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	calculatedMeal, err := rc.Service.GetMealPrice(r.Context(), ids)
+	strategy, err := getPriceStrategy(r)
+	if err != nil {
+		errorResponse(r.Context(), w, err)
+		return
+	}
+
+	calculatedMeal, err := rc.Service.GetMealPrice(r.Context(), ids, strategy)
 	if err != nil {
 		errorResponse(r.Context(), w, err)
 		return
@@ -164,7 +173,13 @@ func (rc *RecipeAPI) GetMealPriceByDate(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	calculatedMeal, err := rc.Service.GetMealPriceByDate(r.Context(), date)
+	strategy, err := getPriceStrategy(r)
+	if err != nil {
+		errorResponse(r.Context(), w, err)
+		return
+	}
+
+	calculatedMeal, err := rc.Service.GetMealPriceByDate(r.Context(), date, strategy)
 	if err != nil {
 		errorResponse(r.Context(), w, err)
 		return
@@ -173,6 +188,24 @@ func (rc *RecipeAPI) GetMealPriceByDate(w http.ResponseWriter, r *http.Request)
 	successResponse(r.Context(), w, calculatedMeal)
 }
 
+// getPriceStrategy reads the price strategy from strategy, days and retailer query parameters.
+func getPriceStrategy(r *http.Request) (model.PriceStrategy, error) {
+	query := r.URL.Query()
+	strategy := model.PriceStrategy{
+		Name:     query.Get("strategy"),
+		Retailer: query.Get("retailer"),
+	}
+
+	if days := query.Get("days"); days != "" {
+		var err error
+		strategy.Days, err = strconv.Atoi(days)
+		if err != nil {
+			return model.PriceStrategy{}, uerror.NewBadRequest("invalid days", err)
+		}
+	}
+	return strategy, nil
+}
+
 func (rc *RecipeAPI) DeleteRecipe(w http.ResponseWriter, r *http.Request) {
 	idParam := chi.URLParam(r, "recipeID")
 
@@ -214,3 +247,38 @@ func (rc *RecipeAPI) GetRecipeNames(w http.ResponseWriter, r *http.Request) {
 
 	successResponse(r.Context(), w, recipeNames)
 }
+
+func (rc *RecipeAPI) GetUnitConversions(w http.ResponseWriter, r *http.Request) {
+	conversions, err := rc.Service.GetUnitConversions(r.Context())
+	if err != nil {
+		errorResponse(r.Context(), w, err)
+		return
+	}
+
+	successResponse(r.Context(), w, map[string]any{"unitConversions": emptyIfNil(conversions)})
+}
+
+func (rc *RecipeAPI) UpsertUnitConversion(w http.ResponseWriter, r *http.Request) {
+	var conversion model.UnitConversion
+	if err := json.NewDecoder(r.Body).Decode(&conversion); err != nil {
+		errorResponse(r.Context(), w, uerror.NewBadRequest("invalid request body", err))
+		return
+	}
+	conversion.Product = chi.URLParam(r, "product")
+
+	if err := rc.Service.UpsertUnitConversion(r.Context(), conversion); err != nil {
+		errorResponse(r.Context(), w, err)
+		return
+	}
+
+	successResponse(r.Context(), w, newSuccessMessage("successfully saved unit conversion"))
+}
+
+func (rc *RecipeAPI) DeleteUnitConversion(w http.ResponseWriter, r *http.Request) {
+	if err := rc.Service.DeleteUnitConversion(r.Context(), chi.URLParam(r, "product")); err != nil {
+		errorResponse(r.Context(), w, err)
+		return
+	}
+
+	successResponse(r.Context(), w, newSuccessMessage("successfully deleted unit conversion"))
+}
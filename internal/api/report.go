@@ -0,0 +1,75 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/SarunasBucius/nutri-price-server/internal/model"
+	"github.com/SarunasBucius/nutri-price-server/internal/utils/uerror"
+)
+
+type ReportAPI struct {
+	Service IReportService
+}
+
+func NewReportAPI(reportService IReportService) *ReportAPI {
+	return &ReportAPI{Service: reportService}
+}
+
+type IReportService interface {
+	GetSpendingReport(ctx context.Context, request model.SpendingReportRequest) (model.SpendingReport, error)
+}
+
+func (rp *ReportAPI) GetSpendingReport(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	from, err := time.Parse(time.DateOnly, query.Get("from"))
+	if err != nil {
+		errorResponse(r.Context(), w, uerror.NewBadRequest("invalid from date", err))
+		return
+	}
+	to, err := time.Parse(time.DateOnly, query.Get("to"))
+	if err != nil {
+		errorResponse(r.Context(), w, uerror.NewBadRequest("invalid to date", err))
+		return
+	}
+
+	filter, err := getProductFilter(r)
+	if err != nil {
+		errorResponse(r.Context(), w, err)
+		return
+	}
+
+	request := model.SpendingReportRequest{
+		From:    from,
+		To:      to,
+		Period:  query.Get("period"),
+		GroupBy: query.Get("groupBy"),
+		Filter:  filter,
+	}
+	if movers := query.Get("movers"); movers != "" {
+		if request.Movers, err = strconv.Atoi(movers); err != nil {
+			errorResponse(r.Context(), w, uerror.NewBadRequest("invalid movers", err))
+			return
+		}
+	}
+
+	format := query.Get("format")
+	if format != "" && format != "json" && format != "csv" {
+		errorResponse(r.Context(), w, uerror.NewBadRequest("format must be json or csv", nil))
+		return
+	}
+
+	report, err := rp.Service.GetSpendingReport(r.Context(), request)
+	if err != nil {
+		errorResponse(r.Context(), w, err)
+		return
+	}
+
+	if format == "csv" {
+		csvResponse(r.Context(), w, "spending-report.csv", report.CSVRecords())
+		return
+	}
+	successResponse(r.Context(), w, report)
+}
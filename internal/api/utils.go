@@ -2,12 +2,14 @@ package api
 
 import (
 	"context"
+	"encoding/csv"
 	"encoding/json"
 	"log/slog"
 	"net/http"
 	"strconv"
 	"strings"
 
+	"github.com/SarunasBucius/nutri-price-server/internal/model"
 	"github.com/SarunasBucius/nutri-price-server/internal/utils/uerror"
 )
 
@@ -38,6 +40,15 @@ func successResponse(ctx context.Context, w http.ResponseWriter, response any) {
 	}
 }
 
+// csvResponse writes records as a CSV file attachment.
+func csvResponse(ctx context.Context, w http.ResponseWriter, filename string, records [][]string) {
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`"`)
+	if err := csv.NewWriter(w).WriteAll(records); err != nil {
+		slog.ErrorContext(ctx, "writing csv response", "error", err)
+	}
+}
+
 func newSuccessMessage(message string) successMessage {
 	return successMessage{Message: message}
 }
@@ -62,3 +73,25 @@ func numbersParamToInts(numbers string) ([]int, error) {
 	}
 	return convertedNumbers, nil
 }
+
+// getProductFilter reads the product filter from categoryId and tag query parameters.
+func getProductFilter(r *http.Request) (model.ProductFilter, error) {
+	filter := model.ProductFilter{
+		CategoryID: strings.TrimSpace(r.URL.Query().Get("categoryId")),
+		Tag:        strings.TrimSpace(r.URL.Query().Get("tag")),
+	}
+	if filter.CategoryID != "" {
+		if _, err := strconv.Atoi(filter.CategoryID); err != nil {
+			return model.ProductFilter{}, uerror.NewBadRequest("invalid categoryId", err)
+		}
+	}
+	return filter, nil
+}
+
+// validateReceiptID rejects receipt ids that are not numbers before they reach the database.
+func validateReceiptID(receiptID string) error {
+	if _, err := strconv.Atoi(receiptID); err != nil {
+		return uerror.NewBadRequest("invalid receipt id", err)
+	}
+	return nil
+}
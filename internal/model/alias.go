@@ -0,0 +1,25 @@
+package model
+
+// ProductAlias maps a product name parsed from receipts to a user defined product and variety.
+// Name is empty for parsed names that were never confirmed. Purchases is the number of confirmed
+// receipt products that were submitted with the parsed name.
+type ProductAlias struct {
+	ID          string `json:"id"`
+	ParsedName  string `json:"parsedName"`
+	Name        string `json:"name"`
+	VarietyName string `json:"varietyName"`
+	Purchases   int    `json:"purchases"`
+}
+
+type ReassignProductAliasesRequest struct {
+	AliasIDs    []string `json:"aliasIds"`
+	Name        string   `json:"name"`
+	VarietyName string   `json:"varietyName"`
+}
+
+// ProductAliasesUpdate lists updated aliases and unconfirmed receipts containing their parsed names.
+// Aliases are applied to unconfirmed receipts when they are read, so these receipts already show the new names.
+type ProductAliasesUpdate struct {
+	Aliases               []ProductAlias `json:"aliases"`
+	UnconfirmedReceiptIDs []string       `json:"unconfirmedReceiptIds"`
+}
@@ -0,0 +1,44 @@
+package model
+
+// BasketComparisonRequest is a shopping list to price at retailers. MaxStores limits stores of the cheapest split,
+// any number of stores can be used when it is 0. Days is the period of recent purchases prices are averaged over,
+// 90 days are used when it is 0.
+type BasketComparisonRequest struct {
+	Products  []BasketProduct `json:"products"`
+	MaxStores int             `json:"maxStores"`
+	Days      int             `json:"days"`
+}
+
+type BasketProduct struct {
+	Product  string   `json:"product"`
+	Quantity Quantity `json:"quantity"`
+}
+
+type BasketComparison struct {
+	Retailers     []RetailerBasket `json:"retailers"`
+	CheapestSplit BasketSplit      `json:"cheapestSplit"`
+}
+
+// RetailerBasket is the estimated cost of products at a retailer.
+// MissingProducts were never bought at the retailer, or not in a unit convertible to the requested one,
+// and are not included in the total.
+type RetailerBasket struct {
+	Retailer        string               `json:"retailer"`
+	Total           float64              `json:"total"`
+	Products        []BasketProductPrice `json:"products"`
+	MissingProducts []string             `json:"missingProducts"`
+}
+
+type BasketProductPrice struct {
+	Product string        `json:"product"`
+	Price   float64       `json:"price"`
+	Sources []PriceSource `json:"sources"`
+}
+
+// BasketSplit assigns each product to the store where it is cheapest among the selected stores.
+// MissingProducts are not sold at any of the stores.
+type BasketSplit struct {
+	Total           float64          `json:"total"`
+	Stores          []RetailerBasket `json:"stores"`
+	MissingProducts []string         `json:"missingProducts"`
+}
@@ -0,0 +1,41 @@
+package model
+
+import "time"
+
+// Budget is a planned grocery spending of a month. A budget is limited to a category, including its subcategories,
+// or to a retailer when either is set. AlertThreshold is the share of Amount, from 0 to 1, spending past which
+// produces an alert.
+type Budget struct {
+	ID             string  `json:"id"`
+	Month          string  `json:"month"`
+	CategoryID     string  `json:"categoryId"`
+	Retailer       string  `json:"retailer"`
+	Amount         float64 `json:"amount"`
+	AlertThreshold float64 `json:"alertThreshold"`
+}
+
+// BudgetStatus compares spending with the budget. Projected is the spending expected by the end of the month
+// at the daily run rate of the elapsed days of the month.
+type BudgetStatus struct {
+	Budget
+	CategoryName       string  `json:"categoryName"`
+	Spent              float64 `json:"spent"`
+	Remaining          float64 `json:"remaining"`
+	SpentShare         float64 `json:"spentShare"`
+	DailyRunRate       float64 `json:"dailyRunRate"`
+	Projected          float64 `json:"projected"`
+	ProjectedOverspend bool    `json:"projectedOverspend"`
+}
+
+// BudgetAlert is produced once per budget amount and threshold, when a confirmed receipt pushes spending past the
+// alert threshold.
+type BudgetAlert struct {
+	ID        string    `json:"id"`
+	BudgetID  string    `json:"budgetId"`
+	ReceiptID string    `json:"receiptId"`
+	Month     string    `json:"month"`
+	Amount    float64   `json:"amount"`
+	Threshold float64   `json:"threshold"`
+	Spent     float64   `json:"spent"`
+	CreatedAt time.Time `json:"createdAt"`
+}
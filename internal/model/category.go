@@ -0,0 +1,8 @@
+package model
+
+// ProductFilter matches products of a category, including its subcategories, and with a tag.
+// Empty fields do not filter.
+type ProductFilter struct {
+	CategoryID string `json:"categoryId"`
+	Tag        string `json:"tag"`
+}
@@ -0,0 +1,28 @@
+package model
+
+import "time"
+
+// DepositAmount is the deposit paid for a single container in the Lithuanian deposit system.
+const DepositAmount = 0.10
+
+// DepositRefundRetailer is the retailer raw receipts of deposit refund slips are stored with.
+const DepositRefundRetailer = "deposit_refund"
+
+const (
+	DepositKindPaid   = "paid"
+	DepositKindRefund = "refund"
+)
+
+// DepositRefund is a refund claimed with a deposit refund slip.
+type DepositRefund struct {
+	ReceiptID string
+	Retailer  string
+	Date      time.Time
+	Amount    float64
+}
+
+type DepositBalance struct {
+	Paid     float64 `json:"paid"`
+	Refunded float64 `json:"refunded"`
+	Balance  float64 `json:"balance"`
+}
@@ -0,0 +1,37 @@
+package model
+
+import "time"
+
+// Food databases food facts are imported from.
+const (
+	FoodSourceOpenFoodFacts   = "open_food_facts"
+	FoodSourceFoodDataCentral = "usda_fdc"
+)
+
+// Formats of food database exports.
+const (
+	FoodFactFormatJSONL = "jsonl"
+	FoodFactFormatCSV   = "csv"
+)
+
+// FoodFact is a product of an external food database. SourceID identifies the product in the source database.
+// The nutritional value is per 100 grams or milliliters, as given by Unit.
+type FoodFact struct {
+	ID               int              `json:"id"`
+	Source           string           `json:"source"`
+	SourceID         string           `json:"sourceId"`
+	Barcode          string           `json:"barcode"`
+	Name             string           `json:"name"`
+	Brands           string           `json:"brands"`
+	Unit             string           `json:"unit"`
+	NutritionalValue NutritionalValue `json:"nutritionalValue"`
+	ImportedAt       time.Time        `json:"importedAt"`
+}
+
+// FoodFactImportReport counts imported food facts and skipped records, which lack a name or a plausible nutritional
+// value or could not be parsed.
+type FoodFactImportReport struct {
+	Source   string `json:"source"`
+	Imported int    `json:"imported"`
+	Skipped  int    `json:"skipped"`
+}
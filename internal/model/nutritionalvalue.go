@@ -1,16 +1,27 @@
 package model
 
+// Nutrients are amounts of catalogue nutrients, such as vitamins and minerals, by nutrient code in the nutrient unit.
 type NutritionalValue struct {
-	EnergyValueKCAL    float64 `json:"energyValueKcal"`
-	Fat                float64 `json:"fat"`
-	SaturatedFat       float64 `json:"saturatedFat"`
-	Carbohydrate       float64 `json:"carbohydrate"`
-	CarbohydrateSugars float64 `json:"carbohydrateSugars"`
-	Fibre              float64 `json:"fibre"`
-	SolubleFibre       float64 `json:"solubleFibre"`
-	InsolubleFibre     float64 `json:"insolubleFibre"`
-	Protein            float64 `json:"protein"`
-	Salt               float64 `json:"salt"`
+	EnergyValueKCAL    float64            `json:"energyValueKcal"`
+	Fat                float64            `json:"fat"`
+	SaturatedFat       float64            `json:"saturatedFat"`
+	Carbohydrate       float64            `json:"carbohydrate"`
+	CarbohydrateSugars float64            `json:"carbohydrateSugars"`
+	Fibre              float64            `json:"fibre"`
+	SolubleFibre       float64            `json:"solubleFibre"`
+	InsolubleFibre     float64            `json:"insolubleFibre"`
+	Protein            float64            `json:"protein"`
+	Salt               float64            `json:"salt"`
+	Nutrients          map[string]float64 `json:"nutrients,omitempty"`
+}
+
+// Nutrient is a catalogue entry of a nutrient tracked in addition to the macronutrients.
+// DailyReference is the daily intake reference value in the nutrient unit, nil if unknown.
+type Nutrient struct {
+	Code           string   `json:"code"`
+	Name           string   `json:"name"`
+	Unit           string   `json:"unit"`
+	DailyReference *float64 `json:"dailyReference"`
 }
 
 type ProductNutritionalValueNew struct {
@@ -0,0 +1,47 @@
+package model
+
+import "time"
+
+// StoredReceipt is a raw receipt together with products parsed on submission and products confirmed by the user.
+type StoredReceipt struct {
+	ID                string
+	Date              time.Time
+	Retailer          string
+	Receipt           string
+	ParsedProducts    ReceiptProducts
+	SubmittedProducts ReceiptProducts
+	// IsSubmitted is false for receipts that were not confirmed yet and so have no submitted products.
+	IsSubmitted bool
+}
+
+// ParserAccuracy compares current parser output of confirmed receipts with the products submitted by the user.
+// Accuracies are shares of submitted products that were parsed with the same name, quantity or price.
+type ParserAccuracy struct {
+	Retailer          string  `json:"retailer"`
+	Receipts          int     `json:"receipts"`
+	ExactReceipts     int     `json:"exactReceipts"`
+	ParseErrors       int     `json:"parseErrors"`
+	SubmittedProducts int     `json:"submittedProducts"`
+	ParsedProducts    int     `json:"parsedProducts"`
+	SkippedLines      int     `json:"skippedLines"`
+	MatchedNames      int     `json:"matchedNames"`
+	MatchedQuantities int     `json:"matchedQuantities"`
+	MatchedPrices     int     `json:"matchedPrices"`
+	NameAccuracy      float64 `json:"nameAccuracy"`
+	QuantityAccuracy  float64 `json:"quantityAccuracy"`
+	PriceAccuracy     float64 `json:"priceAccuracy"`
+}
+
+// ChangedReceipt is a stored receipt whose products parsed by the current parser differ from the stored parsed products.
+type ChangedReceipt struct {
+	ReceiptID    string   `json:"receiptId"`
+	Retailer     string   `json:"retailer"`
+	Date         string   `json:"date"`
+	Changes      []string `json:"changes"`
+	SkippedLines []string `json:"skippedLines,omitempty"`
+}
+
+type ParserRegressionReport struct {
+	Accuracy        []ParserAccuracy `json:"accuracy"`
+	ChangedReceipts []ChangedReceipt `json:"changedReceipts"`
+}
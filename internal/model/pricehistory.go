@@ -0,0 +1,34 @@
+package model
+
+const (
+	PriceBucketWeek  = "week"
+	PriceBucketMonth = "month"
+)
+
+const (
+	PriceTrendRising  = "rising"
+	PriceTrendFalling = "falling"
+	PriceTrendStable  = "stable"
+)
+
+// PriceHistory is a time series of unit prices, a price for one kg, l or piece, bucketed by week or month.
+// Trend is the change of the mean unit price per bucket.
+type PriceHistory struct {
+	Unit           string       `json:"unit"`
+	Bucket         string       `json:"bucket"`
+	Points         []PricePoint `json:"points"`
+	Min            float64      `json:"min"`
+	Max            float64      `json:"max"`
+	Mean           float64      `json:"mean"`
+	Trend          float64      `json:"trend"`
+	TrendDirection string       `json:"trendDirection"`
+}
+
+// PricePoint summarises unit prices of purchases in the period starting at PeriodStart.
+type PricePoint struct {
+	PeriodStart string  `json:"periodStart"`
+	Purchases   int     `json:"purchases"`
+	Min         float64 `json:"min"`
+	Max         float64 `json:"max"`
+	Mean        float64 `json:"mean"`
+}
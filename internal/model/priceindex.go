@@ -0,0 +1,51 @@
+package model
+
+// PriceIndexRequest selects the basket of a household price index. Filtered products bought in at least MinMonths
+// months between From and To months are selected, at most Limit of them with the largest spending.
+// Explicitly listed Products are used instead when given.
+type PriceIndexRequest struct {
+	From      string        `json:"from"`
+	To        string        `json:"to"`
+	MinMonths int           `json:"minMonths"`
+	Limit     int           `json:"limit"`
+	Products  []string      `json:"products"`
+	Filter    ProductFilter `json:"filter"`
+}
+
+// PriceIndex is a chained monthly price index of a fixed basket with the first month equal to 100.
+// Inflation is the change of the index over the whole period, in percent.
+type PriceIndex struct {
+	From       string               `json:"from"`
+	To         string               `json:"to"`
+	Months     []PriceIndexMonth    `json:"months"`
+	Inflation  float64              `json:"inflation"`
+	Categories []CategoryPriceIndex `json:"categories"`
+	Basket     []PriceIndexProduct  `json:"basket"`
+}
+
+// PriceIndexMonth is the index of a month. MonthlyChange and AnnualChange are changes in percent
+// from the previous month and from the same month a year ago, nil when that month is not in the period.
+type PriceIndexMonth struct {
+	Month         string   `json:"month"`
+	Index         float64  `json:"index"`
+	MonthlyChange *float64 `json:"monthlyChange"`
+	AnnualChange  *float64 `json:"annualChange"`
+}
+
+type CategoryPriceIndex struct {
+	Category  string            `json:"category"`
+	Months    []PriceIndexMonth `json:"months"`
+	Inflation float64           `json:"inflation"`
+}
+
+// PriceIndexProduct is a basket product with its share of base period spending and unit prices of the first
+// and last months it was bought in. Unit prices are prices of one Unit, kg, l or piece.
+type PriceIndexProduct struct {
+	Product        string  `json:"product"`
+	Category       string  `json:"category"`
+	Unit           string  `json:"unit"`
+	Weight         float64 `json:"weight"`
+	Months         int     `json:"months"`
+	FirstUnitPrice float64 `json:"firstUnitPrice"`
+	LastUnitPrice  float64 `json:"lastUnitPrice"`
+}
@@ -2,16 +2,60 @@ package model
 
 import "time"
 
+// PurchasedProductNew is a single purchase line. Price is the paid price, FullPrice is the shelf price before Discount.
+// Deposit paid for the product container is not included in the prices.
+// ArticleCode is the EAN or retailer article code printed on the receipt, empty when the receipt has none.
 type PurchasedProductNew struct {
-	ProductID   string   `json:"productId"`
-	Name        string   `json:"name"`
-	VarietyName string   `json:"varietyName"`
-	Price       float64  `json:"price"`
-	Quantity    Quantity `json:"quantity"`
-	Notes       string   `json:"notes"`
-	ParsedName  string   `json:"parsedName"`
+	ProductID    string   `json:"productId"`
+	Name         string   `json:"name"`
+	VarietyName  string   `json:"varietyName"`
+	Price        float64  `json:"price"`
+	FullPrice    float64  `json:"fullPrice"`
+	Discount     float64  `json:"discount"`
+	DiscountType string   `json:"discountType"`
+	Deposit      float64  `json:"deposit"`
+	Quantity     Quantity `json:"quantity"`
+	Notes        string   `json:"notes"`
+	ParsedName   string   `json:"parsedName"`
+	ArticleCode  string   `json:"articleCode"`
 }
 
+// IsEAN reports whether the code is an EAN-8 or EAN-13 barcode with a valid check digit.
+func IsEAN(code string) bool {
+	if len(code) != 8 && len(code) != 13 {
+		return false
+	}
+
+	var sum int
+	for i := range code {
+		if code[i] < '0' || code[i] > '9' {
+			return false
+		}
+		// Digits are weighted 1 and 3 alternately, counting from the check digit, which is weighted 1.
+		digit := int(code[i] - '0')
+		if (len(code)-1-i)%2 == 1 {
+			digit *= 3
+		}
+		sum += digit
+	}
+	return sum%10 == 0
+}
+
+// GetCodeRetailer returns the retailer that the article code belongs to. EAN barcodes identify
+// the same product at every retailer, so they belong to none and an empty string is returned.
+func GetCodeRetailer(code, retailer string) string {
+	if IsEAN(code) {
+		return ""
+	}
+	return retailer
+}
+
+const (
+	DiscountTypeLoyalty  = "loyalty"
+	DiscountTypeMultiBuy = "multi_buy"
+	DiscountTypeMarkdown = "markdown"
+)
+
 type ProductAndVarietyName struct {
 	Name        string `json:"name"`
 	VarietyName string `json:"varietyName"`
@@ -35,12 +79,48 @@ type PurchasedProductsNew struct {
 }
 
 type UnconfirmedReceiptSummary struct {
-	Retailer string `json:"retailer"`
-	Date     string `json:"date"`
+	ID            string `json:"id"`
+	Retailer      string `json:"retailer"`
+	Date          string `json:"date"`
+	Time          string `json:"time"`
+	ReceiptNumber string `json:"receiptNumber"`
 }
 
 type ConfirmPurchasedProductsRequest struct {
-	Date     string                `json:"date"`
-	Retailer string                `json:"retailer"`
-	Products []PurchasedProductNew `json:"products"`
+	ReceiptID string                `json:"receiptId"`
+	Date      string                `json:"date"`
+	Retailer  string                `json:"retailer"`
+	Products  []PurchasedProductNew `json:"products"`
+}
+
+// NameMatchCandidate is an existing product variety that unknown parsed names can be matched to.
+// ParsedNames are receipt names that were confirmed as this variety before.
+type NameMatchCandidate struct {
+	Name        string
+	VarietyName string
+	ParsedNames []string
+	Purchases   int
+}
+
+type NameSuggestion struct {
+	Name        string  `json:"name"`
+	VarietyName string  `json:"varietyName"`
+	Score       float64 `json:"score"`
+}
+
+// UnconfirmedProduct is a product of an unconfirmed receipt. Suggestions are set for products without an alias.
+type UnconfirmedProduct struct {
+	PurchasedProductNew
+	Suggestions []NameSuggestion `json:"suggestions"`
+}
+
+// BarcodeProduct is the product and variety last confirmed with the article code.
+// NutritionalValue is nil when the variety has no nutritional value.
+type BarcodeProduct struct {
+	Code             string            `json:"code"`
+	ProductID        string            `json:"productId"`
+	Name             string            `json:"name"`
+	VarietyName      string            `json:"varietyName"`
+	Unit             string            `json:"unit"`
+	NutritionalValue *NutritionalValue `json:"nutritionalValue"`
 }
@@ -1,5 +1,7 @@
 package model
 
+import "time"
+
 type ReceiptProducts []PurchasedProductNew
 
 func (p *ReceiptProducts) GetVarietyNames() []string {
@@ -16,7 +18,7 @@ func (p *ReceiptProducts) GetVarietyNames() []string {
 }
 
 func (p *ReceiptProducts) UpdateProductNames(aliasByParsedName map[string]ProductAndVarietyName) {
-	if p == nil || len(aliasByParsedName) == 0 {
+	if p == nil {
 		return
 	}
 
@@ -30,13 +32,119 @@ func (p *ReceiptProducts) UpdateProductNames(aliasByParsedName map[string]Produc
 	}
 }
 
+func (p ReceiptProducts) GetArticleCodes() []string {
+	var codes []string
+	for _, product := range p {
+		if product.ArticleCode != "" {
+			codes = append(codes, product.ArticleCode)
+		}
+	}
+	return codes
+}
+
+// UpdateProductNamesByArticleCode sets names of products whose article code was confirmed before.
+// Article codes identify the product exactly, so they take precedence over parsed name aliases.
+func (p ReceiptProducts) UpdateProductNamesByArticleCode(productByArticleCode map[string]ProductAndVarietyName) {
+	for i := range p {
+		if product, ok := productByArticleCode[p[i].ArticleCode]; ok && p[i].ArticleCode != "" {
+			p[i].Name = product.Name
+			p[i].VarietyName = product.VarietyName
+		}
+	}
+}
+
+func (p ReceiptProducts) GetPriceSum() float64 {
+	var sum float64
+	for _, product := range p {
+		sum += product.Price + product.Deposit
+	}
+	return sum
+}
+
 type ParseReceiptFromTextResponse struct {
-	Date     string          `json:"date"`
-	Retailer string          `json:"retailer"`
-	Products ReceiptProducts `json:"products"`
+	ReceiptID     string             `json:"receiptId"`
+	AlreadyStored bool               `json:"alreadyStored"`
+	Date          string             `json:"date"`
+	Time          string             `json:"time"`
+	ReceiptNumber string             `json:"receiptNumber"`
+	Retailer      string             `json:"retailer"`
+	Products      ReceiptProducts    `json:"products"`
+	DepositRefund float64            `json:"depositRefund"`
+	Totals        ReceiptTotals      `json:"totals"`
+	Diagnostics   ReceiptDiagnostics `json:"diagnostics"`
+}
+
+// RawReceipt is the receipt text as submitted together with its parsed header information.
+// Stored receipts are keyed by a hash of the receipt text, so several receipts per retailer per day are kept apart.
+type RawReceipt struct {
+	ID             string
+	Date           time.Time
+	Time           string
+	ReceiptNumber  string
+	Retailer       string
+	Receipt        string
+	ParsedProducts ReceiptProducts
+	// MessageID is set for receipts received by email.
+	MessageID string
+}
+
+// ReceiptTotals holds sums printed on the receipt. Zero values mean the sum was not found.
+type ReceiptTotals struct {
+	Total float64     `json:"total"`
+	Paid  float64     `json:"paid"`
+	VAT   []VATAmount `json:"vat"`
+}
+
+type VATAmount struct {
+	Rate   float64 `json:"rate"`
+	Net    float64 `json:"net"`
+	Amount float64 `json:"amount"`
+	Gross  float64 `json:"gross"`
+}
+
+type ReceiptDiagnostics struct {
+	LinesSum     float64          `json:"linesSum"`
+	Total        float64          `json:"total"`
+	Difference   float64          `json:"difference"`
+	SkippedLines []string         `json:"skippedLines"`
+	Warnings     []ReceiptWarning `json:"warnings"`
+}
+
+type ReceiptWarning struct {
+	Product string `json:"product,omitempty"`
+	Message string `json:"message"`
 }
 
 type LastReceiptDate struct {
 	Date     string `json:"date"`
 	Retailer string `json:"retailer"`
 }
+
+const (
+	ImportStatusParsed          = "parsed"
+	ImportStatusDuplicate       = "duplicate"
+	ImportStatusUnknownRetailer = "unknown_retailer"
+	ImportStatusParseError      = "parse_error"
+)
+
+// ReceiptFile is a receipt text, PDF or email file taken from a bulk import archive or directory.
+type ReceiptFile struct {
+	Name    string
+	Content []byte
+}
+
+type ReceiptImportResult struct {
+	File      string `json:"file"`
+	Status    string `json:"status"`
+	ReceiptID string `json:"receiptId,omitempty"`
+	Retailer  string `json:"retailer,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+type ReceiptImportReport struct {
+	Parsed          int                   `json:"parsed"`
+	Duplicate       int                   `json:"duplicate"`
+	UnknownRetailer int                   `json:"unknownRetailer"`
+	ParseError      int                   `json:"parseError"`
+	Results         []ReceiptImportResult `json:"results"`
+}
@@ -89,9 +89,12 @@ type RecipeSummaries struct {
 	Original []RecipeSummary `json:"originalRecipeSummaries"`
 }
 
+// CalculatedMealNutritionalValue is the nutritional value of a meal.
+// DailyReferenceShares are meal nutrient amounts divided by their daily reference values, by nutrient code.
 type CalculatedMealNutritionalValue struct {
-	NutritionalValue  NutritionalValue                   `json:"nutritionalValue"`
-	CalculatedRecipes []CalculatedRecipeNutritionalValue `json:"calculatedRecipes"`
+	NutritionalValue     NutritionalValue                   `json:"nutritionalValue"`
+	DailyReferenceShares map[string]float64                 `json:"dailyReferenceShares"`
+	CalculatedRecipes    []CalculatedRecipeNutritionalValue `json:"calculatedRecipes"`
 }
 
 type CalculatedRecipeNutritionalValue struct {
@@ -101,14 +104,18 @@ type CalculatedRecipeNutritionalValue struct {
 	CalculatedProducts []CalculatedProductNutritionalValue `json:"calculatedProducts"`
 }
 
+// CalculatedProductNutritionalValue is the nutritional value of an ingredient.
+// Conversion describes how the ingredient amount was converted to the nutritional value unit, if it was.
 type CalculatedProductNutritionalValue struct {
 	Product          string           `json:"product"`
 	Message          string           `json:"message"`
 	NutritionalValue NutritionalValue `json:"nutritionalValue"`
+	Conversion       string           `json:"conversion"`
 }
 
 type CalculatedMealPrice struct {
 	Price             float64                 `json:"price"`
+	Strategy          string                  `json:"strategy"`
 	CalculatedRecipes []CalculatedRecipePrice `json:"calculatedRecipes"`
 }
 
@@ -119,10 +126,41 @@ type CalculatedRecipePrice struct {
 	CalculatedProducts []CalculatedProductPrice `json:"calculatedProducts"`
 }
 
+// CalculatedProductPrice is the price of an ingredient. Sources are the purchases the price was calculated from.
+// Conversion describes how the ingredient amount was converted to the purchase unit, if it was.
 type CalculatedProductPrice struct {
-	Product string  `json:"product"`
-	Message string  `json:"message"`
-	Price   float64 `json:"price"`
+	Product    string        `json:"product"`
+	Message    string        `json:"message"`
+	Price      float64       `json:"price"`
+	Conversion string        `json:"conversion"`
+	Sources    []PriceSource `json:"sources"`
+}
+
+type PriceSource struct {
+	PurchaseID  int      `json:"purchaseId"`
+	VarietyName string   `json:"varietyName"`
+	Retailer    string   `json:"retailer"`
+	Date        string   `json:"date"`
+	Price       float64  `json:"price"`
+	Quantity    Quantity `json:"quantity"`
+}
+
+const (
+	PriceStrategyLast               = "last"
+	PriceStrategyWeightedAverage    = "weighted_average"
+	PriceStrategyMedian             = "median"
+	PriceStrategyCheapest           = "cheapest"
+	PriceStrategyCheapestAtRetailer = "cheapest_at_retailer"
+	PriceStrategyDishDate           = "dish_date"
+)
+
+// PriceStrategy selects purchases used to price ingredients. Days limits weighted average, median
+// and cheapest strategies to purchases of the last Days days, all purchases are used when it is 0.
+// Retailer is required by the cheapest at retailer strategy.
+type PriceStrategy struct {
+	Name     string `json:"strategy"`
+	Days     int    `json:"days"`
+	Retailer string `json:"retailer"`
 }
 
 type CloneRecipesRequest struct {
@@ -0,0 +1,140 @@
+package model
+
+import (
+	"strconv"
+	"time"
+)
+
+const (
+	ReportPeriodWeek  = "week"
+	ReportPeriodMonth = "month"
+)
+
+const (
+	ReportGroupRetailer = "retailer"
+	ReportGroupProduct  = "product"
+	ReportGroupVariety  = "variety"
+	ReportGroupCategory = "category"
+)
+
+// UncategorisedGroup groups spending on products without a category.
+const UncategorisedGroup = "uncategorised"
+
+// SpendingReportRequest selects purchases of filtered products between From and To dates, inclusive,
+// bucketed by Period and grouped by GroupBy. Movers lists at most this many groups with the largest change.
+type SpendingReportRequest struct {
+	From    time.Time
+	To      time.Time
+	Period  string
+	GroupBy string
+	Movers  int
+	Filter  ProductFilter
+}
+
+// SpendingRow is a purchase with its top level product category, empty for uncategorised products.
+// ReceiptID is the raw receipt the purchase was confirmed from, empty for purchases entered without one.
+type SpendingRow struct {
+	ReceiptID   string
+	Date        time.Time
+	Retailer    string
+	Product     string
+	VarietyName string
+	Category    string
+	Price       float64
+}
+
+// SpendingReport aggregates spending. A receipt is a confirmed raw receipt, purchases entered without one
+// count as a receipt per retailer and day.
+// TopMovers compare spending of groups with the previous period of the same length.
+type SpendingReport struct {
+	From              string           `json:"from"`
+	To                string           `json:"to"`
+	Period            string           `json:"period"`
+	GroupBy           string           `json:"groupBy"`
+	Total             float64          `json:"total"`
+	Receipts          int              `json:"receipts"`
+	AveragePerReceipt float64          `json:"averagePerReceipt"`
+	Periods           []SpendingPeriod `json:"periods"`
+	TopMovers         []SpendingMover  `json:"topMovers"`
+}
+
+type SpendingPeriod struct {
+	PeriodStart       string          `json:"periodStart"`
+	Total             float64         `json:"total"`
+	Receipts          int             `json:"receipts"`
+	AveragePerReceipt float64         `json:"averagePerReceipt"`
+	Groups            []SpendingGroup `json:"groups"`
+}
+
+// SpendingGroup is spending of a group in a period. Share is the part of the period total, from 0 to 1.
+type SpendingGroup struct {
+	Group             string  `json:"group"`
+	Total             float64 `json:"total"`
+	Purchases         int     `json:"purchases"`
+	Receipts          int     `json:"receipts"`
+	AveragePerReceipt float64 `json:"averagePerReceipt"`
+	Share             float64 `json:"share"`
+}
+
+// SpendingMover is a change of group spending. ChangePercent is nil when there was no previous spending.
+type SpendingMover struct {
+	Group         string   `json:"group"`
+	PreviousTotal float64  `json:"previousTotal"`
+	CurrentTotal  float64  `json:"currentTotal"`
+	Change        float64  `json:"change"`
+	ChangePercent *float64 `json:"changePercent"`
+}
+
+// CSV period values of rows that are not bound to a single period.
+const (
+	CSVPeriodTotal     = "total"
+	CSVPeriodTopMovers = "top_movers"
+)
+
+// CSVRecords returns the report as a table with a row per period and group, preceded by a header.
+// Rows with an empty group are totals of the period. They are followed by the report total, with the period
+// CSVPeriodTotal, and by the top movers, with the period CSVPeriodTopMovers and the current total in the total column.
+func (r SpendingReport) CSVRecords() [][]string {
+	records := [][]string{{
+		"period", "group", "total", "purchases", "receipts", "average_per_receipt", "share",
+		"previous_total", "change", "change_percent",
+	}}
+	var reportPurchases int
+	for _, period := range r.Periods {
+		var purchases int
+		for _, group := range period.Groups {
+			purchases += group.Purchases
+		}
+		reportPurchases += purchases
+		records = append(records, []string{
+			period.PeriodStart, "", formatCSVFloat(period.Total), strconv.Itoa(purchases), strconv.Itoa(period.Receipts),
+			formatCSVFloat(period.AveragePerReceipt), "1", "", "", "",
+		})
+		for _, group := range period.Groups {
+			records = append(records, []string{
+				period.PeriodStart, group.Group, formatCSVFloat(group.Total), strconv.Itoa(group.Purchases),
+				strconv.Itoa(group.Receipts), formatCSVFloat(group.AveragePerReceipt), formatCSVFloat(group.Share), "", "", "",
+			})
+		}
+	}
+
+	records = append(records, []string{
+		CSVPeriodTotal, "", formatCSVFloat(r.Total), strconv.Itoa(reportPurchases), strconv.Itoa(r.Receipts),
+		formatCSVFloat(r.AveragePerReceipt), "1", "", "", "",
+	})
+	for _, mover := range r.TopMovers {
+		var changePercent string
+		if mover.ChangePercent != nil {
+			changePercent = formatCSVFloat(*mover.ChangePercent)
+		}
+		records = append(records, []string{
+			CSVPeriodTopMovers, mover.Group, formatCSVFloat(mover.CurrentTotal), "", "", "", "",
+			formatCSVFloat(mover.PreviousTotal), formatCSVFloat(mover.Change), changePercent,
+		})
+	}
+	return records
+}
+
+func formatCSVFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
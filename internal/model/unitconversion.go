@@ -0,0 +1,10 @@
+package model
+
+// UnitConversion holds product specific factors for converting between grams, milliliters and pieces.
+// Density is in grams per milliliter and PieceWeight is the average weight of a piece in grams.
+// Zero means the factor is unknown.
+type UnitConversion struct {
+	Product     string  `json:"product"`
+	Density     float64 `json:"density"`
+	PieceWeight float64 `json:"pieceWeight"`
+}
@@ -0,0 +1,164 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/SarunasBucius/nutri-price-server/internal/model"
+	"github.com/SarunasBucius/nutri-price-server/internal/utils/uerror"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type AliasRepo struct {
+	DB *pgxpool.Pool
+}
+
+func NewAliasRepo(db *pgxpool.Pool) *AliasRepo {
+	return &AliasRepo{DB: db}
+}
+
+// productAliasesQuery selects aliases with the number of submitted receipt products per parsed name.
+// Receipts stored without products have JSON null instead of an array, which is skipped.
+const productAliasesQuery = `
+	WITH submitted AS (
+		SELECT product->>'parsedName' AS parsed_name, COUNT(*) AS purchases
+		FROM raw_receipts,
+			json_array_elements(CASE WHEN json_typeof(submitted_products) = 'array' THEN submitted_products ELSE '[]'::json END) product
+		GROUP BY product->>'parsedName'
+	)
+	SELECT aliases.id, aliases.parsed_product_name, COALESCE(aliases.user_defined_product_name, ''),
+		COALESCE(aliases.user_defined_variety_name, ''), COALESCE(submitted.purchases, 0)
+	FROM purchased_products_aliases aliases
+	LEFT JOIN submitted ON submitted.parsed_name = aliases.parsed_product_name`
+
+// GetProductAliases returns aliases whose parsed, product or variety name contains search, all when search is empty.
+func (r *AliasRepo) GetProductAliases(ctx context.Context, search string) ([]model.ProductAlias, error) {
+	query := productAliasesQuery + `
+	WHERE $1 = '' OR aliases.parsed_product_name ILIKE '%' || $1 || '%'
+		OR aliases.user_defined_product_name ILIKE '%' || $1 || '%'
+		OR aliases.user_defined_variety_name ILIKE '%' || $1 || '%'
+	ORDER BY aliases.parsed_product_name`
+
+	return r.queryProductAliases(ctx, query, search)
+}
+
+func (r *AliasRepo) GetProductAliasesByIDs(ctx context.Context, aliasIDs []string) ([]model.ProductAlias, error) {
+	query := productAliasesQuery + `
+	WHERE aliases.id::text = ANY($1)
+	ORDER BY aliases.parsed_product_name`
+
+	return r.queryProductAliases(ctx, query, aliasIDs)
+}
+
+func (r *AliasRepo) queryProductAliases(ctx context.Context, query string, args ...any) ([]model.ProductAlias, error) {
+	rows, err := r.DB.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var aliases []model.ProductAlias
+	for rows.Next() {
+		var alias model.ProductAlias
+		if err := rows.Scan(&alias.ID, &alias.ParsedName, &alias.Name, &alias.VarietyName, &alias.Purchases); err != nil {
+			return nil, err
+		}
+		aliases = append(aliases, alias)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return aliases, nil
+}
+
+// UpdateProductAliases sets the product and variety name of the aliases and returns their parsed names.
+// Nothing is updated if any of the aliases does not exist.
+func (r *AliasRepo) UpdateProductAliases(ctx context.Context, aliasIDs []string, alias model.ProductAndVarietyName) ([]string, error) {
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+	UPDATE purchased_products_aliases
+	SET user_defined_product_name = $1, user_defined_variety_name = $2
+	WHERE id::text = ANY($3)
+	RETURNING parsed_product_name`
+
+	rows, err := tx.Query(ctx, query, alias.Name, alias.VarietyName, aliasIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var parsedNames []string
+	for rows.Next() {
+		var parsedName string
+		if err := rows.Scan(&parsedName); err != nil {
+			return nil, err
+		}
+		parsedNames = append(parsedNames, parsedName)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(parsedNames) != len(aliasIDs) {
+		return nil, uerror.NewNotFound(fmt.Sprintf("%d of %d aliases do not exist", len(aliasIDs)-len(parsedNames), len(aliasIDs)), nil)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return parsedNames, nil
+}
+
+func (r *AliasRepo) DeleteProductAlias(ctx context.Context, aliasID string) error {
+	query := `
+	DELETE FROM purchased_products_aliases
+	WHERE id::text = $1`
+
+	result, err := r.DB.Exec(ctx, query, aliasID)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return uerror.NewNotFound(fmt.Sprintf("alias with id %q does not exist", aliasID), nil)
+	}
+
+	return nil
+}
+
+// GetUnconfirmedReceiptIDsByParsedNames returns unconfirmed receipts with any of the parsed product names.
+func (r *AliasRepo) GetUnconfirmedReceiptIDsByParsedNames(ctx context.Context, parsedNames []string) ([]string, error) {
+	query := `
+	SELECT DISTINCT raw_receipts.id
+	FROM raw_receipts,
+		json_array_elements(CASE WHEN json_typeof(parsed_products) = 'array' THEN parsed_products ELSE '[]'::json END) product
+	WHERE NOT raw_receipts.is_confirmed AND product->>'varietyName' = ANY($1)
+	ORDER BY raw_receipts.id`
+
+	rows, err := r.DB.Query(ctx, query, parsedNames)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var receiptIDs []string
+	for rows.Next() {
+		var receiptID string
+		if err := rows.Scan(&receiptID); err != nil {
+			return nil, err
+		}
+		receiptIDs = append(receiptIDs, receiptID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return receiptIDs, nil
+}
@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/SarunasBucius/nutri-price-server/internal/model"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+)
+
+func (s *ContainerTestSuite) TestAliasRepo_GetProductAliases() {
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		search string
+		want   []model.ProductAlias
+	}{
+		{
+			name: "all_aliases",
+			want: []model.ProductAlias{
+				{ParsedName: "Obuol. Crimson Snow", Name: "apples", VarietyName: "Crimson Snow", Purchases: 2},
+				{ParsedName: "Raudoni lęšiai"},
+			},
+		},
+		{
+			name:   "search_by_product_name",
+			search: "APPLE",
+			want: []model.ProductAlias{
+				{ParsedName: "Obuol. Crimson Snow", Name: "apples", VarietyName: "Crimson Snow", Purchases: 2},
+			},
+		},
+	}
+	for _, tt := range tests {
+		s.T().Run(tt.name, func(t *testing.T) {
+			err := s.Container.Restore(ctx, postgres.WithSnapshotName("emptyTables"))
+			s.Require().NoError(err)
+
+			db, err := pgxpool.New(ctx, s.Container.MustConnectionString(ctx))
+			require.NoError(t, err)
+			defer db.Close()
+
+			receiptRepo := NewReceiptRepo(db)
+			for i, receipt := range []string{"first receipt", "second receipt"} {
+				receiptID, _, err := receiptRepo.InsertRawReceipt(ctx, model.RawReceipt{
+					Date:     time.Date(2024, 01, i+1, 0, 0, 0, 0, time.UTC),
+					Retailer: "norfa",
+					Receipt:  receipt,
+				})
+				require.NoError(t, err)
+				require.NoError(t, receiptRepo.SetRawReceiptSubmittedProducts(ctx, receiptID, []model.PurchasedProductNew{
+					{Name: "apples", VarietyName: "Crimson Snow", ParsedName: "Obuol. Crimson Snow"},
+				}))
+			}
+			require.NoError(t, receiptRepo.UpdateProductNameAlias(ctx, map[string]model.ProductAndVarietyName{
+				"Obuol. Crimson Snow": {Name: "apples", VarietyName: "Crimson Snow"},
+			}))
+			_, err = db.Exec(ctx, "INSERT INTO purchased_products_aliases(parsed_product_name) VALUES('Raudoni lęšiai')")
+			require.NoError(t, err)
+
+			r := NewAliasRepo(db)
+			got, err := r.GetProductAliases(ctx, tt.search)
+			s.Require().NoError(err)
+			for i := range got {
+				got[i].ID = ""
+			}
+			s.Require().Equal(tt.want, got)
+		})
+	}
+}
+
+func (s *ContainerTestSuite) TestAliasRepo_UpdateProductAliases() {
+	ctx := context.Background()
+
+	err := s.Container.Restore(ctx, postgres.WithSnapshotName("emptyTables"))
+	s.Require().NoError(err)
+
+	db, err := pgxpool.New(ctx, s.Container.MustConnectionString(ctx))
+	s.Require().NoError(err)
+	defer db.Close()
+
+	var aliasID string
+	err = db.QueryRow(ctx, "INSERT INTO purchased_products_aliases(parsed_product_name) VALUES('Obuol. Crimson Snow') RETURNING id").Scan(&aliasID)
+	s.Require().NoError(err)
+
+	r := NewAliasRepo(db)
+	newAlias := model.ProductAndVarietyName{Name: "apples", VarietyName: "Crimson Snow"}
+
+	_, err = r.UpdateProductAliases(ctx, []string{aliasID, "0"}, newAlias)
+	s.Require().Error(err)
+
+	parsedNames, err := r.UpdateProductAliases(ctx, []string{aliasID}, newAlias)
+	s.Require().NoError(err)
+	s.Require().Equal([]string{"Obuol. Crimson Snow"}, parsedNames)
+
+	aliases, err := r.GetProductAliasesByIDs(ctx, []string{aliasID})
+	s.Require().NoError(err)
+	s.Require().Equal([]model.ProductAlias{
+		{ID: aliasID, ParsedName: "Obuol. Crimson Snow", Name: "apples", VarietyName: "Crimson Snow"},
+	}, aliases)
+}
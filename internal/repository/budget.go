@@ -0,0 +1,157 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/SarunasBucius/nutri-price-server/internal/model"
+	"github.com/SarunasBucius/nutri-price-server/internal/utils/uerror"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type BudgetRepo struct {
+	DB *pgxpool.Pool
+}
+
+func NewBudgetRepo(db *pgxpool.Pool) *BudgetRepo {
+	return &BudgetRepo{DB: db}
+}
+
+// UpsertBudget inserts the budget or updates the amount and threshold of the budget with the same scope.
+// Changing the amount or threshold deletes the alert of the budget, so it can alert again against the new limit.
+func (b *BudgetRepo) UpsertBudget(ctx context.Context, month time.Time, budget model.Budget) (string, error) {
+	query := `
+	WITH previous AS (
+		SELECT id, amount, alert_threshold
+		FROM budgets
+		WHERE month = $1 AND category_id IS NOT DISTINCT FROM NULLIF($2, '')::INT AND retailer = $3
+	), upserted AS (
+		INSERT INTO budgets (month, category_id, retailer, amount, alert_threshold)
+		VALUES ($1, NULLIF($2, '')::INT, $3, $4, $5)
+		ON CONFLICT ON CONSTRAINT budgets_scope_key DO UPDATE
+		SET amount = EXCLUDED.amount, alert_threshold = EXCLUDED.alert_threshold
+		RETURNING id, amount, alert_threshold
+	), rearmed AS (
+		DELETE FROM budget_alerts
+		USING upserted, previous
+		WHERE budget_alerts.budget_id = upserted.id AND previous.id = upserted.id
+			AND (previous.amount <> upserted.amount OR previous.alert_threshold <> upserted.alert_threshold)
+	)
+	SELECT id FROM upserted`
+
+	var id string
+	if err := b.DB.QueryRow(ctx, query, month, budget.CategoryID, budget.Retailer, budget.Amount, budget.AlertThreshold).Scan(&id); err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+func (b *BudgetRepo) DeleteBudget(ctx context.Context, budgetID string) error {
+	tag, err := b.DB.Exec(ctx, "DELETE FROM budgets WHERE id::text = $1", budgetID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return uerror.NewNotFound(fmt.Sprintf("budget %s not found", budgetID), nil)
+	}
+	return nil
+}
+
+// GetBudgetStatuses returns budgets of the month with their spending. Only spending is calculated.
+func (b *BudgetRepo) GetBudgetStatuses(ctx context.Context, month time.Time) ([]model.BudgetStatus, error) {
+	query := `
+	SELECT budgets.id, budgets.month, COALESCE(budgets.category_id::TEXT, ''), COALESCE(categories.name, ''),
+		budgets.retailer, budgets.amount, budgets.alert_threshold,
+		COALESCE((
+			SELECT SUM(purchases.price)
+			FROM purchases
+			JOIN products ON products.id = purchases.product_id
+			WHERE purchases.purchase_date >= budgets.month AND purchases.purchase_date < budgets.month + INTERVAL '1 month'
+				AND (budgets.retailer = '' OR LOWER(purchases.retailer) = LOWER(budgets.retailer))
+				AND (budgets.category_id IS NULL OR products.category_id IN (SELECT id FROM category_subtree(budgets.category_id)))
+		), 0)
+	FROM budgets
+	LEFT JOIN categories ON categories.id = budgets.category_id
+	WHERE budgets.month = $1
+	ORDER BY budgets.category_id NULLS FIRST, budgets.retailer`
+
+	rows, err := b.DB.Query(ctx, query, month)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var statuses []model.BudgetStatus
+	for rows.Next() {
+		var status model.BudgetStatus
+		var budgetMonth time.Time
+		if err := rows.Scan(&status.ID, &budgetMonth, &status.CategoryID, &status.CategoryName,
+			&status.Retailer, &status.Amount, &status.AlertThreshold, &status.Spent); err != nil {
+			return nil, err
+		}
+		status.Month = budgetMonth.Format("2006-01")
+		statuses = append(statuses, status)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return statuses, nil
+}
+
+// InsertBudgetAlerts inserts alerts of budgets that have none yet and returns the inserted ones.
+func (b *BudgetRepo) InsertBudgetAlerts(ctx context.Context, alerts []model.BudgetAlert) ([]model.BudgetAlert, error) {
+	query := `
+	INSERT INTO budget_alerts (budget_id, receipt_id, amount, threshold, spent)
+	VALUES ($1, $2, $3, $4, $5)
+	ON CONFLICT (budget_id) DO NOTHING
+	RETURNING id, created_at`
+
+	var inserted []model.BudgetAlert
+	for _, alert := range alerts {
+		err := b.DB.QueryRow(ctx, query, alert.BudgetID, alert.ReceiptID, alert.Amount, alert.Threshold, alert.Spent).
+			Scan(&alert.ID, &alert.CreatedAt)
+		if errors.Is(err, pgx.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		inserted = append(inserted, alert)
+	}
+	return inserted, nil
+}
+
+func (b *BudgetRepo) GetBudgetAlerts(ctx context.Context, month time.Time) ([]model.BudgetAlert, error) {
+	query := `
+	SELECT budget_alerts.id, budget_alerts.budget_id, budget_alerts.receipt_id, budgets.month, budget_alerts.amount,
+		budget_alerts.threshold, budget_alerts.spent, budget_alerts.created_at
+	FROM budget_alerts
+	JOIN budgets ON budgets.id = budget_alerts.budget_id
+	WHERE budgets.month = $1
+	ORDER BY budget_alerts.created_at`
+
+	rows, err := b.DB.Query(ctx, query, month)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var alerts []model.BudgetAlert
+	for rows.Next() {
+		var alert model.BudgetAlert
+		var budgetMonth time.Time
+		if err := rows.Scan(&alert.ID, &alert.BudgetID, &alert.ReceiptID, &budgetMonth, &alert.Amount,
+			&alert.Threshold, &alert.Spent, &alert.CreatedAt); err != nil {
+			return nil, err
+		}
+		alert.Month = budgetMonth.Format("2006-01")
+		alerts = append(alerts, alert)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return alerts, nil
+}
@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/SarunasBucius/nutri-price-server/internal/model"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+)
+
+func (s *ContainerTestSuite) TestBudgetRepo_GetBudgetStatuses() {
+	ctx := context.Background()
+
+	err := s.Container.Restore(ctx, postgres.WithSnapshotName("emptyTables"))
+	s.Require().NoError(err)
+
+	db, err := pgxpool.New(ctx, s.Container.MustConnectionString(ctx))
+	s.Require().NoError(err)
+	defer db.Close()
+
+	var dairyID, cheeseID string
+	s.Require().NoError(db.QueryRow(ctx, "INSERT INTO categories (name) VALUES ('Dairy') RETURNING id").Scan(&dairyID))
+	s.Require().NoError(db.QueryRow(ctx, "INSERT INTO categories (name, parent_id) VALUES ('Cheese', $1) RETURNING id", dairyID).Scan(&cheeseID))
+
+	productRepo := NewProductRepo(db)
+	s.Require().NoError(productRepo.InsertProducts(ctx, []string{"cheddar", "apples"}))
+	_, err = db.Exec(ctx, "UPDATE products SET category_id = $1 WHERE name = 'cheddar'", cheeseID)
+	s.Require().NoError(err)
+	ids, err := productRepo.GetProductIDsByName(ctx, []string{"cheddar", "apples"})
+	s.Require().NoError(err)
+
+	month := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	s.Require().NoError(productRepo.InsertPurchases(ctx, "", "lidl", month.AddDate(0, 0, 4), []model.PurchasedProductNew{
+		{ProductID: ids["cheddar"], Name: "cheddar", Price: 3, Quantity: model.Quantity{Unit: model.Grams, Amount: 200}},
+		{ProductID: ids["apples"], Name: "apples", Price: 1, Quantity: model.Quantity{Unit: model.Grams, Amount: 1000}},
+	}))
+	s.Require().NoError(productRepo.InsertPurchases(ctx, "", "norfa", month.AddDate(0, 1, 0), []model.PurchasedProductNew{
+		{ProductID: ids["apples"], Name: "apples", Price: 2, Quantity: model.Quantity{Unit: model.Grams, Amount: 1000}},
+	}))
+
+	r := NewBudgetRepo(db)
+	for _, budget := range []model.Budget{
+		{Amount: 100, AlertThreshold: 0.9},
+		{CategoryID: dairyID, Amount: 10, AlertThreshold: 0.9},
+		{Retailer: "Lidl", Amount: 20, AlertThreshold: 0.8},
+	} {
+		_, err := r.UpsertBudget(ctx, month, budget)
+		s.Require().NoError(err)
+	}
+	id, err := r.UpsertBudget(ctx, month, model.Budget{Amount: 50, AlertThreshold: 0.9})
+	s.Require().NoError(err)
+
+	statuses, err := r.GetBudgetStatuses(ctx, month)
+	s.Require().NoError(err)
+	s.Require().Len(statuses, 3)
+	s.Require().Equal(id, statuses[0].ID)
+	s.Require().Equal(50.0, statuses[0].Amount)
+	s.Require().InDelta(4, statuses[0].Spent, 1e-9)
+	s.Require().Equal("Dairy", statuses[1].CategoryName)
+	s.Require().InDelta(3, statuses[1].Spent, 1e-9)
+	s.Require().Equal("Lidl", statuses[2].Retailer)
+	s.Require().InDelta(4, statuses[2].Spent, 1e-9)
+
+	s.Require().NoError(r.DeleteBudget(ctx, id))
+	s.Require().Error(r.DeleteBudget(ctx, id))
+}
+
+func (s *ContainerTestSuite) TestBudgetRepo_UpsertBudget_rearmsAlert() {
+	ctx := context.Background()
+
+	err := s.Container.Restore(ctx, postgres.WithSnapshotName("emptyTables"))
+	s.Require().NoError(err)
+
+	db, err := pgxpool.New(ctx, s.Container.MustConnectionString(ctx))
+	s.Require().NoError(err)
+	defer db.Close()
+
+	r := NewBudgetRepo(db)
+	month := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	id, err := r.UpsertBudget(ctx, month, model.Budget{Amount: 100, AlertThreshold: 0.9})
+	s.Require().NoError(err)
+
+	alert := model.BudgetAlert{BudgetID: id, ReceiptID: "1", Amount: 100, Threshold: 0.9, Spent: 95}
+	inserted, err := r.InsertBudgetAlerts(ctx, []model.BudgetAlert{alert})
+	s.Require().NoError(err)
+	s.Require().Len(inserted, 1)
+
+	_, err = r.UpsertBudget(ctx, month, model.Budget{Amount: 100, AlertThreshold: 0.9})
+	s.Require().NoError(err)
+	alerts, err := r.GetBudgetAlerts(ctx, month)
+	s.Require().NoError(err)
+	s.Require().Len(alerts, 1)
+
+	_, err = r.UpsertBudget(ctx, month, model.Budget{Amount: 150, AlertThreshold: 0.9})
+	s.Require().NoError(err)
+	alerts, err = r.GetBudgetAlerts(ctx, month)
+	s.Require().NoError(err)
+	s.Require().Empty(alerts)
+
+	inserted, err = r.InsertBudgetAlerts(ctx, []model.BudgetAlert{alert})
+	s.Require().NoError(err)
+	s.Require().Len(inserted, 1)
+}
@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+)
+
+// ProductFilterCondition returns an SQL condition on the products table applying model.ProductFilter
+// with the category ID and tag passed as the numbered parameters.
+func ProductFilterCondition(categoryIDParam, tagParam int) string {
+	return fmt.Sprintf(`(NULLIF($%[1]d, '') IS NULL OR products.category_id IN (SELECT id FROM category_subtree(NULLIF($%[1]d, '')::INT)))
+		AND (NULLIF($%[2]d, '') IS NULL OR EXISTS (
+			SELECT 1 FROM product_tags WHERE product_tags.product_id = products.id AND product_tags.tag = LOWER($%[2]d)))`,
+		categoryIDParam, tagParam)
+}
+
+// categoryRootsCTE selects the name of the top level category of each category.
+const categoryRootsCTE = `
+	WITH RECURSIVE category_roots AS (
+		SELECT id, name AS root_name FROM categories WHERE parent_id IS NULL
+		UNION ALL
+		SELECT categories.id, category_roots.root_name
+		FROM categories
+		JOIN category_roots ON categories.parent_id = category_roots.id
+	)`
+
+// GetProductRootCategories returns names of top level categories of categorised products by product name.
+func (p *ProductRepo) GetProductRootCategories(ctx context.Context) (map[string]string, error) {
+	query := categoryRootsCTE + `
+	SELECT products.name, category_roots.root_name
+	FROM products
+	JOIN category_roots ON category_roots.id = products.category_id`
+
+	rows, err := p.DB.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	categories := make(map[string]string)
+	for rows.Next() {
+		var product, category string
+		if err := rows.Scan(&product, &category); err != nil {
+			return nil, err
+		}
+		categories[product] = category
+	}
+	return categories, nil
+}
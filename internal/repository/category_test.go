@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/SarunasBucius/nutri-price-server/internal/model"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+)
+
+func (s *ContainerTestSuite) TestProductRepo_categories() {
+	ctx := context.Background()
+
+	err := s.Container.Restore(ctx, postgres.WithSnapshotName("emptyTables"))
+	s.Require().NoError(err)
+
+	db, err := pgxpool.New(ctx, s.Container.MustConnectionString(ctx))
+	s.Require().NoError(err)
+	defer db.Close()
+
+	var dairyID, cheeseID string
+	s.Require().NoError(db.QueryRow(ctx, "INSERT INTO categories (name) VALUES ('Dairy') RETURNING id").Scan(&dairyID))
+	s.Require().NoError(db.QueryRow(ctx, "INSERT INTO categories (name, parent_id) VALUES ('Cheese', $1) RETURNING id", dairyID).Scan(&cheeseID))
+
+	r := NewProductRepo(db)
+	s.Require().NoError(r.InsertProducts(ctx, []string{"cheddar", "apples"}))
+	_, err = db.Exec(ctx, "UPDATE products SET category_id = $1 WHERE name = 'cheddar'", cheeseID)
+	s.Require().NoError(err)
+	_, err = db.Exec(ctx, "INSERT INTO product_tags (product_id, tag) SELECT id, 'fruit' FROM products WHERE name = 'apples'")
+	s.Require().NoError(err)
+
+	ids, err := r.GetProductIDsByName(ctx, []string{"cheddar", "apples"})
+	s.Require().NoError(err)
+	date := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	s.Require().NoError(r.InsertPurchases(ctx, "", "lidl", date, []model.PurchasedProductNew{
+		{ProductID: ids["cheddar"], Name: "cheddar", Price: 3, Quantity: model.Quantity{Unit: model.Grams, Amount: 200}},
+		{ProductID: ids["apples"], Name: "apples", Price: 1, Quantity: model.Quantity{Unit: model.Grams, Amount: 1000}},
+	}))
+
+	categories, err := r.GetProductRootCategories(ctx)
+	s.Require().NoError(err)
+	s.Require().Equal(map[string]string{"cheddar": "Dairy"}, categories)
+
+	tests := []struct {
+		filter model.ProductFilter
+		want   []string
+	}{
+		{filter: model.ProductFilter{}, want: []string{"cheddar", "apples"}},
+		{filter: model.ProductFilter{CategoryID: dairyID}, want: []string{"cheddar"}},
+		{filter: model.ProductFilter{Tag: "Fruit"}, want: []string{"apples"}},
+	}
+	for _, tt := range tests {
+		purchases, err := r.GetPurchasesByDateRange(ctx, date, date, tt.filter)
+		s.Require().NoError(err)
+
+		var names []string
+		for _, purchase := range purchases {
+			names = append(names, purchase.Name)
+		}
+		s.Require().Equal(tt.want, names)
+	}
+}
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/SarunasBucius/nutri-price-server/internal/model"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type DepositRepo struct {
+	DB *pgxpool.Pool
+}
+
+func NewDepositRepo(db *pgxpool.Pool) *DepositRepo {
+	return &DepositRepo{DB: db}
+}
+
+// InsertDepositRefund stores the refund of a slip. Processing the same slip again does not add a second refund.
+// The slip is marked as confirmed, as it has no products to review.
+func (r *DepositRepo) InsertDepositRefund(ctx context.Context, refund model.DepositRefund) error {
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+	INSERT INTO deposits (raw_receipt_id, retailer, deposit_date, amount, kind)
+	VALUES ($1, $2, $3, $4, $5)
+	ON CONFLICT (raw_receipt_id) DO UPDATE
+	SET amount = EXCLUDED.amount, deposit_date = EXCLUDED.deposit_date`
+	if _, err := tx.Exec(ctx, query, refund.ReceiptID, refund.Retailer, refund.Date, refund.Amount, model.DepositKindRefund); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE raw_receipts SET is_confirmed = true WHERE id = $1`, refund.ReceiptID); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *DepositRepo) GetDepositBalance(ctx context.Context) (model.DepositBalance, error) {
+	query := `
+	SELECT
+		COALESCE(SUM(amount) FILTER (WHERE kind = $1), 0),
+		COALESCE(SUM(amount) FILTER (WHERE kind = $2), 0)
+	FROM deposits`
+
+	var balance model.DepositBalance
+	if err := r.DB.QueryRow(ctx, query, model.DepositKindPaid, model.DepositKindRefund).Scan(&balance.Paid, &balance.Refunded); err != nil {
+		return model.DepositBalance{}, err
+	}
+
+	return balance, nil
+}
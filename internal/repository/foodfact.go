@@ -0,0 +1,182 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/SarunasBucius/nutri-price-server/internal/model"
+	"github.com/SarunasBucius/nutri-price-server/internal/utils/uerror"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type FoodFactRepo struct {
+	DB *pgxpool.Pool
+}
+
+func NewFoodFactRepo(db *pgxpool.Pool) *FoodFactRepo {
+	return &FoodFactRepo{DB: db}
+}
+
+// UpsertFoodFacts inserts food facts or updates the ones imported from the same source before.
+func (f *FoodFactRepo) UpsertFoodFacts(ctx context.Context, facts []model.FoodFact) error {
+	batch := &pgx.Batch{}
+	for _, fact := range facts {
+		nv := fact.NutritionalValue
+		batch.Queue(`
+		INSERT INTO food_facts (
+			source, source_id, barcode, name, brands, unit, energy_value_kcal, fat, saturated_fat, carbohydrate,
+			carbohydrate_sugars, fibre, soluble_fibre, insoluble_fibre, protein, salt, nutrients
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, COALESCE($17::JSONB, '{}'))
+		ON CONFLICT (source, source_id) DO UPDATE
+		SET barcode = EXCLUDED.barcode,
+			name = EXCLUDED.name,
+			brands = EXCLUDED.brands,
+			unit = EXCLUDED.unit,
+			energy_value_kcal = EXCLUDED.energy_value_kcal,
+			fat = EXCLUDED.fat,
+			saturated_fat = EXCLUDED.saturated_fat,
+			carbohydrate = EXCLUDED.carbohydrate,
+			carbohydrate_sugars = EXCLUDED.carbohydrate_sugars,
+			fibre = EXCLUDED.fibre,
+			soluble_fibre = EXCLUDED.soluble_fibre,
+			insoluble_fibre = EXCLUDED.insoluble_fibre,
+			protein = EXCLUDED.protein,
+			salt = EXCLUDED.salt,
+			nutrients = EXCLUDED.nutrients,
+			imported_at = NOW()`,
+			fact.Source, fact.SourceID, fact.Barcode, fact.Name, fact.Brands, fact.Unit, nv.EnergyValueKCAL, nv.Fat,
+			nv.SaturatedFat, nv.Carbohydrate, nv.CarbohydrateSugars, nv.Fibre, nv.SolubleFibre, nv.InsolubleFibre,
+			nv.Protein, nv.Salt, nv.Nutrients)
+	}
+	if batch.Len() == 0 {
+		return nil
+	}
+
+	return f.DB.SendBatch(ctx, batch).Close()
+}
+
+// SearchFoodFacts returns food facts with the barcode or with names and brands starting with every word of the search.
+// Barcode matches go first, then the best name matches. Food facts of all sources are searched if source is empty.
+func (f *FoodFactRepo) SearchFoodFacts(ctx context.Context, search, source string, limit int) ([]model.FoodFact, error) {
+	query := `
+	SELECT id, source, source_id, barcode, name, brands, unit, energy_value_kcal, fat, saturated_fat, carbohydrate,
+		carbohydrate_sugars, fibre, soluble_fibre, insoluble_fibre, protein, salt, nutrients, imported_at
+	FROM food_facts
+	WHERE ($2 = '' OR source = $2)
+		AND ((barcode <> '' AND barcode = $1) OR ($3 <> '' AND search_vector @@ to_tsquery('simple', $3)))
+	ORDER BY barcode = $1 DESC, ts_rank(search_vector, to_tsquery('simple', $3)) DESC, name, id
+	LIMIT $4`
+
+	rows, err := f.DB.Query(ctx, query, strings.TrimSpace(search), source, toPrefixTSQuery(search), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var facts []model.FoodFact
+	for rows.Next() {
+		fact, err := scanFoodFact(rows)
+		if err != nil {
+			return nil, err
+		}
+		facts = append(facts, fact)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return facts, nil
+}
+
+func (f *FoodFactRepo) GetFoodFact(ctx context.Context, foodFactID int) (model.FoodFact, error) {
+	query := `
+	SELECT id, source, source_id, barcode, name, brands, unit, energy_value_kcal, fat, saturated_fat, carbohydrate,
+		carbohydrate_sugars, fibre, soluble_fibre, insoluble_fibre, protein, salt, nutrients, imported_at
+	FROM food_facts
+	WHERE id = $1`
+
+	fact, err := scanFoodFact(f.DB.QueryRow(ctx, query, foodFactID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.FoodFact{}, uerror.NewNotFound(fmt.Sprintf("food fact %d not found", foodFactID), err)
+	}
+	if err != nil {
+		return model.FoodFact{}, err
+	}
+	return fact, nil
+}
+
+func scanFoodFact(row pgx.Row) (model.FoodFact, error) {
+	var fact model.FoodFact
+	nv := &fact.NutritionalValue
+	err := row.Scan(&fact.ID, &fact.Source, &fact.SourceID, &fact.Barcode, &fact.Name, &fact.Brands, &fact.Unit,
+		&nv.EnergyValueKCAL, &nv.Fat, &nv.SaturatedFat, &nv.Carbohydrate, &nv.CarbohydrateSugars, &nv.Fibre,
+		&nv.SolubleFibre, &nv.InsolubleFibre, &nv.Protein, &nv.Salt, &nv.Nutrients, &fact.ImportedAt)
+	return fact, err
+}
+
+// ApplyFoodFact sets the nutritional value of the product variety from the food fact and records the food fact
+// as its source. Returns the nutritional value id.
+func (f *FoodFactRepo) ApplyFoodFact(ctx context.Context, foodFactID int, productID, varietyName string) (string, error) {
+	query := `
+	INSERT INTO nutritional_values_v2 (
+		product_id, variety_name, unit, energy_value_kcal, fat, saturated_fat, carbohydrate, carbohydrate_sugars,
+		fibre, soluble_fibre, insoluble_fibre, protein, salt, nutrients, source, source_id, sourced_at
+	)
+	SELECT $2, $3, unit, ROUND(energy_value_kcal), fat, saturated_fat, carbohydrate, carbohydrate_sugars,
+		fibre, soluble_fibre, insoluble_fibre, protein, salt, nutrients, source, source_id, NOW()
+	FROM food_facts
+	WHERE id = $1
+	ON CONFLICT (variety_name, product_id) DO UPDATE
+	SET unit = EXCLUDED.unit,
+		energy_value_kcal = EXCLUDED.energy_value_kcal,
+		fat = EXCLUDED.fat,
+		saturated_fat = EXCLUDED.saturated_fat,
+		carbohydrate = EXCLUDED.carbohydrate,
+		carbohydrate_sugars = EXCLUDED.carbohydrate_sugars,
+		fibre = EXCLUDED.fibre,
+		soluble_fibre = EXCLUDED.soluble_fibre,
+		insoluble_fibre = EXCLUDED.insoluble_fibre,
+		protein = EXCLUDED.protein,
+		salt = EXCLUDED.salt,
+		nutrients = EXCLUDED.nutrients,
+		source = EXCLUDED.source,
+		source_id = EXCLUDED.source_id,
+		sourced_at = EXCLUDED.sourced_at
+	RETURNING id`
+
+	var id string
+	err := f.DB.QueryRow(ctx, query, foodFactID, productID, varietyName).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", uerror.NewNotFound(fmt.Sprintf("food fact %d not found", foodFactID), err)
+	}
+	if err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// SetProductReferenceFood sets or, if foodFactID is nil, removes the reference food of the product.
+func (f *FoodFactRepo) SetProductReferenceFood(ctx context.Context, productID int, foodFactID *int) error {
+	tag, err := f.DB.Exec(ctx, "UPDATE products SET reference_food_id = $1 WHERE id = $2", foodFactID, productID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return uerror.NewNotFound(fmt.Sprintf("product %d not found", productID), nil)
+	}
+	return nil
+}
+
+// toPrefixTSQuery turns every word of the search to a prefix match, e.g. "Kefyr rok" to "kefyr:* & rok:*".
+func toPrefixTSQuery(search string) string {
+	words := strings.FieldsFunc(strings.ToLower(search), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, word := range words {
+		words[i] = word + ":*"
+	}
+	return strings.Join(words, " & ")
+}
@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/SarunasBucius/nutri-price-server/internal/model"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+)
+
+func (s *ContainerTestSuite) TestFoodFactRepo() {
+	ctx := context.Background()
+
+	err := s.Container.Restore(ctx, postgres.WithSnapshotName("emptyTables"))
+	s.Require().NoError(err)
+
+	db, err := pgxpool.New(ctx, s.Container.MustConnectionString(ctx))
+	s.Require().NoError(err)
+	defer db.Close()
+
+	r := NewFoodFactRepo(db)
+	kefir := model.FoodFact{
+		Source: model.FoodSourceOpenFoodFacts, SourceID: "4770001001234", Barcode: "4770001001234",
+		Name: "Kefyras 2,5%", Brands: "Rokiškio", Unit: model.Milliliters,
+		NutritionalValue: model.NutritionalValue{EnergyValueKCAL: 51, Fat: 2.5, Nutrients: map[string]float64{"calcium": 120}},
+	}
+	s.Require().NoError(r.UpsertFoodFacts(ctx, []model.FoodFact{
+		kefir,
+		{Source: model.FoodSourceOpenFoodFacts, SourceID: "4770001009999", Barcode: "4770001009999", Name: "Grikių kruopos", Unit: model.Grams},
+	}))
+	kefir.NutritionalValue.Fat = 2.6
+	s.Require().NoError(r.UpsertFoodFacts(ctx, []model.FoodFact{kefir}))
+
+	tests := []struct {
+		search string
+		want   []string
+	}{
+		{search: "4770001001234", want: []string{"Kefyras 2,5%"}},
+		{search: "kefyr rok", want: []string{"Kefyras 2,5%"}},
+		{search: "GRIK", want: []string{"Grikių kruopos"}},
+		{search: "pienas", want: nil},
+	}
+	for _, tt := range tests {
+		facts, err := r.SearchFoodFacts(ctx, tt.search, "", 10)
+		s.Require().NoError(err)
+
+		var names []string
+		for _, fact := range facts {
+			names = append(names, fact.Name)
+		}
+		s.Require().Equal(tt.want, names, tt.search)
+	}
+
+	facts, err := r.SearchFoodFacts(ctx, "kefyras", model.FoodSourceOpenFoodFacts, 10)
+	s.Require().NoError(err)
+	s.Require().Len(facts, 1)
+	s.Require().Equal(2.6, facts[0].NutritionalValue.Fat)
+
+	var productID string
+	s.Require().NoError(db.QueryRow(ctx, "INSERT INTO products (name) VALUES ('kefir') RETURNING id").Scan(&productID))
+	nvID, err := r.ApplyFoodFact(ctx, 0, productID, "kefir")
+	s.Require().Error(err)
+	s.Require().Empty(nvID)
+
+	nvID, err = r.ApplyFoodFact(ctx, facts[0].ID, productID, "kefir")
+	s.Require().NoError(err)
+
+	var unit, source, sourceID string
+	var fat float64
+	err = db.QueryRow(ctx, "SELECT unit, fat, source, source_id FROM nutritional_values_v2 WHERE id = $1", nvID).
+		Scan(&unit, &fat, &source, &sourceID)
+	s.Require().NoError(err)
+	s.Require().Equal(model.Milliliters, unit)
+	s.Require().Equal(2.6, fat)
+	s.Require().Equal(model.FoodSourceOpenFoodFacts, source)
+	s.Require().Equal("4770001001234", sourceID)
+}
+
+func (s *ContainerTestSuite) TestFoodFactRepo_ReferenceFood() {
+	ctx := context.Background()
+
+	err := s.Container.Restore(ctx, postgres.WithSnapshotName("emptyTables"))
+	s.Require().NoError(err)
+
+	db, err := pgxpool.New(ctx, s.Container.MustConnectionString(ctx))
+	s.Require().NoError(err)
+	defer db.Close()
+
+	r := NewFoodFactRepo(db)
+	s.Require().NoError(r.UpsertFoodFacts(ctx, []model.FoodFact{{
+		Source: model.FoodSourceFoodDataCentral, SourceID: "170393", Name: "Carrots, raw", Unit: model.Grams,
+		NutritionalValue: model.NutritionalValue{EnergyValueKCAL: 41, Fibre: 2.8},
+	}}))
+	facts, err := r.SearchFoodFacts(ctx, "carrots", model.FoodSourceFoodDataCentral, 10)
+	s.Require().NoError(err)
+	s.Require().Len(facts, 1)
+
+	fact, err := r.GetFoodFact(ctx, facts[0].ID)
+	s.Require().NoError(err)
+	s.Require().Equal("Carrots, raw", fact.Name)
+	_, err = r.GetFoodFact(ctx, 0)
+	s.Require().Error(err)
+
+	var productID int
+	err = db.QueryRow(ctx, "INSERT INTO products (name) VALUES ('carrots') RETURNING id").Scan(&productID)
+	s.Require().NoError(err)
+	s.Require().NoError(r.SetProductReferenceFood(ctx, productID, &facts[0].ID))
+	s.Require().Error(r.SetProductReferenceFood(ctx, productID+1, &facts[0].ID))
+	_, err = db.Exec(ctx, "INSERT INTO product_codes (code, product_id, variety_name) VALUES ('20000011', $1, 'carrots')", productID)
+	s.Require().NoError(err)
+
+	productRepo := NewProductRepo(db)
+	product, err := productRepo.GetProductByCode(ctx, "20000011")
+	s.Require().NoError(err)
+	s.Require().Equal(model.Grams, product.Unit)
+	s.Require().NotNil(product.NutritionalValue)
+	s.Require().Equal(41.0, product.NutritionalValue.EnergyValueKCAL)
+
+	_, err = db.Exec(ctx, "INSERT INTO nutritional_values_v2 (product_id, variety_name, unit, energy_value_kcal) VALUES ($1, 'carrots', $2, 35)",
+		productID, model.Grams)
+	s.Require().NoError(err)
+	product, err = productRepo.GetProductByCode(ctx, "20000011")
+	s.Require().NoError(err)
+	s.Require().NotNil(product.NutritionalValue)
+	s.Require().Equal(35.0, product.NutritionalValue.EnergyValueKCAL)
+}
@@ -23,12 +23,12 @@ func (n *NutritionalValueRepo) InsertProductNutritionalValue(ctx context.Context
 	query := `
 INSERT INTO nutritional_values (
 	product, measurement_unit, energy_value_kcal, fat, saturated_fat, carbohydrate, 
-	carbohydrate_sugars, fibre, soluble_fibre, insoluble_fibre, protein, salt
+	carbohydrate_sugars, fibre, soluble_fibre, insoluble_fibre, protein, salt, nutrients
 ) VALUES (
-	$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
+	$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, COALESCE($13::JSONB, '{}')
 )`
 	if _, err := n.DB.Exec(ctx, query,
-		product, measurementUnit, nv.EnergyValueKCAL, nv.Fat, nv.SaturatedFat, nv.Carbohydrate, nv.CarbohydrateSugars, nv.Fibre, nv.SolubleFibre, nv.InsolubleFibre, nv.Protein, nv.Salt); err != nil {
+		product, measurementUnit, nv.EnergyValueKCAL, nv.Fat, nv.SaturatedFat, nv.Carbohydrate, nv.CarbohydrateSugars, nv.Fibre, nv.SolubleFibre, nv.InsolubleFibre, nv.Protein, nv.Salt, nv.Nutrients); err != nil {
 		return err
 	}
 
@@ -40,7 +40,7 @@ func (n *NutritionalValueRepo) GetProductsNutritionalValue(ctx context.Context)
 	SELECT 
 		id, product, measurement_unit, energy_value_kcal,
 		fat, saturated_fat, carbohydrate, carbohydrate_sugars, 
-		fibre, soluble_fibre, insoluble_fibre, protein, salt
+		fibre, soluble_fibre, insoluble_fibre, protein, salt, nutrients
 	FROM nutritional_values`
 
 	rows, err := n.DB.Query(ctx, query)
@@ -56,6 +56,7 @@ func (n *NutritionalValueRepo) GetProductsNutritionalValue(ctx context.Context)
 			&n.ID, &n.Product, &n.Unit, &n.NutritionalValue.EnergyValueKCAL,
 			&n.NutritionalValue.Fat, &n.NutritionalValue.SaturatedFat, &n.NutritionalValue.Carbohydrate, &n.NutritionalValue.CarbohydrateSugars,
 			&n.NutritionalValue.Fibre, &n.NutritionalValue.SolubleFibre, &n.NutritionalValue.InsolubleFibre, &n.NutritionalValue.Protein, &n.NutritionalValue.Salt,
+			&n.NutritionalValue.Nutrients,
 		); err != nil {
 			return nil, err
 		}
@@ -69,7 +70,7 @@ func (n *NutritionalValueRepo) GetProductsNutritionalValueByProductNames(ctx con
 	SELECT 
 		id, product, measurement_unit, energy_value_kcal,
 		fat, saturated_fat, carbohydrate, carbohydrate_sugars, 
-		fibre, soluble_fibre, insoluble_fibre, protein, salt
+		fibre, soluble_fibre, insoluble_fibre, protein, salt, nutrients
 	FROM nutritional_values
 	WHERE product=ANY($1)`
 
@@ -86,6 +87,7 @@ func (n *NutritionalValueRepo) GetProductsNutritionalValueByProductNames(ctx con
 			&pnv.ID, &pnv.Product, &pnv.Unit, &pnv.NutritionalValue.EnergyValueKCAL,
 			&pnv.NutritionalValue.Fat, &pnv.NutritionalValue.SaturatedFat, &pnv.NutritionalValue.Carbohydrate, &pnv.NutritionalValue.CarbohydrateSugars,
 			&pnv.NutritionalValue.Fibre, &pnv.NutritionalValue.SolubleFibre, &pnv.NutritionalValue.InsolubleFibre, &pnv.NutritionalValue.Protein, &pnv.NutritionalValue.Salt,
+			&pnv.NutritionalValue.Nutrients,
 		); err != nil {
 			return nil, err
 		}
@@ -94,12 +96,49 @@ func (n *NutritionalValueRepo) GetProductsNutritionalValueByProductNames(ctx con
 	return productNVs, nil
 }
 
+// GetReferenceFoodsNutritionalValueByProductNames returns nutritional values of reference foods of the products,
+// by the product name.
+func (n *NutritionalValueRepo) GetReferenceFoodsNutritionalValueByProductNames(ctx context.Context, productNames []string) ([]model.ProductNutritionalValue, error) {
+	query := `
+	SELECT
+		products.name, food_facts.unit, ROUND(food_facts.energy_value_kcal),
+		food_facts.fat, food_facts.saturated_fat, food_facts.carbohydrate, food_facts.carbohydrate_sugars,
+		food_facts.fibre, food_facts.soluble_fibre, food_facts.insoluble_fibre, food_facts.protein, food_facts.salt, food_facts.nutrients
+	FROM products
+	JOIN food_facts ON food_facts.id = products.reference_food_id
+	WHERE products.name=ANY($1)`
+
+	rows, err := n.DB.Query(ctx, query, productNames)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var productNVs []model.ProductNutritionalValue
+	for rows.Next() {
+		var pnv model.ProductNutritionalValue
+		if err := rows.Scan(
+			&pnv.Product, &pnv.Unit, &pnv.NutritionalValue.EnergyValueKCAL,
+			&pnv.NutritionalValue.Fat, &pnv.NutritionalValue.SaturatedFat, &pnv.NutritionalValue.Carbohydrate, &pnv.NutritionalValue.CarbohydrateSugars,
+			&pnv.NutritionalValue.Fibre, &pnv.NutritionalValue.SolubleFibre, &pnv.NutritionalValue.InsolubleFibre, &pnv.NutritionalValue.Protein, &pnv.NutritionalValue.Salt,
+			&pnv.NutritionalValue.Nutrients,
+		); err != nil {
+			return nil, err
+		}
+		productNVs = append(productNVs, pnv)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return productNVs, nil
+}
+
 func (n *NutritionalValueRepo) GetProductNutritionalValue(ctx context.Context, nutritionalValueID int) (model.ProductNutritionalValue, error) {
 	query := `
 	SELECT 
 		id, product, measurement_unit, energy_value_kcal,
 		fat, saturated_fat, carbohydrate, carbohydrate_sugars, 
-		fibre, soluble_fibre, insoluble_fibre, protein, salt
+		fibre, soluble_fibre, insoluble_fibre, protein, salt, nutrients
 	FROM nutritional_values
 	WHERE id=$1`
 
@@ -108,6 +147,7 @@ func (n *NutritionalValueRepo) GetProductNutritionalValue(ctx context.Context, n
 		&pnv.ID, &pnv.Product, &pnv.Unit, &pnv.NutritionalValue.EnergyValueKCAL,
 		&pnv.NutritionalValue.Fat, &pnv.NutritionalValue.SaturatedFat, &pnv.NutritionalValue.Carbohydrate, &pnv.NutritionalValue.CarbohydrateSugars,
 		&pnv.NutritionalValue.Fibre, &pnv.NutritionalValue.SolubleFibre, &pnv.NutritionalValue.InsolubleFibre, &pnv.NutritionalValue.Protein, &pnv.NutritionalValue.Salt,
+		&pnv.NutritionalValue.Nutrients,
 	)
 	if errors.Is(err, pgx.ErrNoRows) {
 		return model.ProductNutritionalValue{}, uerror.NewNotFound("nutritional value not found", err)
@@ -163,12 +203,13 @@ func (n *NutritionalValueRepo) UpdateProductNutritionalValue(ctx context.Context
 		soluble_fibre = $9,
 		insoluble_fibre = $10,
 		protein = $11,
-		salt = $12
-	WHERE id = $13`
+		salt = $12,
+		nutrients = COALESCE($13::JSONB, '{}')
+	WHERE id = $14`
 	status, err := n.DB.Exec(ctx, query, pnv.Product, pnv.Unit, pnv.NutritionalValue.EnergyValueKCAL,
 		pnv.NutritionalValue.Fat, pnv.NutritionalValue.SaturatedFat, pnv.NutritionalValue.Carbohydrate, pnv.NutritionalValue.CarbohydrateSugars,
 		pnv.NutritionalValue.Fibre, pnv.NutritionalValue.SolubleFibre, &pnv.NutritionalValue.InsolubleFibre,
-		pnv.NutritionalValue.Protein, pnv.NutritionalValue.Salt, pnv.ID)
+		pnv.NutritionalValue.Protein, pnv.NutritionalValue.Salt, pnv.NutritionalValue.Nutrients, pnv.ID)
 	if err != nil {
 		return err
 	}
@@ -239,3 +280,36 @@ func (n *NutritionalValueRepo) getExistingProducts(ctx context.Context, products
 	}
 	return existingProducts, nil
 }
+
+func (n *NutritionalValueRepo) GetNutrients(ctx context.Context) ([]model.Nutrient, error) {
+	rows, err := n.DB.Query(ctx, "SELECT code, name, unit, daily_reference FROM nutrients ORDER BY name")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var nutrients []model.Nutrient
+	for rows.Next() {
+		var nutrient model.Nutrient
+		if err := rows.Scan(&nutrient.Code, &nutrient.Name, &nutrient.Unit, &nutrient.DailyReference); err != nil {
+			return nil, err
+		}
+		nutrients = append(nutrients, nutrient)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return nutrients, nil
+}
+
+func (n *NutritionalValueRepo) UpsertNutrient(ctx context.Context, nutrient model.Nutrient) error {
+	query := `
+	INSERT INTO nutrients (code, name, unit, daily_reference)
+	VALUES ($1, $2, $3, $4)
+	ON CONFLICT (code) DO UPDATE
+	SET name = EXCLUDED.name, unit = EXCLUDED.unit, daily_reference = EXCLUDED.daily_reference`
+	if _, err := n.DB.Exec(ctx, query, nutrient.Code, nutrient.Name, nutrient.Unit, nutrient.DailyReference); err != nil {
+		return err
+	}
+	return nil
+}
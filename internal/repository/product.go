@@ -2,11 +2,13 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/SarunasBucius/nutri-price-server/internal/model"
+	"github.com/SarunasBucius/nutri-price-server/internal/utils/uerror"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
@@ -39,20 +41,64 @@ func (p *ProductRepo) InsertProducts(ctx context.Context, productNames []string)
 	return err
 }
 
-func (p *ProductRepo) InsertPurchases(ctx context.Context, retailer string, purchaseDate time.Time, products []model.PurchasedProductNew) error {
+// InsertPurchases inserts purchase lines. Deposits of the purchases are stored in the deposits table linked to their purchase.
+// The raw receipt, unless receiptID is empty, is marked confirmed in the same transaction, so purchases of a receipt
+// are inserted once even if it is confirmed concurrently.
+func (p *ProductRepo) InsertPurchases(ctx context.Context, receiptID, retailer string, purchaseDate time.Time, products []model.PurchasedProductNew) error {
+	tx, err := p.DB.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if receiptID != "" {
+		tag, err := tx.Exec(ctx, "UPDATE raw_receipts SET is_confirmed = true WHERE id = $1 AND NOT is_confirmed", receiptID)
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return uerror.NewConflict(fmt.Sprintf("receipt with id %q is already confirmed", receiptID), nil)
+		}
+	}
+
+	var rawReceiptID *string
+	if receiptID != "" {
+		rawReceiptID = &receiptID
+	}
+
 	rows := make([][]interface{}, 0, len(products))
+	batch := &pgx.Batch{}
 	for _, p := range products {
-		row := []interface{}{p.ProductID, retailer, purchaseDate, p.Quantity.Unit, p.Quantity.Amount, p.Price, p.Notes, p.VarietyName}
+		if p.Deposit > 0 {
+			batch.Queue(`
+			WITH purchase AS (
+				INSERT INTO purchases (product_id, retailer, purchase_date, unit, quantity, price, full_price, discount, discount_type, notes, variety_name, article_code, raw_receipt_id)
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $15)
+				RETURNING id
+			)
+			INSERT INTO deposits (purchase_id, retailer, deposit_date, amount, kind)
+			SELECT id, $2, $3, $13, $14 FROM purchase`,
+				p.ProductID, retailer, purchaseDate, p.Quantity.Unit, p.Quantity.Amount, p.Price, p.FullPrice, p.Discount, p.DiscountType, p.Notes, p.VarietyName, p.ArticleCode,
+				p.Deposit, model.DepositKindPaid, rawReceiptID)
+			continue
+		}
+		row := []interface{}{p.ProductID, retailer, purchaseDate, p.Quantity.Unit, p.Quantity.Amount, p.Price, p.FullPrice, p.Discount, p.DiscountType, p.Notes, p.VarietyName, p.ArticleCode, rawReceiptID}
 		rows = append(rows, row)
 	}
 
-	_, err := p.DB.CopyFrom(ctx,
+	if _, err := tx.CopyFrom(ctx,
 		pgx.Identifier{"purchases"},
-		[]string{"product_id", "retailer", "purchase_date", "unit", "quantity", "price", "notes", "variety_name"},
+		[]string{"product_id", "retailer", "purchase_date", "unit", "quantity", "price", "full_price", "discount", "discount_type", "notes", "variety_name", "article_code", "raw_receipt_id"},
 		pgx.CopyFromRows(rows),
-	)
+	); err != nil {
+		return err
+	}
 
-	return err
+	if err := tx.SendBatch(ctx, batch).Close(); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
 }
 
 func (p *ProductRepo) GetProductIDsByName(ctx context.Context, productNames []string) (map[string]string, error) {
@@ -74,13 +120,90 @@ func (p *ProductRepo) GetProductIDsByName(ctx context.Context, productNames []st
 	return productIDs, nil
 }
 
-func (p *ProductRepo) GetLastBoughtProductsByNamesOrGroups(ctx context.Context, productNames []string) ([]model.PurchasedProduct, error) {
+// UpsertProductCodes links article codes of the purchases to their product and variety.
+// Codes other than EAN barcodes are internal to the retailer, so they are stored together with it.
+// A code confirmed again as another variety is moved to it.
+func (p *ProductRepo) UpsertProductCodes(ctx context.Context, retailer string, products []model.PurchasedProductNew) error {
+	batch := &pgx.Batch{}
+	for _, product := range products {
+		if product.ArticleCode == "" {
+			continue
+		}
+		batch.Queue(`
+		INSERT INTO product_codes (retailer, code, product_id, variety_name) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (retailer, code) DO UPDATE
+		SET product_id = EXCLUDED.product_id, variety_name = EXCLUDED.variety_name`,
+			model.GetCodeRetailer(product.ArticleCode, retailer), product.ArticleCode, product.ProductID, product.VarietyName)
+	}
+	if batch.Len() == 0 {
+		return nil
+	}
+
+	return p.DB.SendBatch(ctx, batch).Close()
+}
+
+// GetProductByCode returns the product and variety linked to the EAN barcode with the variety nutritional value.
+// The nutritional value of the product reference food is returned when the variety has none.
+func (p *ProductRepo) GetProductByCode(ctx context.Context, code string) (model.BarcodeProduct, error) {
 	query := `
-	SELECT DISTINCT ON (product_name) 
-		id, variety_name, retailer, unit, quantity, price, notes, purchase_date
+	SELECT product_codes.code, products.id, products.name, product_codes.variety_name
+	FROM product_codes
+	JOIN products ON products.id = product_codes.product_id
+	WHERE product_codes.retailer = '' AND product_codes.code = $1`
+
+	var product model.BarcodeProduct
+	err := p.DB.QueryRow(ctx, query, code).Scan(&product.Code, &product.ProductID, &product.Name, &product.VarietyName)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.BarcodeProduct{}, uerror.NewNotFound(fmt.Sprintf("product with code %q does not exist", code), err)
+	}
+	if err != nil {
+		return model.BarcodeProduct{}, err
+	}
+
+	query = `
+	SELECT unit, energy_value_kcal, fat, saturated_fat, carbohydrate, carbohydrate_sugars,
+		fibre, soluble_fibre, insoluble_fibre, protein, salt, nutrients
+	FROM nutritional_values_v2
+	WHERE product_id = $1 AND variety_name = $2`
+
+	var nv model.NutritionalValue
+	scan := func(row pgx.Row) error {
+		return row.Scan(&product.Unit, &nv.EnergyValueKCAL, &nv.Fat, &nv.SaturatedFat, &nv.Carbohydrate, &nv.CarbohydrateSugars,
+			&nv.Fibre, &nv.SolubleFibre, &nv.InsolubleFibre, &nv.Protein, &nv.Salt, &nv.Nutrients)
+	}
+	err = scan(p.DB.QueryRow(ctx, query, product.ProductID, product.VarietyName))
+	if errors.Is(err, pgx.ErrNoRows) {
+		query = `
+		SELECT food_facts.unit, energy_value_kcal, fat, saturated_fat, carbohydrate, carbohydrate_sugars,
+			fibre, soluble_fibre, insoluble_fibre, protein, salt, nutrients
+		FROM products
+		JOIN food_facts ON food_facts.id = products.reference_food_id
+		WHERE products.id = $1`
+		err = scan(p.DB.QueryRow(ctx, query, product.ProductID))
+	}
+	if errors.Is(err, pgx.ErrNoRows) {
+		return product, nil
+	}
+	if err != nil {
+		return model.BarcodeProduct{}, err
+	}
+	product.NutritionalValue = &nv
+
+	return product, nil
+}
+
+// GetPurchasesByNamesOrGroups returns dated purchases of products matched by product or variety name.
+// Name of a returned purchase is the name it was matched by.
+func (p *ProductRepo) GetPurchasesByNamesOrGroups(ctx context.Context, productNames []string) ([]model.PurchasedProduct, error) {
+	query := `
+	SELECT DISTINCT
+		purchases.id, matched.name, purchases.variety_name, purchases.retailer, purchases.unit, purchases.quantity,
+		purchases.price, purchases.notes, purchases.purchase_date
 	FROM purchases
-	WHERE variety_name=ANY($1)
-	ORDER BY variety_name, purchase_date DESC`
+	JOIN products ON products.id = purchases.product_id
+	CROSS JOIN LATERAL (SELECT products.name UNION SELECT purchases.variety_name) AS matched(name)
+	WHERE matched.name = ANY($1) AND purchases.purchase_date IS NOT NULL
+	ORDER BY purchases.purchase_date DESC, purchases.id DESC, matched.name`
 
 	rows, err := p.DB.Query(ctx, query, productNames)
 	if err != nil {
@@ -92,7 +215,37 @@ func (p *ProductRepo) GetLastBoughtProductsByNamesOrGroups(ctx context.Context,
 	for rows.Next() {
 		var p model.PurchasedProduct
 		if err := rows.Scan(
-			&p.ID, &p.VarietyName, &p.Retailer, &p.Quantity.Unit, &p.Quantity.Amount, &p.Price, &p.Notes, &p.Date,
+			&p.ID, &p.Name, &p.VarietyName, &p.Retailer, &p.Quantity.Unit, &p.Quantity.Amount, &p.Price, &p.Notes, &p.Date,
+		); err != nil {
+			return nil, err
+		}
+		products = append(products, p)
+	}
+	return products, nil
+}
+
+// GetPurchasesByDateRange returns purchases of filtered products made between the dates, inclusive,
+// named by their product names.
+func (p *ProductRepo) GetPurchasesByDateRange(ctx context.Context, from, to time.Time, filter model.ProductFilter) ([]model.PurchasedProduct, error) {
+	query := `
+	SELECT purchases.id, products.name, purchases.variety_name, purchases.retailer, purchases.unit, purchases.quantity,
+		purchases.price, purchases.notes, purchases.purchase_date
+	FROM purchases
+	JOIN products ON products.id = purchases.product_id
+	WHERE purchases.purchase_date BETWEEN $1 AND $2 AND ` + ProductFilterCondition(3, 4) + `
+	ORDER BY purchases.purchase_date, purchases.id`
+
+	rows, err := p.DB.Query(ctx, query, from, to, filter.CategoryID, filter.Tag)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var products []model.PurchasedProduct
+	for rows.Next() {
+		var p model.PurchasedProduct
+		if err := rows.Scan(
+			&p.ID, &p.Name, &p.VarietyName, &p.Retailer, &p.Quantity.Unit, &p.Quantity.Amount, &p.Price, &p.Notes, &p.Date,
 		); err != nil {
 			return nil, err
 		}
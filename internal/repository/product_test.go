@@ -83,7 +83,7 @@ func (s *ContainerTestSuite) TestProductRepo_InsertProducts() {
 			}
 			s.Require().NoError(err)
 
-			err = r.InsertPurchases(tt.args.ctx, tt.args.retailer, tt.args.purchaseDate, tt.args.products)
+			err = r.InsertPurchases(tt.args.ctx, "", tt.args.retailer, tt.args.purchaseDate, tt.args.products)
 			if tt.wantErr {
 				s.Require().Error(err)
 				return
@@ -92,3 +92,98 @@ func (s *ContainerTestSuite) TestProductRepo_InsertProducts() {
 		})
 	}
 }
+
+func (s *ContainerTestSuite) TestProductRepo_GetPurchasesByNamesOrGroups() {
+	ctx := context.Background()
+
+	err := s.Container.Restore(ctx, postgres.WithSnapshotName("emptyTables"))
+	s.Require().NoError(err)
+
+	db, err := pgxpool.New(ctx, s.Container.MustConnectionString(ctx))
+	s.Require().NoError(err)
+	defer db.Close()
+
+	r := NewProductRepo(db)
+	s.Require().NoError(r.InsertProducts(ctx, []string{"apples"}))
+	s.Require().NoError(r.InsertPurchases(ctx, "", "norfa", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), []model.PurchasedProductNew{
+		{ProductID: "1", Name: "apples", VarietyName: "red", Price: 1, Quantity: model.Quantity{Unit: model.Grams, Amount: 500}},
+	}))
+	s.Require().NoError(r.InsertPurchases(ctx, "", "lidl", time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), []model.PurchasedProductNew{
+		{ProductID: "1", Name: "apples", VarietyName: "green", Price: 2, Quantity: model.Quantity{Unit: model.Grams, Amount: 1000}},
+	}))
+
+	got, err := r.GetPurchasesByNamesOrGroups(ctx, []string{"apples", "red"})
+	s.Require().NoError(err)
+
+	var names []string
+	for _, purchase := range got {
+		names = append(names, purchase.Name+"/"+purchase.VarietyName)
+	}
+	s.Require().Equal([]string{"apples/green", "apples/red", "red/red"}, names)
+}
+
+func (s *ContainerTestSuite) TestProductRepo_InsertPurchases_confirmsReceiptOnce() {
+	ctx := context.Background()
+
+	err := s.Container.Restore(ctx, postgres.WithSnapshotName("emptyTables"))
+	s.Require().NoError(err)
+
+	db, err := pgxpool.New(ctx, s.Container.MustConnectionString(ctx))
+	s.Require().NoError(err)
+	defer db.Close()
+
+	date := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	receiptID, _, err := NewReceiptRepo(db).InsertRawReceipt(ctx, model.RawReceipt{Date: date, Retailer: "norfa", Receipt: "receipt"})
+	s.Require().NoError(err)
+
+	r := NewProductRepo(db)
+	s.Require().NoError(r.InsertProducts(ctx, []string{"apples"}))
+	purchases := []model.PurchasedProductNew{
+		{ProductID: "1", Name: "apples", Price: 1, Quantity: model.Quantity{Unit: model.Grams, Amount: 500}},
+	}
+	s.Require().NoError(r.InsertPurchases(ctx, receiptID, "norfa", date, purchases))
+	s.Require().Error(r.InsertPurchases(ctx, receiptID, "norfa", date, purchases))
+
+	var count int
+	s.Require().NoError(db.QueryRow(ctx, "SELECT COUNT(*) FROM purchases").Scan(&count))
+	s.Require().Equal(1, count)
+}
+
+func (s *ContainerTestSuite) TestProductRepo_UpsertProductCodes() {
+	ctx := context.Background()
+
+	err := s.Container.Restore(ctx, postgres.WithSnapshotName("emptyTables"))
+	s.Require().NoError(err)
+
+	db, err := pgxpool.New(ctx, s.Container.MustConnectionString(ctx))
+	s.Require().NoError(err)
+	defer db.Close()
+
+	r := NewProductRepo(db)
+	s.Require().NoError(r.InsertProducts(ctx, []string{"chocolate", "milk"}))
+	productIDs, err := r.GetProductIDsByName(ctx, []string{"chocolate", "milk"})
+	s.Require().NoError(err)
+
+	s.Require().NoError(r.UpsertProductCodes(ctx, "lidl", []model.PurchasedProductNew{
+		{ProductID: productIDs["chocolate"], VarietyName: "dark chocolate", ArticleCode: "0159177"},
+		{ProductID: productIDs["milk"], VarietyName: "milk 2.5%", ArticleCode: "4770001234563"},
+	}))
+	s.Require().NoError(r.UpsertProductCodes(ctx, "maxima", []model.PurchasedProductNew{
+		{ProductID: productIDs["milk"], VarietyName: "skimmed milk", ArticleCode: "0159177"},
+	}))
+
+	product, err := r.GetProductByCode(ctx, "4770001234563")
+	s.Require().NoError(err)
+	s.Require().Equal("milk 2.5%", product.VarietyName)
+
+	_, err = r.GetProductByCode(ctx, "0159177")
+	s.Require().Error(err)
+
+	receiptRepo := NewReceiptRepo(db)
+	productByCode, err := receiptRepo.GetProductNamesByArticleCodes(ctx, "lidl", []string{"0159177", "4770001234563"})
+	s.Require().NoError(err)
+	s.Require().Equal(map[string]model.ProductAndVarietyName{
+		"0159177":       {Name: "chocolate", VarietyName: "dark chocolate"},
+		"4770001234563": {Name: "milk", VarietyName: "milk 2.5%"},
+	}, productByCode)
+}
@@ -3,9 +3,11 @@ package repository
 import (
 	"context"
 	"encoding/json"
-	"time"
+	"errors"
+	"fmt"
 
 	"github.com/SarunasBucius/nutri-price-server/internal/model"
+	"github.com/SarunasBucius/nutri-price-server/internal/utils/uerror"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -19,23 +21,47 @@ func NewReceiptRepo(db *pgxpool.Pool) *ReceiptRepo {
 	return &ReceiptRepo{DB: db}
 }
 
-func (r *ReceiptRepo) InsertRawReceipt(ctx context.Context, receiptDate time.Time, receipt, retailer string, parsedProducts model.ReceiptProducts) error {
+// InsertRawReceipt stores the receipt and returns its ID and whether it was already stored.
+// Resubmitting the same receipt text updates the parsed products of the stored receipt, unless it is already confirmed.
+func (r *ReceiptRepo) InsertRawReceipt(ctx context.Context, rawReceipt model.RawReceipt) (string, bool, error) {
 	query := `
-	INSERT INTO raw_receipts (purchase_date, receipt, retailer, parsed_products) 
-	VALUES ($1, $2, $3, $4) 
-	ON CONFLICT (purchase_date, retailer) 
-	DO UPDATE SET parsed_products = EXCLUDED.parsed_products`
-
-	productsJSON, err := json.Marshal(parsedProducts)
+	INSERT INTO raw_receipts (purchase_date, purchase_time, receipt_number, receipt, retailer, parsed_products, content_hash, message_id) 
+	VALUES ($1, $2, $3, $4, $5, $6, encode(sha256(convert_to($4, 'UTF8')), 'hex'), NULLIF($7, '')) 
+	ON CONFLICT (content_hash) 
+	DO UPDATE SET parsed_products = CASE WHEN raw_receipts.is_confirmed THEN raw_receipts.parsed_products ELSE EXCLUDED.parsed_products END,
+		purchase_time = EXCLUDED.purchase_time,
+		receipt_number = EXCLUDED.receipt_number,
+		message_id = COALESCE(raw_receipts.message_id, EXCLUDED.message_id)
+	RETURNING id, xmax <> 0`
+
+	productsJSON, err := json.Marshal(rawReceipt.ParsedProducts)
 	if err != nil {
-		return err
+		return "", false, err
 	}
 
-	if _, err := r.DB.Exec(ctx, query, receiptDate, receipt, retailer, productsJSON); err != nil {
-		return err
+	// xmax of a row is set only when the upsert updated an existing receipt.
+	var id string
+	var alreadyStored bool
+	if err := r.DB.QueryRow(ctx, query,
+		rawReceipt.Date, rawReceipt.Time, rawReceipt.ReceiptNumber, rawReceipt.Receipt, rawReceipt.Retailer, productsJSON, rawReceipt.MessageID,
+	).Scan(&id, &alreadyStored); err != nil {
+		return "", false, err
 	}
 
-	return r.insertParsedProducts(ctx, parsedProducts)
+	return id, alreadyStored, r.insertParsedProducts(ctx, rawReceipt.ParsedProducts)
+}
+
+// IsEmailReceiptProcessed reports whether a receipt from the email with the Message-ID is already stored.
+func (r *ReceiptRepo) IsEmailReceiptProcessed(ctx context.Context, messageID string) (bool, error) {
+	query := `
+	SELECT EXISTS (SELECT 1 FROM raw_receipts WHERE message_id = $1)`
+
+	var exists bool
+	if err := r.DB.QueryRow(ctx, query, messageID).Scan(&exists); err != nil {
+		return false, err
+	}
+
+	return exists, nil
 }
 
 func (r *ReceiptRepo) insertParsedProducts(ctx context.Context, parsedProducts model.ReceiptProducts) error {
@@ -49,18 +75,18 @@ func (r *ReceiptRepo) insertParsedProducts(ctx context.Context, parsedProducts m
 	return br.Close()
 }
 
-func (r *ReceiptRepo) SetRawReceiptSubmittedProducts(ctx context.Context, receiptDate time.Time, submittedProducts []model.PurchasedProductNew) error {
+func (r *ReceiptRepo) SetRawReceiptSubmittedProducts(ctx context.Context, receiptID string, submittedProducts []model.PurchasedProductNew) error {
 	query := `
 	UPDATE raw_receipts 
 	SET submitted_products = $1
-	WHERE purchase_date = $2`
+	WHERE id = $2`
 
 	productsJSON, err := json.Marshal(submittedProducts)
 	if err != nil {
 		return err
 	}
 
-	if _, err := r.DB.Exec(ctx, query, productsJSON, receiptDate); err != nil {
+	if _, err := r.DB.Exec(ctx, query, productsJSON, receiptID); err != nil {
 		return err
 	}
 
@@ -82,14 +108,50 @@ func (r *ReceiptRepo) GetUnprocessedReceipt(ctx context.Context) (string, error)
 	return receipt, nil
 }
 
-func (r *ReceiptRepo) GetRawReceiptByDate(ctx context.Context, date time.Time) (string, error) {
+// GetStoredReceipts returns all stored receipts, oldest first.
+func (r *ReceiptRepo) GetStoredReceipts(ctx context.Context) ([]model.StoredReceipt, error) {
+	query := `
+	SELECT id, purchase_date, retailer, receipt, parsed_products,
+		COALESCE(submitted_products, '[]'::json), submitted_products IS NOT NULL
+	FROM raw_receipts
+	ORDER BY id`
+
+	rows, err := r.DB.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var receipts []model.StoredReceipt
+	for rows.Next() {
+		var receipt model.StoredReceipt
+		var receiptDate pgtype.Date
+		if err := rows.Scan(&receipt.ID, &receiptDate, &receipt.Retailer, &receipt.Receipt,
+			&receipt.ParsedProducts, &receipt.SubmittedProducts, &receipt.IsSubmitted); err != nil {
+			return nil, err
+		}
+		receipt.Date = receiptDate.Time
+		receipts = append(receipts, receipt)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return receipts, nil
+}
+
+func (r *ReceiptRepo) GetRawReceiptByID(ctx context.Context, receiptID string) (string, error) {
 	query := `
 	SELECT receipt
 	FROM raw_receipts
-	WHERE purchase_date = $1`
+	WHERE id = $1`
 
 	var receipt string
-	if err := r.DB.QueryRow(ctx, query, date).Scan(&receipt); err != nil {
+	err := r.DB.QueryRow(ctx, query, receiptID).Scan(&receipt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", uerror.NewNotFound(fmt.Sprintf("receipt with id %q does not exist", receiptID), err)
+	}
+	if err != nil {
 		return "", err
 	}
 
@@ -137,11 +199,75 @@ func (r *ReceiptRepo) GetProductNameAlias(ctx context.Context, parsedNames []str
 	return aliases, nil
 }
 
+// GetProductNamesByArticleCodes returns product and variety names confirmed for EAN barcodes
+// and for article codes of the retailer.
+func (r *ReceiptRepo) GetProductNamesByArticleCodes(ctx context.Context, retailer string, codes []string) (map[string]model.ProductAndVarietyName, error) {
+	query := `
+	SELECT product_codes.code, products.name, product_codes.variety_name
+	FROM product_codes
+	JOIN products ON products.id = product_codes.product_id
+	WHERE product_codes.code = ANY($1) AND product_codes.retailer IN ('', $2)`
+
+	rows, err := r.DB.Query(ctx, query, codes, retailer)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	productByCode := make(map[string]model.ProductAndVarietyName)
+	for rows.Next() {
+		var code string
+		var product model.ProductAndVarietyName
+		if err := rows.Scan(&code, &product.Name, &product.VarietyName); err != nil {
+			return nil, err
+		}
+		productByCode[code] = product
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return productByCode, nil
+}
+
+// GetNameMatchCandidates returns purchased product varieties with their purchase count
+// and parsed names that were confirmed as the variety.
+func (r *ReceiptRepo) GetNameMatchCandidates(ctx context.Context) ([]model.NameMatchCandidate, error) {
+	query := `
+	SELECT products.name, purchases.variety_name, COUNT(DISTINCT purchases.id),
+		COALESCE(array_agg(DISTINCT aliases.parsed_product_name) FILTER (WHERE aliases.parsed_product_name IS NOT NULL), '{}')
+	FROM purchases
+	JOIN products ON products.id = purchases.product_id
+	LEFT JOIN purchased_products_aliases aliases ON aliases.user_defined_product_name = products.name
+		AND aliases.user_defined_variety_name = purchases.variety_name
+	GROUP BY products.name, purchases.variety_name`
+
+	rows, err := r.DB.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var candidates []model.NameMatchCandidate
+	for rows.Next() {
+		var candidate model.NameMatchCandidate
+		if err := rows.Scan(&candidate.Name, &candidate.VarietyName, &candidate.Purchases, &candidate.ParsedNames); err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, candidate)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return candidates, nil
+}
+
 func (r *ReceiptRepo) GetUnconfirmedReceiptSummaries(ctx context.Context) ([]model.UnconfirmedReceiptSummary, error) {
 	query := `
-	SELECT purchase_date, retailer
+	SELECT id, purchase_date, purchase_time, receipt_number, retailer
 	FROM raw_receipts
-	WHERE NOT is_confirmed ORDER BY purchase_date DESC`
+	WHERE NOT is_confirmed ORDER BY purchase_date DESC, purchase_time DESC`
 
 	rows, err := r.DB.Query(ctx, query)
 	if err != nil {
@@ -153,7 +279,7 @@ func (r *ReceiptRepo) GetUnconfirmedReceiptSummaries(ctx context.Context) ([]mod
 	for rows.Next() {
 		var summary model.UnconfirmedReceiptSummary
 		var receiptDate *pgtype.Date
-		if err := rows.Scan(&receiptDate, &summary.Retailer); err != nil {
+		if err := rows.Scan(&summary.ID, &receiptDate, &summary.Time, &summary.ReceiptNumber, &summary.Retailer); err != nil {
 			return nil, err
 		}
 		summary.Date = receiptDate.Time.Format("2006-01-02")
@@ -163,49 +289,67 @@ func (r *ReceiptRepo) GetUnconfirmedReceiptSummaries(ctx context.Context) ([]mod
 	return summaries, nil
 }
 
-func (r *ReceiptRepo) GetUnconfirmedReceipt(ctx context.Context, retailer, date string) ([]model.PurchasedProductNew, error) {
+// GetUnconfirmedReceipt returns parsed products and the retailer of the receipt.
+func (r *ReceiptRepo) GetUnconfirmedReceipt(ctx context.Context, receiptID string) ([]model.PurchasedProductNew, string, error) {
 	query := `
-	SELECT parsed_products
+	SELECT parsed_products, retailer
 	FROM raw_receipts
-	WHERE retailer = $1 AND purchase_date = $2`
+	WHERE id = $1`
 
-	rows, err := r.DB.Query(ctx, query, retailer, date)
-	if err != nil {
-		return nil, err
+	var parsedProducts []model.PurchasedProductNew
+	var retailer string
+	err := r.DB.QueryRow(ctx, query, receiptID).Scan(&parsedProducts, &retailer)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, "", uerror.NewNotFound(fmt.Sprintf("receipt with id %q does not exist", receiptID), err)
 	}
-	defer rows.Close()
-
-	var daysParsedProducts []model.PurchasedProductNew
-	for rows.Next() {
-		var parsedProducts []model.PurchasedProductNew
-		if err := rows.Scan(&parsedProducts); err != nil {
-			return nil, err
-		}
-		daysParsedProducts = append(daysParsedProducts, parsedProducts...)
+	if err != nil {
+		return nil, "", err
 	}
 
-	return daysParsedProducts, nil
+	return parsedProducts, retailer, nil
 }
 
-func (r *ReceiptRepo) ConfirmReceipts(ctx context.Context, retailer, date string) error {
+func (r *ReceiptRepo) ConfirmReceipt(ctx context.Context, receiptID string) error {
 	query := `
 	UPDATE raw_receipts
 	SET is_confirmed = true
-	WHERE purchase_date = $1 AND retailer = $2`
+	WHERE id = $1`
 
-	if _, err := r.DB.Exec(ctx, query, date, retailer); err != nil {
+	if _, err := r.DB.Exec(ctx, query, receiptID); err != nil {
 		return err
 	}
 
 	return nil
 }
 
+// IsReceiptConfirmed reports whether products of the receipt were already confirmed.
+func (r *ReceiptRepo) IsReceiptConfirmed(ctx context.Context, receiptID string) (bool, error) {
+	query := `
+	SELECT is_confirmed
+	FROM raw_receipts
+	WHERE id = $1`
+
+	var isConfirmed bool
+	err := r.DB.QueryRow(ctx, query, receiptID).Scan(&isConfirmed)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, uerror.NewNotFound(fmt.Sprintf("receipt with id %q does not exist", receiptID), err)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return isConfirmed, nil
+}
+
+// GetLastReceiptDates returns the date of the latest receipt of every retailer. Deposit refund slips are left out,
+// as they are not purchases at a store.
 func (r *ReceiptRepo) GetLastReceiptDates(ctx context.Context) ([]model.LastReceiptDate, error) {
 	query := `
 	SELECT retailer, MAX(purchase_date)
 FROM raw_receipts
+WHERE retailer <> $1
 GROUP BY retailer`
-	rows, err := r.DB.Query(ctx, query)
+	rows, err := r.DB.Query(ctx, query, model.DepositRefundRetailer)
 	if err != nil {
 		return nil, err
 	}
@@ -226,14 +370,15 @@ GROUP BY retailer`
 	return lastConfirmedDates, nil
 }
 
-func (r *ReceiptRepo) GetProductsWithMissingInfo(ctx context.Context, dateFrom string) ([]model.ProductAndVarietyName, error) {
+func (r *ReceiptRepo) GetProductsWithMissingInfo(ctx context.Context, dateFrom string, filter model.ProductFilter) ([]model.ProductAndVarietyName, error) {
 	query := `
 	SELECT DISTINCT name, purchases.variety_name
 	FROM purchases 
 	LEFT JOIN nutritional_values_v2 ON purchases.variety_name = nutritional_values_v2.variety_name
 	JOIN products ON products.id = purchases.product_id
-	WHERE purchase_date > $1 AND (nutritional_values_v2.id is null OR purchases.unit = '')`
-	rows, err := r.DB.Query(ctx, query, dateFrom)
+	WHERE purchase_date > $1 AND ((nutritional_values_v2.id is null AND products.reference_food_id IS NULL) OR purchases.unit = '')
+		AND ` + ProductFilterCondition(2, 3)
+	rows, err := r.DB.Query(ctx, query, dateFrom, filter.CategoryID, filter.Tag)
 	if err != nil {
 		return nil, err
 	}
@@ -71,35 +71,50 @@ func (s *ContainerTestSuite) TestReceiptRepo_GetUnconfirmedReceipt() {
 	err := s.Container.Restore(ctx, postgres.WithSnapshotName("emptyTables"))
 	s.Require().NoError(err)
 	type args struct {
-		ctx      context.Context
-		retailer string
-		date     string
+		ctx context.Context
 	}
 	tests := []struct {
 		name       string
 		args       args
-		insertData func(db *pgxpool.Pool)
+		insertData func(db *pgxpool.Pool) string
 		want       []model.PurchasedProductNew
 		wantErr    bool
 	}{
 		{
 			name: "get_unconfirmed_receipt",
 			args: args{
-				ctx:      ctx,
-				retailer: "norfa",
-				date:     "2024-01-01",
+				ctx: ctx,
 			},
-			insertData: func(db *pgxpool.Pool) {
+			insertData: func(db *pgxpool.Pool) string {
 				r := NewReceiptRepo(db)
 
-				r.InsertRawReceipt(ctx, time.Date(2024, 01, 01, 0, 0, 0, 0, time.UTC), "receipt", "norfa", model.ReceiptProducts{
-					{
-						Name: "red apples",
+				r.InsertRawReceipt(ctx, model.RawReceipt{
+					Date:     time.Date(2024, 01, 01, 0, 0, 0, 0, time.UTC),
+					Time:     "09:15:00",
+					Retailer: "norfa",
+					Receipt:  "another receipt",
+					ParsedProducts: model.ReceiptProducts{
+						{
+							Name: "bread",
+						},
 					},
-					{
-						Name: "lentils",
+				})
+
+				id, _, _ := r.InsertRawReceipt(ctx, model.RawReceipt{
+					Date:     time.Date(2024, 01, 01, 0, 0, 0, 0, time.UTC),
+					Time:     "18:30:00",
+					Retailer: "norfa",
+					Receipt:  "receipt",
+					ParsedProducts: model.ReceiptProducts{
+						{
+							Name: "red apples",
+						},
+						{
+							Name: "lentils",
+						},
 					},
 				})
+				return id
 			},
 			want: []model.PurchasedProductNew{
 				{
@@ -110,6 +125,42 @@ func (s *ContainerTestSuite) TestReceiptRepo_GetUnconfirmedReceipt() {
 				},
 			},
 		},
+		{
+			name: "keep_parsed_products_of_confirmed_receipt_on_resubmit",
+			args: args{
+				ctx: ctx,
+			},
+			insertData: func(db *pgxpool.Pool) string {
+				r := NewReceiptRepo(db)
+
+				rawReceipt := model.RawReceipt{
+					Date:     time.Date(2024, 01, 01, 0, 0, 0, 0, time.UTC),
+					Time:     "18:30:00",
+					Retailer: "norfa",
+					Receipt:  "receipt",
+					ParsedProducts: model.ReceiptProducts{
+						{
+							Name: "red apples",
+						},
+					},
+				}
+				id, _, _ := r.InsertRawReceipt(ctx, rawReceipt)
+				r.ConfirmReceipt(ctx, id)
+
+				rawReceipt.ParsedProducts = model.ReceiptProducts{
+					{
+						Name: "green apples",
+					},
+				}
+				r.InsertRawReceipt(ctx, rawReceipt)
+				return id
+			},
+			want: []model.PurchasedProductNew{
+				{
+					Name: "red apples",
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		s.T().Run(tt.name, func(t *testing.T) {
@@ -120,13 +171,14 @@ func (s *ContainerTestSuite) TestReceiptRepo_GetUnconfirmedReceipt() {
 			require.NoError(t, err)
 			defer db.Close()
 
+			var receiptID string
 			if tt.insertData != nil {
-				tt.insertData(db)
+				receiptID = tt.insertData(db)
 			}
 
 			r := NewReceiptRepo(db)
 
-			got, err := r.GetUnconfirmedReceipt(tt.args.ctx, tt.args.retailer, tt.args.date)
+			got, _, err := r.GetUnconfirmedReceipt(tt.args.ctx, receiptID)
 			if tt.wantErr {
 				s.Require().Error(err)
 				return
@@ -136,3 +188,28 @@ func (s *ContainerTestSuite) TestReceiptRepo_GetUnconfirmedReceipt() {
 		})
 	}
 }
+
+func (s *ContainerTestSuite) TestReceiptRepo_GetLastReceiptDates() {
+	ctx := context.Background()
+
+	err := s.Container.Restore(ctx, postgres.WithSnapshotName("emptyTables"))
+	s.Require().NoError(err)
+
+	db, err := pgxpool.New(ctx, s.Container.MustConnectionString(ctx))
+	s.Require().NoError(err)
+	defer db.Close()
+
+	r := NewReceiptRepo(db)
+	for _, rawReceipt := range []model.RawReceipt{
+		{Date: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Retailer: "norfa", Receipt: "receipt"},
+		{Date: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), Retailer: model.DepositRefundRetailer, Receipt: "refund slip"},
+	} {
+		_, _, err := r.InsertRawReceipt(ctx, rawReceipt)
+		s.Require().NoError(err)
+	}
+
+	got, err := r.GetLastReceiptDates(ctx)
+	s.Require().NoError(err)
+	s.Require().Len(got, 1)
+	s.Require().Equal("norfa", got[0].Retailer)
+}
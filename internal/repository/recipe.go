@@ -300,6 +300,34 @@ func (r *RecipeRepo) GetRecipeNamesByIDs(ctx context.Context, recipeIDs []int) (
 	return recipeNames, nil
 }
 
+// GetRecipeDishMadeDates returns dish made dates of recipes that have one.
+func (r *RecipeRepo) GetRecipeDishMadeDates(ctx context.Context, recipeIDs []int) (map[int]time.Time, error) {
+	query := `
+	SELECT id, dish_made_date
+	FROM recipes
+	WHERE id = ANY($1) AND dish_made_date IS NOT NULL`
+
+	rows, err := r.DB.Query(ctx, query, recipeIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	dates := make(map[int]time.Time, len(recipeIDs))
+	for rows.Next() {
+		var id int
+		var date time.Time
+		if err := rows.Scan(&id, &date); err != nil {
+			return nil, err
+		}
+		dates[id] = date
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return dates, nil
+}
+
 func cloneRecipe(ctx context.Context, tx pgx.Tx, recipeID int, date string) (int, error) {
 	query := `INSERT INTO recipes (recipe_name, steps, notes, dish_made_date)
 SELECT recipe_name, steps, notes, $1
@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/SarunasBucius/nutri-price-server/internal/model"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ReportRepo struct {
+	DB *pgxpool.Pool
+}
+
+func NewReportRepo(db *pgxpool.Pool) *ReportRepo {
+	return &ReportRepo{DB: db}
+}
+
+// GetSpendingRows returns purchases of filtered products made between the dates, inclusive.
+func (r *ReportRepo) GetSpendingRows(ctx context.Context, from, to time.Time, filter model.ProductFilter) ([]model.SpendingRow, error) {
+	query := categoryRootsCTE + `
+	SELECT COALESCE(purchases.raw_receipt_id::TEXT, ''), purchases.purchase_date, purchases.retailer, products.name, purchases.variety_name,
+		COALESCE(category_roots.root_name, ''), purchases.price
+	FROM purchases
+	JOIN products ON products.id = purchases.product_id
+	LEFT JOIN category_roots ON category_roots.id = products.category_id
+	WHERE purchases.purchase_date BETWEEN $1 AND $2 AND ` + ProductFilterCondition(3, 4) + `
+	ORDER BY purchases.purchase_date, purchases.id`
+
+	rows, err := r.DB.Query(ctx, query, from, to, filter.CategoryID, filter.Tag)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var spendingRows []model.SpendingRow
+	for rows.Next() {
+		var row model.SpendingRow
+		if err := rows.Scan(&row.ReceiptID, &row.Date, &row.Retailer, &row.Product, &row.VarietyName, &row.Category, &row.Price); err != nil {
+			return nil, err
+		}
+		spendingRows = append(spendingRows, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return spendingRows, nil
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/SarunasBucius/nutri-price-server/internal/model"
+	"github.com/SarunasBucius/nutri-price-server/internal/utils/uerror"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type UnitConversionRepo struct {
+	DB *pgxpool.Pool
+}
+
+func NewUnitConversionRepo(db *pgxpool.Pool) *UnitConversionRepo {
+	return &UnitConversionRepo{DB: db}
+}
+
+func (r *UnitConversionRepo) GetUnitConversions(ctx context.Context) ([]model.UnitConversion, error) {
+	query := `
+	SELECT product, density, piece_weight
+	FROM unit_conversions
+	ORDER BY product`
+
+	rows, err := r.DB.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var conversions []model.UnitConversion
+	for rows.Next() {
+		var conversion model.UnitConversion
+		if err := rows.Scan(&conversion.Product, &conversion.Density, &conversion.PieceWeight); err != nil {
+			return nil, err
+		}
+		conversions = append(conversions, conversion)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return conversions, nil
+}
+
+func (r *UnitConversionRepo) GetUnitConversionsByProducts(ctx context.Context, products []string) (map[string]model.UnitConversion, error) {
+	query := `
+	SELECT product, density, piece_weight
+	FROM unit_conversions
+	WHERE product = ANY($1)`
+
+	rows, err := r.DB.Query(ctx, query, products)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	conversions := make(map[string]model.UnitConversion)
+	for rows.Next() {
+		var conversion model.UnitConversion
+		if err := rows.Scan(&conversion.Product, &conversion.Density, &conversion.PieceWeight); err != nil {
+			return nil, err
+		}
+		conversions[conversion.Product] = conversion
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return conversions, nil
+}
+
+func (r *UnitConversionRepo) UpsertUnitConversion(ctx context.Context, conversion model.UnitConversion) error {
+	query := `
+	INSERT INTO unit_conversions (product, density, piece_weight)
+	VALUES ($1, $2, $3)
+	ON CONFLICT (product) DO UPDATE
+	SET density = EXCLUDED.density, piece_weight = EXCLUDED.piece_weight`
+
+	if _, err := r.DB.Exec(ctx, query, conversion.Product, conversion.Density, conversion.PieceWeight); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *UnitConversionRepo) DeleteUnitConversion(ctx context.Context, product string) error {
+	result, err := r.DB.Exec(ctx, `DELETE FROM unit_conversions WHERE product = $1`, product)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return uerror.NewNotFound(fmt.Sprintf("unit conversion of product %q does not exist", product), nil)
+	}
+
+	return nil
+}
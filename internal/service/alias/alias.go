@@ -0,0 +1,88 @@
+package alias
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/SarunasBucius/nutri-price-server/internal/model"
+	"github.com/SarunasBucius/nutri-price-server/internal/utils/uerror"
+)
+
+type Service struct {
+	AliasRepo IAliasRepository
+}
+
+func NewAliasService(aliasRepo IAliasRepository) *Service {
+	return &Service{
+		AliasRepo: aliasRepo,
+	}
+}
+
+type IAliasRepository interface {
+	GetProductAliases(ctx context.Context, search string) ([]model.ProductAlias, error)
+	GetProductAliasesByIDs(ctx context.Context, aliasIDs []string) ([]model.ProductAlias, error)
+	UpdateProductAliases(ctx context.Context, aliasIDs []string, alias model.ProductAndVarietyName) ([]string, error)
+	DeleteProductAlias(ctx context.Context, aliasID string) error
+	GetUnconfirmedReceiptIDsByParsedNames(ctx context.Context, parsedNames []string) ([]string, error)
+}
+
+func (s *Service) GetProductAliases(ctx context.Context, search string) ([]model.ProductAlias, error) {
+	aliases, err := s.AliasRepo.GetProductAliases(ctx, strings.TrimSpace(search))
+	if err != nil {
+		return nil, fmt.Errorf("get product aliases: %w", err)
+	}
+	return aliases, nil
+}
+
+func (s *Service) UpdateProductAlias(ctx context.Context, aliasID string, alias model.ProductAndVarietyName) (model.ProductAliasesUpdate, error) {
+	return s.ReassignProductAliases(ctx, []string{aliasID}, alias)
+}
+
+// ReassignProductAliases maps all given aliases to the same product and variety.
+// Variety defaults to the product name, like for products created without a variety.
+func (s *Service) ReassignProductAliases(ctx context.Context, aliasIDs []string, alias model.ProductAndVarietyName) (model.ProductAliasesUpdate, error) {
+	alias.Name = strings.TrimSpace(alias.Name)
+	alias.VarietyName = strings.TrimSpace(alias.VarietyName)
+	if alias.Name == "" {
+		return model.ProductAliasesUpdate{}, uerror.NewBadRequest("missing product name", nil)
+	}
+	if alias.VarietyName == "" {
+		alias.VarietyName = alias.Name
+	}
+
+	aliasIDs = slices.Compact(slices.Sorted(slices.Values(aliasIDs)))
+	if len(aliasIDs) == 0 || aliasIDs[0] == "" {
+		return model.ProductAliasesUpdate{}, uerror.NewBadRequest("missing alias ids", nil)
+	}
+
+	parsedNames, err := s.AliasRepo.UpdateProductAliases(ctx, aliasIDs, alias)
+	if err != nil {
+		return model.ProductAliasesUpdate{}, fmt.Errorf("update product aliases: %w", err)
+	}
+
+	aliases, err := s.AliasRepo.GetProductAliasesByIDs(ctx, aliasIDs)
+	if err != nil {
+		return model.ProductAliasesUpdate{}, fmt.Errorf("get product aliases by ids: %w", err)
+	}
+
+	receiptIDs, err := s.AliasRepo.GetUnconfirmedReceiptIDsByParsedNames(ctx, parsedNames)
+	if err != nil {
+		return model.ProductAliasesUpdate{}, fmt.Errorf("get unconfirmed receipt ids by parsed names: %w", err)
+	}
+	if receiptIDs == nil {
+		receiptIDs = []string{}
+	}
+
+	return model.ProductAliasesUpdate{Aliases: aliases, UnconfirmedReceiptIDs: receiptIDs}, nil
+}
+
+// DeleteProductAlias removes the alias. The parsed name is stored again without a product
+// when it appears on a new receipt.
+func (s *Service) DeleteProductAlias(ctx context.Context, aliasID string) error {
+	if err := s.AliasRepo.DeleteProductAlias(ctx, aliasID); err != nil {
+		return fmt.Errorf("delete product alias: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,185 @@
+package budget
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/SarunasBucius/nutri-price-server/internal/model"
+	"github.com/SarunasBucius/nutri-price-server/internal/utils/uerror"
+	"github.com/SarunasBucius/nutri-price-server/internal/utils/umath"
+)
+
+const (
+	monthLayout           = "2006-01"
+	defaultAlertThreshold = 0.9
+)
+
+type Service struct {
+	BudgetRepo IBudgetRepository
+}
+
+func NewBudgetService(budgetRepo IBudgetRepository) *Service {
+	return &Service{
+		BudgetRepo: budgetRepo,
+	}
+}
+
+type IBudgetRepository interface {
+	UpsertBudget(ctx context.Context, month time.Time, budget model.Budget) (string, error)
+	DeleteBudget(ctx context.Context, budgetID string) error
+	GetBudgetStatuses(ctx context.Context, month time.Time) ([]model.BudgetStatus, error)
+	InsertBudgetAlerts(ctx context.Context, alerts []model.BudgetAlert) ([]model.BudgetAlert, error)
+	GetBudgetAlerts(ctx context.Context, month time.Time) ([]model.BudgetAlert, error)
+}
+
+// UpsertBudget sets the budget of the month, category and retailer. The alert threshold defaults to 90%.
+// A budget that already alerted alerts again once its amount or threshold changes.
+func (s *Service) UpsertBudget(ctx context.Context, budget model.Budget) (model.Budget, error) {
+	month, err := time.Parse(monthLayout, budget.Month)
+	if err != nil {
+		return model.Budget{}, uerror.NewBadRequest("invalid month, expected YYYY-MM", err)
+	}
+	budget.CategoryID = strings.TrimSpace(budget.CategoryID)
+	budget.Retailer = strings.ToLower(strings.TrimSpace(budget.Retailer))
+	if budget.CategoryID != "" && budget.Retailer != "" {
+		return model.Budget{}, uerror.NewBadRequest("budget can be limited either to a category or to a retailer", nil)
+	}
+	if budget.CategoryID != "" {
+		if _, err := strconv.Atoi(budget.CategoryID); err != nil {
+			return model.Budget{}, uerror.NewBadRequest("invalid categoryId", err)
+		}
+	}
+	if budget.Amount <= 0 {
+		return model.Budget{}, uerror.NewBadRequest("amount must be positive", nil)
+	}
+	if budget.AlertThreshold < 0 || budget.AlertThreshold > 1 {
+		return model.Budget{}, uerror.NewBadRequest("alertThreshold must be between 0 and 1", nil)
+	}
+	if budget.AlertThreshold == 0 {
+		budget.AlertThreshold = defaultAlertThreshold
+	}
+
+	budget.ID, err = s.BudgetRepo.UpsertBudget(ctx, month, budget)
+	if err != nil {
+		return model.Budget{}, fmt.Errorf("upsert budget: %w", err)
+	}
+	return budget, nil
+}
+
+func (s *Service) DeleteBudget(ctx context.Context, budgetID string) error {
+	if err := s.BudgetRepo.DeleteBudget(ctx, budgetID); err != nil {
+		return fmt.Errorf("delete budget: %w", err)
+	}
+	return nil
+}
+
+// GetBudgetStatuses compares spending with budgets of the month, the current month when it is empty.
+func (s *Service) GetBudgetStatuses(ctx context.Context, month string) ([]model.BudgetStatus, error) {
+	monthStart, err := parseMonth(month)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses, err := s.BudgetRepo.GetBudgetStatuses(ctx, monthStart)
+	if err != nil {
+		return nil, fmt.Errorf("get budget statuses: %w", err)
+	}
+
+	now := time.Now()
+	for i := range statuses {
+		statuses[i] = projectSpending(statuses[i], monthStart, now)
+	}
+	return statuses, nil
+}
+
+// GetBudgetAlerts returns alerts of budgets of the month, the current month when it is empty.
+func (s *Service) GetBudgetAlerts(ctx context.Context, month string) ([]model.BudgetAlert, error) {
+	monthStart, err := parseMonth(month)
+	if err != nil {
+		return nil, err
+	}
+
+	alerts, err := s.BudgetRepo.GetBudgetAlerts(ctx, monthStart)
+	if err != nil {
+		return nil, fmt.Errorf("get budget alerts: %w", err)
+	}
+	return alerts, nil
+}
+
+// CheckBudgetAlerts produces alerts of budgets of the receipt month that spending has pushed past their threshold.
+// A budget alerts only once per amount and threshold, so the alert is attributed to the receipt that crossed the threshold.
+func (s *Service) CheckBudgetAlerts(ctx context.Context, receiptID string, receiptDate time.Time) ([]model.BudgetAlert, error) {
+	monthStart := time.Date(receiptDate.Year(), receiptDate.Month(), 1, 0, 0, 0, 0, time.UTC)
+	statuses, err := s.BudgetRepo.GetBudgetStatuses(ctx, monthStart)
+	if err != nil {
+		return nil, fmt.Errorf("get budget statuses: %w", err)
+	}
+
+	var alerts []model.BudgetAlert
+	for _, status := range statuses {
+		if status.Spent < status.Amount*status.AlertThreshold {
+			continue
+		}
+		alerts = append(alerts, model.BudgetAlert{
+			BudgetID:  status.ID,
+			ReceiptID: receiptID,
+			Month:     status.Month,
+			Amount:    status.Amount,
+			Threshold: status.AlertThreshold,
+			Spent:     status.Spent,
+		})
+	}
+	if len(alerts) == 0 {
+		return nil, nil
+	}
+
+	inserted, err := s.BudgetRepo.InsertBudgetAlerts(ctx, alerts)
+	if err != nil {
+		return nil, fmt.Errorf("insert budget alerts: %w", err)
+	}
+	for _, alert := range inserted {
+		slog.WarnContext(ctx, "budget threshold exceeded", "budgetID", alert.BudgetID, "month", alert.Month,
+			"amount", alert.Amount, "spent", alert.Spent, "receiptID", alert.ReceiptID)
+	}
+	return inserted, nil
+}
+
+// projectSpending projects spending to the end of the month at the daily run rate of the elapsed days.
+func projectSpending(status model.BudgetStatus, monthStart, now time.Time) model.BudgetStatus {
+	daysInMonth := monthStart.AddDate(0, 1, -1).Day()
+	var elapsedDays int
+	switch {
+	case now.Before(monthStart):
+	case now.Before(monthStart.AddDate(0, 1, 0)):
+		elapsedDays = now.Day()
+	default:
+		elapsedDays = daysInMonth
+	}
+
+	status.Projected = status.Spent
+	if elapsedDays > 0 {
+		status.DailyRunRate = umath.RoundFloat(status.Spent/float64(elapsedDays), 2)
+		status.Projected = umath.RoundFloat(status.Spent/float64(elapsedDays)*float64(daysInMonth), 2)
+	}
+	status.Remaining = umath.RoundFloat(status.Amount-status.Spent, 2)
+	status.SpentShare = umath.RoundFloat(status.Spent/status.Amount, 4)
+	status.ProjectedOverspend = status.Projected > status.Amount
+	return status
+}
+
+func parseMonth(month string) (time.Time, error) {
+	if month == "" {
+		now := time.Now()
+		return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC), nil
+	}
+
+	monthStart, err := time.Parse(monthLayout, month)
+	if err != nil {
+		return time.Time{}, uerror.NewBadRequest("invalid month, expected YYYY-MM", err)
+	}
+	return monthStart, nil
+}
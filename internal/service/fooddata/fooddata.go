@@ -0,0 +1,231 @@
+package fooddata
+
+import (
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/SarunasBucius/nutri-price-server/internal/model"
+	"github.com/SarunasBucius/nutri-price-server/internal/service/fooddata/fooddatacentral"
+	"github.com/SarunasBucius/nutri-price-server/internal/service/fooddata/openfoodfacts"
+	"github.com/SarunasBucius/nutri-price-server/internal/utils/uerror"
+)
+
+const (
+	// importBatchSize is the number of food facts stored at a time, as exports have millions of products.
+	importBatchSize    = 1000
+	defaultSearchLimit = 20
+	maxSearchLimit     = 100
+)
+
+type Service struct {
+	FoodFactRepo IFoodFactRepository
+	NutrientRepo INutrientRepository
+}
+
+func NewFoodDataService(foodFactRepo IFoodFactRepository, nutrientRepo INutrientRepository) *Service {
+	return &Service{FoodFactRepo: foodFactRepo, NutrientRepo: nutrientRepo}
+}
+
+type IFoodFactRepository interface {
+	UpsertFoodFacts(ctx context.Context, facts []model.FoodFact) error
+	SearchFoodFacts(ctx context.Context, search, source string, limit int) ([]model.FoodFact, error)
+	ApplyFoodFact(ctx context.Context, foodFactID int, productID, varietyName string) (string, error)
+	GetFoodFact(ctx context.Context, foodFactID int) (model.FoodFact, error)
+	SetProductReferenceFood(ctx context.Context, productID int, foodFactID *int) error
+}
+
+type INutrientRepository interface {
+	GetNutrients(ctx context.Context) ([]model.Nutrient, error)
+}
+
+// ImportOpenFoodFacts stores products of an Open Food Facts export in the JSONL or CSV format.
+// Products imported before are updated.
+func (s *Service) ImportOpenFoodFacts(ctx context.Context, export io.Reader, format string) (model.FoodFactImportReport, error) {
+	var read func(io.Reader, []model.Nutrient, func(model.FoodFact) error) (int, error)
+	switch format {
+	case model.FoodFactFormatJSONL:
+		read = openfoodfacts.ReadJSONL
+	case model.FoodFactFormatCSV:
+		read = openfoodfacts.ReadCSV
+	default:
+		return model.FoodFactImportReport{}, uerror.NewBadRequest(fmt.Sprintf("unknown export format %q", format), nil)
+	}
+
+	return s.importFoodFacts(ctx, model.FoodSourceOpenFoodFacts, func(catalogue []model.Nutrient, handle func(model.FoodFact) error) (int, error) {
+		return read(export, catalogue, handle)
+	})
+}
+
+// ImportFoodDataCentral stores generic foods of a FoodData Central CSV release, such as Foundation or SR Legacy foods.
+// Foods imported before are updated.
+func (s *Service) ImportFoodDataCentral(ctx context.Context, release fs.FS) (model.FoodFactImportReport, error) {
+	return s.importFoodFacts(ctx, model.FoodSourceFoodDataCentral, func(catalogue []model.Nutrient, handle func(model.FoodFact) error) (int, error) {
+		return fooddatacentral.Read(release, catalogue, handle)
+	})
+}
+
+// importFoodFacts stores food facts read from the source in batches.
+// read calls handle with every food fact, with amounts of the catalogue nutrients, and returns the number of skipped records.
+func (s *Service) importFoodFacts(ctx context.Context, source string, read func(catalogue []model.Nutrient, handle func(model.FoodFact) error) (int, error)) (model.FoodFactImportReport, error) {
+	report := model.FoodFactImportReport{Source: source}
+	catalogue, err := s.NutrientRepo.GetNutrients(ctx)
+	if err != nil {
+		return report, fmt.Errorf("get nutrients: %w", err)
+	}
+
+	batch := make([]model.FoodFact, 0, importBatchSize)
+	flush := func() error {
+		if err := s.FoodFactRepo.UpsertFoodFacts(ctx, batch); err != nil {
+			return fmt.Errorf("upsert food facts: %w", err)
+		}
+		report.Imported += len(batch)
+		batch = batch[:0]
+		return nil
+	}
+
+	skipped, err := read(catalogue, func(fact model.FoodFact) error {
+		batch = append(batch, fact)
+		if len(batch) < importBatchSize {
+			return nil
+		}
+		return flush()
+	})
+	report.Skipped = skipped
+	if err != nil {
+		return report, fmt.Errorf("read %s: %w", source, err)
+	}
+	if err := flush(); err != nil {
+		return report, err
+	}
+	return report, nil
+}
+
+// SearchFoodFacts finds food facts by barcode or by words of their name and brands.
+func (s *Service) SearchFoodFacts(ctx context.Context, search, source string, limit int) ([]model.FoodFact, error) {
+	if strings.TrimSpace(search) == "" {
+		return nil, uerror.NewBadRequest("search must not be empty", nil)
+	}
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+
+	facts, err := s.FoodFactRepo.SearchFoodFacts(ctx, search, source, min(limit, maxSearchLimit))
+	if err != nil {
+		return nil, fmt.Errorf("search food facts: %w", err)
+	}
+	return facts, nil
+}
+
+// ApplyFoodFact fills the nutritional value of the product variety from the food fact.
+func (s *Service) ApplyFoodFact(ctx context.Context, foodFactID, productID, varietyName string) (string, error) {
+	if varietyName == "" {
+		return "", uerror.NewBadRequest("variety name is required", nil)
+	}
+	id, err := parseFoodFactID(foodFactID)
+	if err != nil {
+		return "", err
+	}
+
+	nvID, err := s.FoodFactRepo.ApplyFoodFact(ctx, id, productID, varietyName)
+	if err != nil {
+		return "", fmt.Errorf("apply food fact: %w", err)
+	}
+	return nvID, nil
+}
+
+func (s *Service) GetFoodFact(ctx context.Context, foodFactID string) (model.FoodFact, error) {
+	id, err := parseFoodFactID(foodFactID)
+	if err != nil {
+		return model.FoodFact{}, err
+	}
+
+	fact, err := s.FoodFactRepo.GetFoodFact(ctx, id)
+	if err != nil {
+		return model.FoodFact{}, fmt.Errorf("get food fact: %w", err)
+	}
+	return fact, nil
+}
+
+// SetProductReferenceFood sets the food fact that is the nutritional value of product varieties without one of their own.
+// The reference food is removed if foodFactID is empty.
+func (s *Service) SetProductReferenceFood(ctx context.Context, productID, foodFactID string) error {
+	id, err := strconv.Atoi(productID)
+	if err != nil {
+		return uerror.NewBadRequest(fmt.Sprintf("invalid product id %q", productID), err)
+	}
+
+	var referenceFoodID *int
+	if foodFactID != "" {
+		fact, err := s.GetFoodFact(ctx, foodFactID)
+		if err != nil {
+			return err
+		}
+		referenceFoodID = &fact.ID
+	}
+
+	if err := s.FoodFactRepo.SetProductReferenceFood(ctx, id, referenceFoodID); err != nil {
+		return fmt.Errorf("set product reference food: %w", err)
+	}
+	return nil
+}
+
+func parseFoodFactID(foodFactID string) (int, error) {
+	id, err := strconv.Atoi(foodFactID)
+	if err != nil {
+		return 0, uerror.NewBadRequest(fmt.Sprintf("invalid food fact id %q", foodFactID), err)
+	}
+	return id, nil
+}
+
+// OpenExport opens a food database export file, decompressing it if it is gzipped.
+// The format is detected from the file extension, e.g. products.jsonl.gz is a JSONL export,
+// and is empty for other extensions.
+func OpenExport(path string) (io.ReadCloser, string, error) {
+	name := strings.ToLower(filepath.Base(path))
+	gzipped := strings.HasSuffix(name, ".gz")
+	name = strings.TrimSuffix(name, ".gz")
+
+	var format string
+	switch filepath.Ext(name) {
+	case ".jsonl", ".json":
+		format = model.FoodFactFormatJSONL
+	case ".csv", ".tsv":
+		format = model.FoodFactFormatCSV
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, "", fmt.Errorf("open %s: %w", path, err)
+	}
+	if !gzipped {
+		return file, format, nil
+	}
+
+	reader, err := gzip.NewReader(file)
+	if err != nil {
+		file.Close()
+		return nil, "", fmt.Errorf("read gzip %s: %w", path, err)
+	}
+	return gzipFile{Reader: reader, file: file}, format, nil
+}
+
+// gzipFile closes both the gzip reader and the underlying file.
+type gzipFile struct {
+	*gzip.Reader
+	file *os.File
+}
+
+func (g gzipFile) Close() error {
+	if err := g.Reader.Close(); err != nil {
+		g.file.Close()
+		return err
+	}
+	return g.file.Close()
+}
@@ -0,0 +1,295 @@
+package fooddatacentral
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/SarunasBucius/nutri-price-server/internal/model"
+	"github.com/SarunasBucius/nutri-price-server/internal/service/fooddata/nutrition"
+)
+
+// Files of a FoodData Central CSV release used by the import.
+const (
+	foodFile         = "food.csv"
+	nutrientFile     = "nutrient.csv"
+	foodNutrientFile = "food_nutrient.csv"
+)
+
+const byteOrderMark = "\ufeff"
+
+// dataTypes are the generic food data types imported. Other types, such as branded or sample foods, are skipped.
+var dataTypes = []string{"foundation_food", "sr_legacy_food"}
+
+// fields maps nutritional value fields to FoodData Central nutrient ids.
+// Fields with several nutrient ids use the first one a food has.
+var fields = map[nutrition.Field][]int{
+	nutrition.EnergyKcal:     {1008, 2048, 2047},
+	nutrition.EnergyKJ:       {1062},
+	nutrition.Fat:            {1004},
+	nutrition.SaturatedFat:   {1258},
+	nutrition.Carbohydrate:   {1005},
+	nutrition.Sugars:         {2000, 1063},
+	nutrition.Fibre:          {1079},
+	nutrition.SolubleFibre:   {1082},
+	nutrition.InsolubleFibre: {1084},
+	nutrition.Protein:        {1003},
+	nutrition.Sodium:         {1093},
+}
+
+// nutrients maps catalogue nutrient codes to FoodData Central nutrient ids.
+// Only nutrients of the catalogue passed to Read are imported.
+var nutrients = map[string]int{
+	"vitamin_a":        1106,
+	"vitamin_d":        1114,
+	"vitamin_e":        1109,
+	"vitamin_k":        1185,
+	"vitamin_c":        1162,
+	"thiamin":          1165,
+	"riboflavin":       1166,
+	"niacin":           1167,
+	"vitamin_b6":       1175,
+	"folate":           1177,
+	"vitamin_b12":      1178,
+	"biotin":           1176,
+	"pantothenic_acid": 1170,
+	"potassium":        1092,
+	"chloride":         1088,
+	"calcium":          1087,
+	"phosphorus":       1091,
+	"magnesium":        1090,
+	"iron":             1089,
+	"zinc":             1095,
+	"copper":           1098,
+	"manganese":        1101,
+	"fluoride":         1099,
+	"selenium":         1103,
+	"chromium":         1096,
+	"molybdenum":       1102,
+	"iodine":           1100,
+}
+
+// food is a generic food with amounts per 100 g by nutrient id.
+type food struct {
+	id          string
+	description string
+	amounts     map[int]float64
+}
+
+// Read reads generic foods of a FoodData Central CSV release and calls handle with every food that has
+// a nutritional value, in the order of the food file. Amounts of the catalogue nutrients are added to the nutritional values. The release files are looked up by name anywhere
+// in the file system, so both an extracted release directory and the release zip can be read.
+// Returns the number of skipped generic foods.
+func Read(release fs.FS, catalogue []model.Nutrient, handle func(model.FoodFact) error) (int, error) {
+	paths, err := findFiles(release, foodFile, nutrientFile, foodNutrientFile)
+	if err != nil {
+		return 0, err
+	}
+
+	units, err := readNutrientUnits(release, paths[nutrientFile])
+	if err != nil {
+		return 0, fmt.Errorf("read %s: %w", nutrientFile, err)
+	}
+	foods, err := readFoods(release, paths[foodFile])
+	if err != nil {
+		return 0, fmt.Errorf("read %s: %w", foodFile, err)
+	}
+	if err := readFoodNutrients(release, paths[foodNutrientFile], foods); err != nil {
+		return 0, fmt.Errorf("read %s: %w", foodNutrientFile, err)
+	}
+
+	var skipped int
+	for _, f := range foods.ordered {
+		fact, ok := toFoodFact(*f, units, catalogue)
+		if !ok {
+			skipped++
+			continue
+		}
+		if err := handle(fact); err != nil {
+			return skipped, err
+		}
+	}
+	return skipped, nil
+}
+
+func findFiles(release fs.FS, names ...string) (map[string]string, error) {
+	paths := make(map[string]string, len(names))
+	err := fs.WalkDir(release, ".", func(p string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		name := path.Base(p)
+		if _, found := paths[name]; !entry.IsDir() && !found {
+			paths[name] = p
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("find release files: %w", err)
+	}
+
+	for _, name := range names {
+		if _, ok := paths[name]; !ok {
+			return nil, fmt.Errorf("release has no %s", name)
+		}
+	}
+	return paths, nil
+}
+
+// readNutrientUnits returns lowercase nutrient units by nutrient id.
+func readNutrientUnits(release fs.FS, path string) (map[int]string, error) {
+	units := make(map[int]string)
+	err := readCSV(release, path, func(field func(string) string) {
+		id, err := strconv.Atoi(field("id"))
+		if err != nil {
+			return
+		}
+		units[id] = strings.ToLower(field("unit_name"))
+	})
+	return units, err
+}
+
+type foods struct {
+	byID    map[string]*food
+	ordered []*food
+}
+
+func readFoods(release fs.FS, path string) (foods, error) {
+	result := foods{byID: make(map[string]*food)}
+	err := readCSV(release, path, func(field func(string) string) {
+		id, description := field("fdc_id"), field("description")
+		if !slices.Contains(dataTypes, field("data_type")) || id == "" || description == "" {
+			return
+		}
+		f := &food{id: id, description: description, amounts: make(map[int]float64)}
+		result.byID[id] = f
+		result.ordered = append(result.ordered, f)
+	})
+	return result, err
+}
+
+// readFoodNutrients sets amounts of nutrients used by the import to the foods.
+func readFoodNutrients(release fs.FS, path string, foods foods) error {
+	return readCSV(release, path, func(field func(string) string) {
+		f, ok := foods.byID[field("fdc_id")]
+		if !ok {
+			return
+		}
+		id, err := strconv.Atoi(field("nutrient_id"))
+		if err != nil || !isImportedNutrient(id) {
+			return
+		}
+		amount, err := strconv.ParseFloat(field("amount"), 64)
+		if err != nil || amount < 0 {
+			return
+		}
+		f.amounts[id] = amount
+	})
+}
+
+func isImportedNutrient(id int) bool {
+	for _, ids := range fields {
+		if slices.Contains(ids, id) {
+			return true
+		}
+	}
+	for _, nutrientID := range nutrients {
+		if nutrientID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// readCSV calls handle with every record of the file. Records are read by column name.
+func readCSV(release fs.FS, path string, handle func(field func(column string) string)) error {
+	file, err := release.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	// Release files may start with a byte order mark, which breaks parsing of the quoted header.
+	buffered := bufio.NewReader(file)
+	if bom, err := buffered.Peek(len(byteOrderMark)); err == nil && string(bom) == byteOrderMark {
+		buffered.Discard(len(byteOrderMark))
+	}
+
+	reader := csv.NewReader(buffered)
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return fmt.Errorf("read header: %w", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, column := range header {
+		columns[column] = i
+	}
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		handle(func(column string) string {
+			i, ok := columns[column]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		})
+	}
+}
+
+// toFoodFact converts the food amounts to a nutritional value per 100 g.
+// Returns false if the food has none of the macronutrients or an implausible nutritional value.
+func toFoodFact(f food, units map[int]string, catalogue []model.Nutrient) (model.FoodFact, bool) {
+	nv, ok := nutrition.NutritionalValue(catalogue, func(field nutrition.Field) (float64, bool) {
+		for _, id := range fields[field] {
+			amount, ok := f.amounts[id]
+			if !ok {
+				continue
+			}
+			if field == nutrition.EnergyKcal || field == nutrition.EnergyKJ {
+				return amount, true
+			}
+			if grams, known := nutrition.ToGrams(amount, units[id]); known {
+				return grams, true
+			}
+		}
+		return 0, false
+	}, func(code string) (float64, bool) {
+		id, ok := nutrients[code]
+		if !ok {
+			return 0, false
+		}
+		amount, ok := f.amounts[id]
+		if !ok {
+			return 0, false
+		}
+		return nutrition.ToGrams(amount, units[id])
+	})
+	if !ok {
+		return model.FoodFact{}, false
+	}
+
+	return model.FoodFact{
+		Source:           model.FoodSourceFoodDataCentral,
+		SourceID:         f.id,
+		Name:             f.description,
+		Unit:             model.Grams,
+		NutritionalValue: nv,
+	}, true
+}
@@ -0,0 +1,86 @@
+package fooddatacentral
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/SarunasBucius/nutri-price-server/internal/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRead(t *testing.T) {
+	release := fstest.MapFS{
+		"FoodData_Central_csv/food.csv": {Data: []byte("\ufeff" + `"fdc_id","data_type","description","food_category_id","publication_date"
+"170393","sr_legacy_food","Carrots, raw","11","2019-04-01"
+"2346403","foundation_food","Flour, wheat, all-purpose, enriched","20","2022-10-28"
+"2346404","sample_food","Flour, sample","20","2022-10-28"
+"170000","sr_legacy_food","Water, bottled","14","2019-04-01"
+`)},
+		"FoodData_Central_csv/nutrient.csv": {Data: []byte(`"id","name","unit_name","nutrient_nbr","rank"
+"1003","Protein","G","203","600"
+"1004","Total lipid (fat)","G","204","800"
+"1005","Carbohydrate, by difference","G","205","1110"
+"1008","Energy","KCAL","208","300"
+"2047","Energy (Atwater General Factors)","KCAL","957","280"
+"1079","Fiber, total dietary","G","291","1200"
+"1063","Sugars, Total","G","269.3","1500"
+"2000","Total Sugars","G","269","1510"
+"1093","Sodium, Na","MG","307","5800"
+"1106","Vitamin A, RAE","UG","320","7420"
+"1162","Vitamin C, total ascorbic acid","MG","401","6300"
+"1089","Iron, Fe","MG","303","5400"
+`)},
+		"FoodData_Central_csv/food_nutrient.csv": {Data: []byte(`"id","fdc_id","nutrient_id","amount","data_points"
+"1","170393","1008","41","0"
+"2","170393","1003","0.93","0"
+"3","170393","1004","0.24","0"
+"4","170393","1005","9.58","0"
+"5","170393","2000","4.74","0"
+"6","170393","1079","2.8","0"
+"7","170393","1093","69","0"
+"8","170393","1106","835","0"
+"9","170393","1162","5.9","0"
+"10","2346403","2047","366","0"
+"11","2346403","1063","0.3","0"
+"12","2346403","1003","10.9","0"
+"13","2346403","1089","4.64","0"
+"14","2346404","1003","10","0"
+`)},
+	}
+
+	catalogue := []model.Nutrient{{Code: "vitamin_a", Unit: "µg"}, {Code: "vitamin_c", Unit: "mg"}, {Code: "iron", Unit: "mg"}}
+
+	var got []model.FoodFact
+	skipped, err := Read(release, catalogue, func(fact model.FoodFact) error {
+		got = append(got, fact)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, skipped)
+	require.Equal(t, []model.FoodFact{
+		{
+			Source:   model.FoodSourceFoodDataCentral,
+			SourceID: "170393",
+			Name:     "Carrots, raw",
+			Unit:     model.Grams,
+			NutritionalValue: model.NutritionalValue{
+				EnergyValueKCAL: 41, Fat: 0.24, Carbohydrate: 9.58, CarbohydrateSugars: 4.74, Fibre: 2.8, Protein: 0.93, Salt: 0.173,
+				Nutrients: map[string]float64{"vitamin_a": 835, "vitamin_c": 5.9},
+			},
+		},
+		{
+			Source:   model.FoodSourceFoodDataCentral,
+			SourceID: "2346403",
+			Name:     "Flour, wheat, all-purpose, enriched",
+			Unit:     model.Grams,
+			NutritionalValue: model.NutritionalValue{
+				EnergyValueKCAL: 366, CarbohydrateSugars: 0.3, Protein: 10.9, Nutrients: map[string]float64{"iron": 4.64},
+			},
+		},
+	}, got)
+}
+
+func TestRead_missingFile(t *testing.T) {
+	_, err := Read(fstest.MapFS{"food.csv": {Data: []byte("fdc_id\n")}}, nil, func(model.FoodFact) error { return nil })
+	require.Error(t, err)
+}
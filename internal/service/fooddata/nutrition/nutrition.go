@@ -0,0 +1,119 @@
+package nutrition
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/SarunasBucius/nutri-price-server/internal/model"
+	"github.com/SarunasBucius/nutri-price-server/internal/utils/umath"
+)
+
+const (
+	// kilojoulesPerKilocalorie converts energy given only in kJ.
+	kilojoulesPerKilocalorie = 4.184
+	// saltPerSodium converts sodium to salt when salt is not given.
+	saltPerSodium = 2.5
+	// maxKilocalories is a little above the energy of pure fat, no food has more per 100 g.
+	maxKilocalories = 900
+	// maxGrams is the most of a macronutrient 100 g of food can contain.
+	maxGrams = 100
+)
+
+// Field is a field of a nutritional value, as food sources name them differently.
+type Field int
+
+const (
+	EnergyKcal Field = iota
+	EnergyKJ
+	Fat
+	SaturatedFat
+	Carbohydrate
+	Sugars
+	Fibre
+	SolubleFibre
+	InsolubleFibre
+	Protein
+	Salt
+	Sodium
+)
+
+// gramsPerUnit converts amounts in source and catalogue units to grams.
+var gramsPerUnit = map[string]float64{"g": 1, "mg": 0.001, "ug": 0.000001, "µg": 0.000001}
+
+// ToGrams converts an amount in g, mg or µg, in any case, to grams. Returns false for other units.
+func ToGrams(amount float64, unit string) (float64, bool) {
+	perUnit, ok := gramsPerUnit[strings.ToLower(unit)]
+	return amount * perUnit, ok
+}
+
+// NutritionalValue builds a nutritional value per 100 g or ml. amount returns amounts of the fields,
+// in kcal and kJ for energy and in grams otherwise. nutrientGrams returns amounts of catalogue nutrients
+// in grams by nutrient code, they are converted to the catalogue units. Energy falls back to kJ and salt to sodium.
+// Returns false if neither energy nor any macronutrient is found, or if the nutritional value is implausible,
+// as source values may be typos or per package amounts.
+func NutritionalValue(catalogue []model.Nutrient, amount func(Field) (float64, bool), nutrientGrams func(code string) (float64, bool)) (model.NutritionalValue, bool) {
+	var found bool
+	grams := func(field Field) float64 {
+		value, ok := amount(field)
+		found = found || ok
+		return value
+	}
+
+	energy, ok := amount(EnergyKcal)
+	if !ok {
+		var kilojoules float64
+		kilojoules, ok = amount(EnergyKJ)
+		energy = kilojoules / kilojoulesPerKilocalorie
+	}
+	found = ok
+
+	salt, ok := amount(Salt)
+	if !ok {
+		var sodium float64
+		sodium, ok = amount(Sodium)
+		salt = sodium * saltPerSodium
+	}
+	found = found || ok
+
+	nv := model.NutritionalValue{
+		EnergyValueKCAL:    umath.RoundFloat(energy, 0),
+		Fat:                umath.RoundFloat(grams(Fat), 3),
+		SaturatedFat:       umath.RoundFloat(grams(SaturatedFat), 3),
+		Carbohydrate:       umath.RoundFloat(grams(Carbohydrate), 3),
+		CarbohydrateSugars: umath.RoundFloat(grams(Sugars), 3),
+		Fibre:              umath.RoundFloat(grams(Fibre), 3),
+		SolubleFibre:       umath.RoundFloat(grams(SolubleFibre), 3),
+		InsolubleFibre:     umath.RoundFloat(grams(InsolubleFibre), 3),
+		Protein:            umath.RoundFloat(grams(Protein), 3),
+		Salt:               umath.RoundFloat(salt, 3),
+	}
+	if !found || !isPlausible(nv) {
+		return model.NutritionalValue{}, false
+	}
+
+	for _, nutrient := range catalogue {
+		perUnit, known := gramsPerUnit[strings.ToLower(nutrient.Unit)]
+		if !known {
+			continue
+		}
+		value, ok := nutrientGrams(nutrient.Code)
+		if !ok {
+			continue
+		}
+		if nv.Nutrients == nil {
+			nv.Nutrients = make(map[string]float64)
+		}
+		nv.Nutrients[nutrient.Code] = umath.RoundFloat(value/perUnit, 3)
+	}
+	return nv, true
+}
+
+// isPlausible reports whether the nutritional value per 100 g or ml has no more energy than pure fat
+// and no macronutrient above 100 g.
+func isPlausible(nv model.NutritionalValue) bool {
+	macronutrients := []float64{
+		nv.Fat, nv.SaturatedFat, nv.Carbohydrate, nv.CarbohydrateSugars, nv.Fibre, nv.SolubleFibre, nv.InsolubleFibre,
+		nv.Protein, nv.Salt,
+	}
+	return nv.EnergyValueKCAL <= maxKilocalories && slices.Max(macronutrients) <= maxGrams
+}
@@ -0,0 +1,243 @@
+package openfoodfacts
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/SarunasBucius/nutri-price-server/internal/model"
+	"github.com/SarunasBucius/nutri-price-server/internal/service/fooddata/nutrition"
+)
+
+// fields maps nutritional value fields to Open Food Facts nutriment names.
+var fields = map[nutrition.Field]string{
+	nutrition.EnergyKcal:     "energy-kcal",
+	nutrition.EnergyKJ:       "energy",
+	nutrition.Fat:            "fat",
+	nutrition.SaturatedFat:   "saturated-fat",
+	nutrition.Carbohydrate:   "carbohydrates",
+	nutrition.Sugars:         "sugars",
+	nutrition.Fibre:          "fiber",
+	nutrition.SolubleFibre:   "soluble-fiber",
+	nutrition.InsolubleFibre: "insoluble-fiber",
+	nutrition.Protein:        "proteins",
+	nutrition.Salt:           "salt",
+	nutrition.Sodium:         "sodium",
+}
+
+// nutrients maps catalogue nutrient codes to Open Food Facts nutriment names. Open Food Facts gives amounts in grams.
+// Only nutrients of the catalogue passed to the readers are imported.
+var nutrients = map[string]string{
+	"vitamin_a":        "vitamin-a",
+	"vitamin_d":        "vitamin-d",
+	"vitamin_e":        "vitamin-e",
+	"vitamin_k":        "vitamin-k",
+	"vitamin_c":        "vitamin-c",
+	"thiamin":          "vitamin-b1",
+	"riboflavin":       "vitamin-b2",
+	"niacin":           "vitamin-pp",
+	"vitamin_b6":       "vitamin-b6",
+	"folate":           "vitamin-b9",
+	"vitamin_b12":      "vitamin-b12",
+	"biotin":           "biotin",
+	"pantothenic_acid": "pantothenic-acid",
+	"potassium":        "potassium",
+	"chloride":         "chloride",
+	"calcium":          "calcium",
+	"phosphorus":       "phosphorus",
+	"magnesium":        "magnesium",
+	"iron":             "iron",
+	"zinc":             "zinc",
+	"copper":           "copper",
+	"manganese":        "manganese",
+	"fluoride":         "fluoride",
+	"selenium":         "selenium",
+	"chromium":         "chromium",
+	"molybdenum":       "molybdenum",
+	"iodine":           "iodine",
+}
+
+// liquidQuantity matches product quantities in volume units, e.g. "1,5 l" or "330 ml".
+// Nutriments of such products are per 100 ml.
+var liquidQuantity = regexp.MustCompile(`(?i)\d\s*(ml|cl|dl|l)\b`)
+
+// product is a line of the JSONL export. Nutriment values are numbers or numeric strings.
+type product struct {
+	Code          string         `json:"code"`
+	ProductName   string         `json:"product_name"`
+	ProductNameEN string         `json:"product_name_en"`
+	GenericName   string         `json:"generic_name"`
+	Brands        string         `json:"brands"`
+	Quantity      string         `json:"quantity"`
+	Nutriments    map[string]any `json:"nutriments"`
+}
+
+// ReadJSONL reads an Open Food Facts JSONL export and calls handle with every product that has a name
+// and a plausible nutritional value. Returns the number of skipped lines, including lines that are not valid JSON.
+func ReadJSONL(r io.Reader, catalogue []model.Nutrient, handle func(model.FoodFact) error) (int, error) {
+	reader := bufio.NewReader(r)
+	var skipped int
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(strings.TrimSpace(string(line))) > 0 {
+			var p product
+			fact, ok := model.FoodFact{}, false
+			if json.Unmarshal(line, &p) == nil {
+				name := firstNonEmpty(p.ProductName, p.ProductNameEN, p.GenericName)
+				fact, ok = toFoodFact(p.Code, name, p.Brands, p.Quantity, catalogue, func(key string) (float64, bool) {
+					return parseAmount(p.Nutriments[key])
+				})
+			}
+			if !ok {
+				skipped++
+			} else if err := handle(fact); err != nil {
+				return skipped, err
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return skipped, nil
+		}
+		if err != nil {
+			return skipped, fmt.Errorf("read line: %w", err)
+		}
+	}
+}
+
+// ReadCSV reads an Open Food Facts CSV export and calls handle with every product that has a name
+// and a plausible nutritional value. The official export is tab separated, comma separated files are accepted too.
+// Returns the number of skipped records.
+func ReadCSV(r io.Reader, catalogue []model.Nutrient, handle func(model.FoodFact) error) (int, error) {
+	reader := bufio.NewReader(r)
+	header, err := reader.ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return 0, fmt.Errorf("read header: %w", err)
+	}
+
+	csvReader := csv.NewReader(io.MultiReader(strings.NewReader(header), reader))
+	if strings.Contains(header, "\t") {
+		csvReader.Comma = '\t'
+	}
+	csvReader.LazyQuotes = true
+	csvReader.FieldsPerRecord = -1
+	csvReader.ReuseRecord = true
+
+	columns, err := csvReader.Read()
+	if err != nil {
+		return 0, fmt.Errorf("read header: %w", err)
+	}
+	columnIndexes := make(map[string]int, len(columns))
+	for i, column := range columns {
+		columnIndexes[strings.TrimSpace(column)] = i
+	}
+
+	var skipped int
+	for {
+		record, err := csvReader.Read()
+		if errors.Is(err, io.EOF) {
+			return skipped, nil
+		}
+		if err != nil {
+			skipped++
+			continue
+		}
+
+		field := func(column string) string {
+			i, ok := columnIndexes[column]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+		name := firstNonEmpty(field("product_name"), field("product_name_en"), field("generic_name"))
+		fact, ok := toFoodFact(field("code"), name, field("brands"), field("quantity"), catalogue, func(key string) (float64, bool) {
+			return parseAmount(field(key))
+		})
+		if !ok {
+			skipped++
+			continue
+		}
+		if err := handle(fact); err != nil {
+			return skipped, err
+		}
+	}
+}
+
+// toFoodFact builds the food fact from product fields and nutriments per 100 g or ml, with amounts of the catalogue nutrients.
+// Returns false if the product has no code, no name or no plausible nutritional value.
+func toFoodFact(code, name, brands, quantity string, catalogue []model.Nutrient, nutriment func(key string) (float64, bool)) (model.FoodFact, bool) {
+	code, name = strings.TrimSpace(code), strings.TrimSpace(name)
+	if code == "" || name == "" {
+		return model.FoodFact{}, false
+	}
+
+	nv, ok := nutrition.NutritionalValue(catalogue, func(field nutrition.Field) (float64, bool) {
+		return nutriment(fields[field] + "_100g")
+	}, func(code string) (float64, bool) {
+		nutrimentName, ok := nutrients[code]
+		if !ok {
+			return 0, false
+		}
+		return nutriment(nutrimentName + "_100g")
+	})
+	if !ok {
+		return model.FoodFact{}, false
+	}
+
+	fact := model.FoodFact{
+		Source:           model.FoodSourceOpenFoodFacts,
+		SourceID:         code,
+		Name:             name,
+		Brands:           strings.TrimSpace(brands),
+		Unit:             model.Grams,
+		NutritionalValue: nv,
+	}
+	if isBarcode(code) {
+		fact.Barcode = code
+	}
+	if liquidQuantity.MatchString(quantity) {
+		fact.Unit = model.Milliliters
+	}
+	return fact, true
+}
+
+// parseAmount parses a nutriment amount. Missing, empty and negative amounts are not found.
+func parseAmount(value any) (float64, bool) {
+	var amount float64
+	switch v := value.(type) {
+	case float64:
+		amount = v
+	case string:
+		parsed, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil {
+			return 0, false
+		}
+		amount = parsed
+	default:
+		return 0, false
+	}
+	return amount, amount >= 0
+}
+
+func isBarcode(code string) bool {
+	for _, r := range code {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return code != ""
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if strings.TrimSpace(value) != "" {
+			return value
+		}
+	}
+	return ""
+}
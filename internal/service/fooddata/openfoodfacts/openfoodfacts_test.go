@@ -0,0 +1,154 @@
+package openfoodfacts
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/SarunasBucius/nutri-price-server/internal/model"
+	"github.com/stretchr/testify/require"
+)
+
+// catalogue is the part of the nutrient catalogue the tests import.
+var catalogue = []model.Nutrient{
+	{Code: "calcium", Unit: "mg"},
+	{Code: "iron", Unit: "mg"},
+	{Code: "vitamin_b12", Unit: "µg"},
+}
+
+func TestReadJSONL(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		want        []model.FoodFact
+		wantSkipped int
+	}{
+		{
+			name: "product_with_micronutrients",
+			input: `{"code":"4770001001234","product_name":"Kefyras 2,5%","brands":"Rokiškio","quantity":"1 l",` +
+				`"nutriments":{"energy-kcal_100g":51,"fat_100g":2.5,"saturated-fat_100g":"1.6","carbohydrates_100g":4,` +
+				`"sugars_100g":4,"proteins_100g":3.2,"salt_100g":0.1,"calcium_100g":0.12,"vitamin-b12_100g":0.0000004}}`,
+			want: []model.FoodFact{{
+				Source:   model.FoodSourceOpenFoodFacts,
+				SourceID: "4770001001234",
+				Barcode:  "4770001001234",
+				Name:     "Kefyras 2,5%",
+				Brands:   "Rokiškio",
+				Unit:     model.Milliliters,
+				NutritionalValue: model.NutritionalValue{
+					EnergyValueKCAL: 51, Fat: 2.5, SaturatedFat: 1.6, Carbohydrate: 4, CarbohydrateSugars: 4, Protein: 3.2, Salt: 0.1,
+					Nutrients: map[string]float64{"calcium": 120, "vitamin_b12": 0.4},
+				},
+			}},
+		},
+		{
+			name: "energy_in_kilojoules_and_sodium",
+			input: `{"code":"123","product_name_en":"Oats","quantity":"500 g",` +
+				`"nutriments":{"energy_100g":1569,"sodium_100g":0.004,"fiber_100g":10,"soluble-fiber_100g":4}}`,
+			want: []model.FoodFact{{
+				Source:           model.FoodSourceOpenFoodFacts,
+				SourceID:         "123",
+				Barcode:          "123",
+				Name:             "Oats",
+				Unit:             model.Grams,
+				NutritionalValue: model.NutritionalValue{EnergyValueKCAL: 375, Fibre: 10, SolubleFibre: 4, Salt: 0.01},
+			}},
+		},
+		{
+			name:  "nutrient_not_in_catalogue",
+			input: `{"code":"7","product_name":"Nuts","nutriments":{"energy-kcal_100g":600,"fat_100g":50,"zinc_100g":0.003}}`,
+			want: []model.FoodFact{{
+				Source:           model.FoodSourceOpenFoodFacts,
+				SourceID:         "7",
+				Barcode:          "7",
+				Name:             "Nuts",
+				Unit:             model.Grams,
+				NutritionalValue: model.NutritionalValue{EnergyValueKCAL: 600, Fat: 50},
+			}},
+		},
+		{
+			name: "implausible_nutritional_values",
+			input: `{"code":"1","product_name":"Energy per package","nutriments":{"energy-kcal_100g":2400,"fat_100g":20}}` + "\n" +
+				`{"code":"2","product_name":"Fat typo","nutriments":{"energy-kcal_100g":120,"fat_100g":250}}` + "\n" +
+				`{"code":"3","product_name":"Butter","nutriments":{"energy-kcal_100g":740,"fat_100g":82}}`,
+			want: []model.FoodFact{{
+				Source:           model.FoodSourceOpenFoodFacts,
+				SourceID:         "3",
+				Barcode:          "3",
+				Name:             "Butter",
+				Unit:             model.Grams,
+				NutritionalValue: model.NutritionalValue{EnergyValueKCAL: 740, Fat: 82},
+			}},
+			wantSkipped: 2,
+		},
+		{
+			name: "skipped_lines",
+			input: `{"code":"1","product_name":"No nutriments","nutriments":{}}` + "\n" +
+				`{"code":"2","nutriments":{"fat_100g":1}}` + "\n" +
+				`not json` + "\n\n",
+			wantSkipped: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []model.FoodFact
+			skipped, err := ReadJSONL(strings.NewReader(tt.input), catalogue, func(fact model.FoodFact) error {
+				got = append(got, fact)
+				return nil
+			})
+			require.NoError(t, err)
+			require.Equal(t, tt.wantSkipped, skipped)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestReadCSV(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		want        []model.FoodFact
+		wantSkipped int
+	}{
+		{
+			name: "tab_separated",
+			input: "code\tproduct_name\tbrands\tquantity\tenergy-kcal_100g\tfat_100g\tproteins_100g\tiron_100g\n" +
+				"4770001009999\tGrikių kruopos\tMalsena\t1 kg\t343\t3.4\t13.3\t0.0022\n" +
+				"4770001008888\t\t\t\t100\t\t\t\n",
+			want: []model.FoodFact{{
+				Source:   model.FoodSourceOpenFoodFacts,
+				SourceID: "4770001009999",
+				Barcode:  "4770001009999",
+				Name:     "Grikių kruopos",
+				Brands:   "Malsena",
+				Unit:     model.Grams,
+				NutritionalValue: model.NutritionalValue{
+					EnergyValueKCAL: 343, Fat: 3.4, Protein: 13.3, Nutrients: map[string]float64{"iron": 2.2},
+				},
+			}},
+			wantSkipped: 1,
+		},
+		{
+			name:  "comma_separated",
+			input: "code,product_name,energy-kcal_100g,sugars_100g\nabc-1,\"Apple juice, cloudy\",46,10.4\n",
+			want: []model.FoodFact{{
+				Source:           model.FoodSourceOpenFoodFacts,
+				SourceID:         "abc-1",
+				Name:             "Apple juice, cloudy",
+				Unit:             model.Grams,
+				NutritionalValue: model.NutritionalValue{EnergyValueKCAL: 46, CarbohydrateSugars: 10.4},
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []model.FoodFact
+			skipped, err := ReadCSV(strings.NewReader(tt.input), catalogue, func(fact model.FoodFact) error {
+				got = append(got, fact)
+				return nil
+			})
+			require.NoError(t, err)
+			require.Equal(t, tt.wantSkipped, skipped)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
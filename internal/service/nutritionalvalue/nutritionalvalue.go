@@ -3,8 +3,10 @@ package nutritionalvalue
 import (
 	"context"
 	"fmt"
+	"regexp"
 
 	"github.com/SarunasBucius/nutri-price-server/internal/model"
+	"github.com/SarunasBucius/nutri-price-server/internal/utils/uerror"
 )
 
 type Service struct {
@@ -25,9 +27,15 @@ type INutritionalValueRepository interface {
 	UpdateProductNutritionalValue(ctx context.Context, productNV model.ProductNutritionalValue) error
 	DeleteProductNutritionalValue(ctx context.Context, id int) error
 	GetNutritionalValuesUnits(ctx context.Context) (map[string][]string, error)
+	GetNutrients(ctx context.Context) ([]model.Nutrient, error)
+	UpsertNutrient(ctx context.Context, nutrient model.Nutrient) error
 }
 
 func (s *Service) InsertNutritionalValue(ctx context.Context, pnv model.ProductNutritionalValueNew) error {
+	if err := s.ValidateNutrients(ctx, pnv.NutritionalValue.Nutrients); err != nil {
+		return err
+	}
+
 	nvs, err := s.NutritionalValueRepo.GetProductsNutritionalValueByProductNames(ctx, []string{pnv.Product})
 	if err != nil {
 		return fmt.Errorf("get products nutritional value by product names: %w", err)
@@ -97,6 +105,10 @@ func (s *Service) GetProductNutritionalValue(ctx context.Context, nvID int) (mod
 }
 
 func (s *Service) UpdateProductNutritionalValue(ctx context.Context, productNV model.ProductNutritionalValue) error {
+	if err := s.ValidateNutrients(ctx, productNV.NutritionalValue.Nutrients); err != nil {
+		return err
+	}
+
 	if err := s.NutritionalValueRepo.UpdateProductNutritionalValue(ctx, productNV); err != nil {
 		return fmt.Errorf("update product nutritional value: %w", err)
 	}
@@ -109,3 +121,57 @@ func (s *Service) DeleteProductNutritionalValue(ctx context.Context, nvID int) e
 	}
 	return nil
 }
+
+// nutrientCodePattern keeps nutrient codes usable as JSON keys in requests, e.g. vitamin_b12.
+var nutrientCodePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+func (s *Service) GetNutrients(ctx context.Context) ([]model.Nutrient, error) {
+	nutrients, err := s.NutritionalValueRepo.GetNutrients(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get nutrients: %w", err)
+	}
+	return nutrients, nil
+}
+
+func (s *Service) UpsertNutrient(ctx context.Context, nutrient model.Nutrient) error {
+	if !nutrientCodePattern.MatchString(nutrient.Code) {
+		return uerror.NewBadRequest("nutrient code must be lowercase letters, digits and underscores", nil)
+	}
+	if nutrient.Name == "" || nutrient.Unit == "" {
+		return uerror.NewBadRequest("nutrient name and unit are required", nil)
+	}
+	if nutrient.DailyReference != nil && *nutrient.DailyReference <= 0 {
+		return uerror.NewBadRequest("daily reference must be positive", nil)
+	}
+
+	if err := s.NutritionalValueRepo.UpsertNutrient(ctx, nutrient); err != nil {
+		return fmt.Errorf("upsert nutrient: %w", err)
+	}
+	return nil
+}
+
+// ValidateNutrients checks that nutrient amounts are not negative and nutrients are in the catalogue.
+func (s *Service) ValidateNutrients(ctx context.Context, amounts map[string]float64) error {
+	if len(amounts) == 0 {
+		return nil
+	}
+
+	nutrients, err := s.NutritionalValueRepo.GetNutrients(ctx)
+	if err != nil {
+		return fmt.Errorf("get nutrients: %w", err)
+	}
+	known := make(map[string]struct{}, len(nutrients))
+	for _, nutrient := range nutrients {
+		known[nutrient.Code] = struct{}{}
+	}
+
+	for code, amount := range amounts {
+		if _, ok := known[code]; !ok {
+			return uerror.NewBadRequest(fmt.Sprintf("unknown nutrient %q", code), nil)
+		}
+		if amount < 0 {
+			return uerror.NewBadRequest(fmt.Sprintf("amount of nutrient %q must not be negative", code), nil)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package nutritionalvalue
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/SarunasBucius/nutri-price-server/internal/model"
+	"github.com/SarunasBucius/nutri-price-server/internal/utils/uerror"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeNutritionalValueRepo keeps the nutrient catalogue in memory. Methods not overridden panic when called.
+type fakeNutritionalValueRepo struct {
+	INutritionalValueRepository
+	nutrients []model.Nutrient
+}
+
+func (f *fakeNutritionalValueRepo) GetNutrients(context.Context) ([]model.Nutrient, error) {
+	return f.nutrients, nil
+}
+
+func (f *fakeNutritionalValueRepo) UpsertNutrient(_ context.Context, nutrient model.Nutrient) error {
+	f.nutrients = append(f.nutrients, nutrient)
+	return nil
+}
+
+func TestService_ValidateNutrients(t *testing.T) {
+	tests := []struct {
+		name    string
+		amounts map[string]float64
+		wantErr bool
+	}{
+		{
+			name: "no_nutrients",
+		},
+		{
+			name:    "known_nutrients",
+			amounts: map[string]float64{"calcium": 120, "vitamin_d": 0},
+		},
+		{
+			name:    "unknown_nutrient",
+			amounts: map[string]float64{"calcium": 120, "unobtainium": 1},
+			wantErr: true,
+		},
+		{
+			name:    "negative_amount",
+			amounts: map[string]float64{"calcium": -1},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewNutritionalValueService(&fakeNutritionalValueRepo{nutrients: []model.Nutrient{
+				{Code: "calcium", Name: "Calcium", Unit: "mg"},
+				{Code: "vitamin_d", Name: "Vitamin D", Unit: "µg"},
+			}})
+
+			err := s.ValidateNutrients(context.Background(), tt.amounts)
+			if !tt.wantErr {
+				require.NoError(t, err)
+				return
+			}
+			requireBadRequest(t, err)
+		})
+	}
+}
+
+func TestService_UpsertNutrient(t *testing.T) {
+	tests := []struct {
+		name     string
+		nutrient model.Nutrient
+		wantErr  bool
+	}{
+		{
+			name:     "valid",
+			nutrient: model.Nutrient{Code: "vitamin_b12", Name: "Vitamin B12", Unit: "µg", DailyReference: ptr(2.5)},
+		},
+		{
+			name:     "without_daily_reference",
+			nutrient: model.Nutrient{Code: "chromium", Name: "Chromium", Unit: "µg"},
+		},
+		{
+			name:     "invalid_code",
+			nutrient: model.Nutrient{Code: "Vitamin B12", Name: "Vitamin B12", Unit: "µg"},
+			wantErr:  true,
+		},
+		{
+			name:     "missing_unit",
+			nutrient: model.Nutrient{Code: "iron", Name: "Iron"},
+			wantErr:  true,
+		},
+		{
+			name:     "negative_daily_reference",
+			nutrient: model.Nutrient{Code: "iron", Name: "Iron", Unit: "mg", DailyReference: ptr(-14.0)},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeNutritionalValueRepo{}
+			s := NewNutritionalValueService(repo)
+
+			err := s.UpsertNutrient(context.Background(), tt.nutrient)
+			if !tt.wantErr {
+				require.NoError(t, err)
+				require.Equal(t, []model.Nutrient{tt.nutrient}, repo.nutrients)
+				return
+			}
+			requireBadRequest(t, err)
+			require.Empty(t, repo.nutrients)
+		})
+	}
+}
+
+func requireBadRequest(t *testing.T, err error) {
+	t.Helper()
+	var apiErr *uerror.APIError
+	require.True(t, errors.As(err, &apiErr))
+	require.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,48 @@
+package product
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/SarunasBucius/nutri-price-server/internal/model"
+	"github.com/SarunasBucius/nutri-price-server/internal/service/product/basket"
+	"github.com/SarunasBucius/nutri-price-server/internal/utils/uerror"
+)
+
+const defaultBasketDays = 90
+
+// CompareBasket estimates the shopping list cost at each retailer and suggests the cheapest split across stores.
+func (s *Service) CompareBasket(ctx context.Context, request model.BasketComparisonRequest) (model.BasketComparison, error) {
+	if len(request.Products) == 0 {
+		return model.BasketComparison{}, uerror.NewBadRequest("products are required", nil)
+	}
+	if request.MaxStores < 0 || request.Days < 0 {
+		return model.BasketComparison{}, uerror.NewBadRequest("maxStores and days must not be negative", nil)
+	}
+	if request.Days == 0 {
+		request.Days = defaultBasketDays
+	}
+
+	productNames := make([]string, 0, len(request.Products))
+	for i, product := range request.Products {
+		request.Products[i].Product = strings.TrimSpace(product.Product)
+		if request.Products[i].Product == "" || product.Quantity.Amount <= 0 {
+			return model.BasketComparison{}, uerror.NewBadRequest("product name and positive quantity are required", nil)
+		}
+		productNames = append(productNames, request.Products[i].Product)
+	}
+
+	purchases, err := s.ProductRepo.GetPurchasesByNamesOrGroups(ctx, productNames)
+	if err != nil {
+		return model.BasketComparison{}, fmt.Errorf("get purchases by names: %w", err)
+	}
+
+	conversions, err := s.UnitConversionRepo.GetUnitConversionsByProducts(ctx, productNames)
+	if err != nil {
+		return model.BasketComparison{}, fmt.Errorf("get unit conversions by products: %w", err)
+	}
+
+	return basket.Compare(request.Products, purchases, conversions, request.MaxStores, request.Days, time.Now()), nil
+}
@@ -0,0 +1,166 @@
+package basket
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/SarunasBucius/nutri-price-server/internal/model"
+	"github.com/SarunasBucius/nutri-price-server/internal/service/recipe/pricing"
+	"github.com/SarunasBucius/nutri-price-server/internal/utils/umath"
+)
+
+// Compare estimates the cost of the products at each retailer they were bought at and finds the cheapest split
+// across at most maxStores stores, or any number of stores when maxStores is 0.
+// A product is priced by the weighted average of its purchases at the retailer in the last days days,
+// or by its last purchase at the retailer when it was not bought there recently.
+// Purchase names must be the product names they were matched by.
+func Compare(products []model.BasketProduct, purchases []model.PurchasedProduct, conversions map[string]model.UnitConversion,
+	maxStores, days int, now time.Time) model.BasketComparison {
+	purchasesByRetailer := make(map[string]map[string][]model.PurchasedProduct)
+	for _, purchase := range purchases {
+		retailer := strings.ToLower(strings.TrimSpace(purchase.Retailer))
+		if retailer == "" {
+			continue
+		}
+		if purchasesByRetailer[retailer] == nil {
+			purchasesByRetailer[retailer] = make(map[string][]model.PurchasedProduct)
+		}
+		purchasesByRetailer[retailer][purchase.Name] = append(purchasesByRetailer[retailer][purchase.Name], purchase)
+	}
+
+	strategy := model.PriceStrategy{Name: model.PriceStrategyWeightedAverage, Days: days}
+	retailers := make([]model.RetailerBasket, 0, len(purchasesByRetailer))
+	for retailer, purchasesByProduct := range purchasesByRetailer {
+		basket := model.RetailerBasket{Retailer: retailer, Products: []model.BasketProductPrice{}, MissingProducts: []string{}}
+		for _, product := range products {
+			price, ok := pricing.Price(product.Quantity, purchasesByProduct[product.Product], conversions[product.Product],
+				strategy, time.Time{}, now)
+			if !ok {
+				price, ok = pricing.Price(product.Quantity, purchasesByProduct[product.Product], conversions[product.Product],
+					model.PriceStrategy{Name: model.PriceStrategyLast}, time.Time{}, now)
+			}
+			if !ok {
+				basket.MissingProducts = append(basket.MissingProducts, product.Product)
+				continue
+			}
+			basket.Products = append(basket.Products, model.BasketProductPrice{
+				Product: product.Product,
+				Price:   price.Price,
+				Sources: price.Sources,
+			})
+			basket.Total += price.Price
+		}
+		basket.Total = umath.RoundFloat(basket.Total, 2)
+		retailers = append(retailers, basket)
+	}
+	slices.SortFunc(retailers, compareBaskets)
+
+	return model.BasketComparison{
+		Retailers:     retailers,
+		CheapestSplit: getCheapestSplit(products, retailers, maxStores),
+	}
+}
+
+// getCheapestSplit tries every combination of at most maxStores retailers and picks the one
+// missing the fewest products, then the cheapest one, then the one with fewer stores.
+func getCheapestSplit(products []model.BasketProduct, retailers []model.RetailerBasket, maxStores int) model.BasketSplit {
+	if maxStores <= 0 || maxStores > len(retailers) {
+		maxStores = len(retailers)
+	}
+
+	best := splitProducts(products, nil)
+	for _, combination := range getCombinations(retailers, maxStores) {
+		split := splitProducts(products, combination)
+		if compareSplits(split, best) < 0 {
+			best = split
+		}
+	}
+	return best
+}
+
+// splitProducts assigns each product to the store of the combination where it is the cheapest.
+func splitProducts(products []model.BasketProduct, stores []model.RetailerBasket) model.BasketSplit {
+	split := model.BasketSplit{Stores: []model.RetailerBasket{}, MissingProducts: []string{}}
+	productsByStore := make(map[string][]model.BasketProductPrice)
+	for _, product := range products {
+		var cheapest *model.BasketProductPrice
+		var cheapestStore string
+		for _, store := range stores {
+			i := slices.IndexFunc(store.Products, func(p model.BasketProductPrice) bool {
+				return p.Product == product.Product
+			})
+			if i == -1 || (cheapest != nil && store.Products[i].Price >= cheapest.Price) {
+				continue
+			}
+			cheapest, cheapestStore = &store.Products[i], store.Retailer
+		}
+		if cheapest == nil {
+			split.MissingProducts = append(split.MissingProducts, product.Product)
+			continue
+		}
+		productsByStore[cheapestStore] = append(productsByStore[cheapestStore], *cheapest)
+	}
+
+	for _, store := range stores {
+		storeProducts, ok := productsByStore[store.Retailer]
+		if !ok {
+			continue
+		}
+		basket := model.RetailerBasket{Retailer: store.Retailer, Products: storeProducts, MissingProducts: []string{}}
+		for _, product := range storeProducts {
+			basket.Total += product.Price
+		}
+		basket.Total = umath.RoundFloat(basket.Total, 2)
+		split.Total += basket.Total
+		split.Stores = append(split.Stores, basket)
+	}
+	split.Total = umath.RoundFloat(split.Total, 2)
+	return split
+}
+
+// getCombinations returns all non-empty combinations of at most size retailers.
+func getCombinations(retailers []model.RetailerBasket, size int) [][]model.RetailerBasket {
+	var combinations [][]model.RetailerBasket
+	var combine func(start int, current []model.RetailerBasket)
+	combine = func(start int, current []model.RetailerBasket) {
+		if len(current) > 0 {
+			combinations = append(combinations, slices.Clone(current))
+		}
+		if len(current) == size {
+			return
+		}
+		for i := start; i < len(retailers); i++ {
+			combine(i+1, append(current, retailers[i]))
+		}
+	}
+	combine(0, nil)
+	return combinations
+}
+
+func compareSplits(a, b model.BasketSplit) int {
+	if c := len(a.MissingProducts) - len(b.MissingProducts); c != 0 {
+		return c
+	}
+	if a.Total != b.Total {
+		if a.Total < b.Total {
+			return -1
+		}
+		return 1
+	}
+	return len(a.Stores) - len(b.Stores)
+}
+
+// compareBaskets orders baskets by missing products, then by total and retailer.
+func compareBaskets(a, b model.RetailerBasket) int {
+	if c := len(a.MissingProducts) - len(b.MissingProducts); c != 0 {
+		return c
+	}
+	if a.Total != b.Total {
+		if a.Total < b.Total {
+			return -1
+		}
+		return 1
+	}
+	return strings.Compare(a.Retailer, b.Retailer)
+}
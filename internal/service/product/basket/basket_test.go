@@ -0,0 +1,87 @@
+package basket
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SarunasBucius/nutri-price-server/internal/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompare(t *testing.T) {
+	now := time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC)
+	kg := model.Quantity{Unit: model.Grams, Amount: 1000}
+	purchases := []model.PurchasedProduct{
+		{ID: 1, Name: "milk", Retailer: "lidl", Price: 1, Quantity: model.Quantity{Unit: model.Milliliters, Amount: 1000}, Date: now.AddDate(0, 0, -5)},
+		{ID: 2, Name: "milk", Retailer: "Norfa", Price: 1.2, Quantity: model.Quantity{Unit: model.Milliliters, Amount: 1000}, Date: now.AddDate(0, 0, -3)},
+		{ID: 3, Name: "oats", Retailer: "lidl", Price: 2, Quantity: kg, Date: now.AddDate(0, 0, -2)},
+		{ID: 4, Name: "oats", Retailer: "norfa", Price: 1, Quantity: kg, Date: now.AddDate(0, 0, -200)},
+		{ID: 5, Name: "coffee", Retailer: "barbora", Price: 10, Quantity: kg, Date: now.AddDate(0, 0, -1)},
+	}
+	products := []model.BasketProduct{
+		{Product: "milk", Quantity: model.Quantity{Unit: model.Milliliters, Amount: 2000}},
+		{Product: "oats", Quantity: model.Quantity{Unit: model.Grams, Amount: 500}},
+		{Product: "coffee", Quantity: model.Quantity{Unit: model.Grams, Amount: 250}},
+	}
+
+	tests := []struct {
+		name          string
+		maxStores     int
+		wantRetailers []string
+		wantTotals    []float64
+		wantSplit     map[string][]string
+		wantTotal     float64
+		wantMissing   []string
+	}{
+		{
+			name:          "one_store",
+			maxStores:     1,
+			wantRetailers: []string{"norfa", "lidl", "barbora"},
+			wantTotals:    []float64{2.9, 3, 2.5},
+			wantSplit:     map[string][]string{"norfa": {"milk", "oats"}},
+			wantTotal:     2.9,
+			wantMissing:   []string{"coffee"},
+		},
+		{
+			name:          "two_stores",
+			maxStores:     2,
+			wantRetailers: []string{"norfa", "lidl", "barbora"},
+			wantTotals:    []float64{2.9, 3, 2.5},
+			wantSplit:     map[string][]string{"norfa": {"milk", "oats"}, "barbora": {"coffee"}},
+			wantTotal:     5.4,
+			wantMissing:   []string{},
+		},
+		{
+			name:          "any_number_of_stores",
+			wantRetailers: []string{"norfa", "lidl", "barbora"},
+			wantTotals:    []float64{2.9, 3, 2.5},
+			wantSplit:     map[string][]string{"lidl": {"milk"}, "norfa": {"oats"}, "barbora": {"coffee"}},
+			wantTotal:     5.0,
+			wantMissing:   []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Compare(products, purchases, nil, tt.maxStores, 90, now)
+
+			var retailers []string
+			var totals []float64
+			for _, retailer := range got.Retailers {
+				retailers = append(retailers, retailer.Retailer)
+				totals = append(totals, retailer.Total)
+			}
+			require.Equal(t, tt.wantRetailers, retailers)
+			require.Equal(t, tt.wantTotals, totals)
+
+			split := make(map[string][]string)
+			for _, store := range got.CheapestSplit.Stores {
+				for _, product := range store.Products {
+					split[store.Retailer] = append(split[store.Retailer], product.Product)
+				}
+			}
+			require.Equal(t, tt.wantSplit, split)
+			require.InDelta(t, tt.wantTotal, got.CheapestSplit.Total, 1e-9)
+			require.Equal(t, tt.wantMissing, got.CheapestSplit.MissingProducts)
+		})
+	}
+}
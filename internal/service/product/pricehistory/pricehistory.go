@@ -0,0 +1,125 @@
+package pricehistory
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/SarunasBucius/nutri-price-server/internal/model"
+	"github.com/SarunasBucius/nutri-price-server/internal/service/recipe/unitconv"
+	"github.com/SarunasBucius/nutri-price-server/internal/utils/umath"
+	"github.com/SarunasBucius/nutri-price-server/internal/utils/utime"
+)
+
+// stableTrendShare is the share of the mean price a trend per bucket has to exceed to count as rising or falling.
+const stableTrendShare = 0.01
+
+// Units a price history can be normalised to.
+var units = []string{unitconv.Kilograms, unitconv.Liters, model.Pieces}
+
+// Build normalises purchase prices to the unit and summarises them by week or month buckets.
+// Purchases that can not be converted to the unit are skipped.
+func Build(purchases []model.PurchasedProduct, unit, bucket string, conversion model.UnitConversion) (model.PriceHistory, error) {
+	if !slices.Contains(units, unit) {
+		return model.PriceHistory{}, fmt.Errorf("unsupported unit %q", unit)
+	}
+	if bucket != model.PriceBucketWeek && bucket != model.PriceBucketMonth {
+		return model.PriceHistory{}, fmt.Errorf("unsupported bucket %q", bucket)
+	}
+
+	pricesByPeriod := make(map[time.Time][]float64)
+	var allPrices []float64
+	for _, purchase := range purchases {
+		if purchase.Date.IsZero() || purchase.Quantity.Amount <= 0 {
+			continue
+		}
+		quantity, _, err := unitconv.Convert(purchase.Quantity, unit, conversion)
+		if err != nil || quantity.Amount <= 0 {
+			continue
+		}
+
+		unitPrice := purchase.Price / quantity.Amount
+		period := utime.PeriodStart(purchase.Date, bucket == model.PriceBucketMonth)
+		pricesByPeriod[period] = append(pricesByPeriod[period], unitPrice)
+		allPrices = append(allPrices, unitPrice)
+	}
+
+	history := model.PriceHistory{Unit: unit, Bucket: bucket, Points: []model.PricePoint{}, TrendDirection: model.PriceTrendStable}
+	if len(allPrices) == 0 {
+		return history, nil
+	}
+
+	periods := make([]time.Time, 0, len(pricesByPeriod))
+	for period := range pricesByPeriod {
+		periods = append(periods, period)
+	}
+	slices.SortFunc(periods, time.Time.Compare)
+
+	means := make([]float64, 0, len(periods))
+	for _, period := range periods {
+		prices := pricesByPeriod[period]
+		mean := getMean(prices)
+		means = append(means, mean)
+		history.Points = append(history.Points, model.PricePoint{
+			PeriodStart: period.Format(time.DateOnly),
+			Purchases:   len(prices),
+			Min:         umath.RoundFloat(slices.Min(prices), 2),
+			Max:         umath.RoundFloat(slices.Max(prices), 2),
+			Mean:        umath.RoundFloat(mean, 2),
+		})
+	}
+
+	mean := getMean(allPrices)
+	trend := getTrend(periods, means, bucket)
+	history.Min = umath.RoundFloat(slices.Min(allPrices), 2)
+	history.Max = umath.RoundFloat(slices.Max(allPrices), 2)
+	history.Mean = umath.RoundFloat(mean, 2)
+	history.Trend = umath.RoundFloat(trend, 4)
+	switch {
+	case trend > mean*stableTrendShare:
+		history.TrendDirection = model.PriceTrendRising
+	case trend < -mean*stableTrendShare:
+		history.TrendDirection = model.PriceTrendFalling
+	}
+	return history, nil
+}
+
+// getTrend returns the least squares slope of period means, with periods numbered by buckets since the first one,
+// so empty buckets between purchases are accounted for.
+func getTrend(periods []time.Time, means []float64, bucket string) float64 {
+	if len(periods) < 2 {
+		return 0
+	}
+
+	xs := make([]float64, 0, len(periods))
+	for _, period := range periods {
+		xs = append(xs, bucketsBetween(periods[0], period, bucket))
+	}
+
+	meanX, meanY := getMean(xs), getMean(means)
+	var covariance, variance float64
+	for i := range xs {
+		covariance += (xs[i] - meanX) * (means[i] - meanY)
+		variance += (xs[i] - meanX) * (xs[i] - meanX)
+	}
+	if variance == 0 {
+		return 0
+	}
+	return covariance / variance
+}
+
+func bucketsBetween(from, to time.Time, bucket string) float64 {
+	if bucket == model.PriceBucketMonth {
+		return float64((to.Year()-from.Year())*12 + int(to.Month()) - int(from.Month()))
+	}
+	return math.Round(to.Sub(from).Hours() / 24 / 7)
+}
+
+func getMean(values []float64) float64 {
+	var sum float64
+	for _, value := range values {
+		sum += value
+	}
+	return sum / float64(len(values))
+}
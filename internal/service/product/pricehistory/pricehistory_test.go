@@ -0,0 +1,105 @@
+package pricehistory
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SarunasBucius/nutri-price-server/internal/model"
+	"github.com/SarunasBucius/nutri-price-server/internal/service/recipe/unitconv"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuild(t *testing.T) {
+	purchases := []model.PurchasedProduct{
+		{Price: 1, Quantity: model.Quantity{Unit: model.Grams, Amount: 500}, Date: time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)},
+		{Price: 3, Quantity: model.Quantity{Unit: unitconv.Kilograms, Amount: 1}, Date: time.Date(2025, 1, 12, 0, 0, 0, 0, time.UTC)},
+		{Price: 1.25, Quantity: model.Quantity{Unit: model.Grams, Amount: 500}, Date: time.Date(2025, 2, 3, 0, 0, 0, 0, time.UTC)},
+		{Price: 1.5, Quantity: model.Quantity{Unit: model.Pieces, Amount: 1}, Date: time.Date(2025, 2, 4, 0, 0, 0, 0, time.UTC)},
+		{Price: 2, Quantity: model.Quantity{Unit: model.Grams, Amount: 1000}, Date: time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	tests := []struct {
+		name       string
+		purchases  []model.PurchasedProduct
+		unit       string
+		bucket     string
+		conversion model.UnitConversion
+		want       model.PriceHistory
+		wantErr    bool
+	}{
+		{
+			name:      "monthly_skips_inconvertible_purchases",
+			purchases: purchases,
+			unit:      unitconv.Kilograms,
+			bucket:    model.PriceBucketMonth,
+			want: model.PriceHistory{
+				Unit:   unitconv.Kilograms,
+				Bucket: model.PriceBucketMonth,
+				Points: []model.PricePoint{
+					{PeriodStart: "2025-01-01", Purchases: 2, Min: 2, Max: 3, Mean: 2.5},
+					{PeriodStart: "2025-02-01", Purchases: 1, Min: 2.5, Max: 2.5, Mean: 2.5},
+					{PeriodStart: "2025-04-01", Purchases: 1, Min: 2, Max: 2, Mean: 2},
+				},
+				Min:            2,
+				Max:            3,
+				Mean:           2.38,
+				Trend:          -0.1786,
+				TrendDirection: model.PriceTrendFalling,
+			},
+		},
+		{
+			name:       "weekly_with_piece_weight",
+			purchases:  purchases[:4],
+			unit:       model.Pieces,
+			bucket:     model.PriceBucketWeek,
+			conversion: model.UnitConversion{PieceWeight: 500},
+			want: model.PriceHistory{
+				Unit:   model.Pieces,
+				Bucket: model.PriceBucketWeek,
+				Points: []model.PricePoint{
+					{PeriodStart: "2025-01-06", Purchases: 2, Min: 1, Max: 1.5, Mean: 1.25},
+					{PeriodStart: "2025-02-03", Purchases: 2, Min: 1.25, Max: 1.5, Mean: 1.38},
+				},
+				Min:            1,
+				Max:            1.5,
+				Mean:           1.31,
+				Trend:          0.0313,
+				TrendDirection: model.PriceTrendRising,
+			},
+		},
+		{
+			name:   "no_purchases",
+			unit:   unitconv.Liters,
+			bucket: model.PriceBucketWeek,
+			want: model.PriceHistory{
+				Unit:           unitconv.Liters,
+				Bucket:         model.PriceBucketWeek,
+				Points:         []model.PricePoint{},
+				TrendDirection: model.PriceTrendStable,
+			},
+		},
+		{
+			name:    "unsupported_unit",
+			unit:    model.Grams,
+			bucket:  model.PriceBucketWeek,
+			wantErr: true,
+		},
+		{
+			name:    "unsupported_bucket",
+			unit:    unitconv.Kilograms,
+			bucket:  "day",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Build(tt.purchases, tt.unit, tt.bucket, tt.conversion)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
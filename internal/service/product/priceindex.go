@@ -0,0 +1,60 @@
+package product
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/SarunasBucius/nutri-price-server/internal/model"
+	"github.com/SarunasBucius/nutri-price-server/internal/service/product/priceindex"
+	"github.com/SarunasBucius/nutri-price-server/internal/utils/uerror"
+)
+
+const (
+	defaultPriceIndexMonths    = 12
+	defaultPriceIndexMinMonths = 2
+)
+
+// GetPriceIndex calculates the household price index, with categories indexed by top level product categories.
+// Purchases of the base period before the from month are read too, as they weight the basket.
+// The index covers the last 12 months up to the current month when the period is not given,
+// and products bought in at least 2 months when the basket is not configured.
+func (s *Service) GetPriceIndex(ctx context.Context, request model.PriceIndexRequest) (model.PriceIndex, error) {
+	to := time.Now().UTC()
+	if request.To != "" {
+		var err error
+		if to, err = time.Parse("2006-01", request.To); err != nil {
+			return model.PriceIndex{}, uerror.NewBadRequest("invalid to month, expected YYYY-MM", err)
+		}
+	}
+	to = time.Date(to.Year(), to.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	from := to.AddDate(0, -(defaultPriceIndexMonths - 1), 0)
+	if request.From != "" {
+		var err error
+		if from, err = time.Parse("2006-01", request.From); err != nil {
+			return model.PriceIndex{}, uerror.NewBadRequest("invalid from month, expected YYYY-MM", err)
+		}
+	}
+	if from.After(to) {
+		return model.PriceIndex{}, uerror.NewBadRequest("from month must not be after to month", nil)
+	}
+	if request.MinMonths < 0 || request.Limit < 0 {
+		return model.PriceIndex{}, uerror.NewBadRequest("minMonths and limit must not be negative", nil)
+	}
+	if request.MinMonths == 0 {
+		request.MinMonths = defaultPriceIndexMinMonths
+	}
+
+	purchases, err := s.ProductRepo.GetPurchasesByDateRange(ctx, priceindex.BasePeriodStart(from), to.AddDate(0, 1, -1), request.Filter)
+	if err != nil {
+		return model.PriceIndex{}, fmt.Errorf("get purchases by date range: %w", err)
+	}
+
+	categories, err := s.ProductRepo.GetProductRootCategories(ctx)
+	if err != nil {
+		return model.PriceIndex{}, fmt.Errorf("get product root categories: %w", err)
+	}
+
+	return priceindex.Calculate(purchases, from, to, request, categories), nil
+}
@@ -0,0 +1,270 @@
+package priceindex
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	"github.com/SarunasBucius/nutri-price-server/internal/model"
+	"github.com/SarunasBucius/nutri-price-server/internal/service/recipe/unitconv"
+	"github.com/SarunasBucius/nutri-price-server/internal/utils/umath"
+)
+
+const monthLayout = "2006-01"
+
+// basePeriodMonths is the length of the base period, which ends with the first month of the index.
+// Weights are shares of spending in the base period, as official price indexes use past expenditure.
+const basePeriodMonths = 12
+
+// Units unit prices are normalised to. A product is priced in the unit most of its purchases convert to.
+var units = []string{unitconv.Kilograms, unitconv.Liters, model.Pieces}
+
+// productMonths holds monthly spending and purchased quantity of a product in its unit.
+// Total is the spending over the index period and baseSpending the spending over the base period.
+type productMonths struct {
+	product      string
+	unit         string
+	spending     map[time.Time]float64
+	quantity     map[time.Time]float64
+	total        float64
+	baseSpending float64
+}
+
+func (p productMonths) unitPrice(month time.Time) (float64, bool) {
+	quantity := p.quantity[month]
+	if quantity <= 0 {
+		return 0, false
+	}
+	return p.spending[month] / quantity, true
+}
+
+// basketProduct is a product of the basket with its spending share.
+type basketProduct struct {
+	productMonths
+	weight float64
+}
+
+// BasePeriodStart returns the first month of the base period of an index starting with the from month.
+// Purchases from this month on are needed to calculate the index.
+func BasePeriodStart(from time.Time) time.Time {
+	return monthStart(from).AddDate(0, -(basePeriodMonths - 1), 0)
+}
+
+// Calculate calculates a chained price index of purchases between the first days of from and to months.
+// Each month the index changes by the weighted average change of unit prices of basket products bought that month,
+// compared to their last unit price. Weights are shares of basket spending in the base period, the 12 months up to
+// and including the from month, so basket products not bought in it do not change the index.
+// Categories are indexed separately when categoryByProduct is given.
+func Calculate(purchases []model.PurchasedProduct, from, to time.Time, request model.PriceIndexRequest,
+	categoryByProduct map[string]string) model.PriceIndex {
+	months := getMonths(from, to)
+	basket := selectBasket(groupByProduct(purchases, from, to), request)
+
+	index := model.PriceIndex{
+		From:       from.Format(monthLayout),
+		To:         to.Format(monthLayout),
+		Categories: []model.CategoryPriceIndex{},
+		Basket:     make([]model.PriceIndexProduct, 0, len(basket)),
+	}
+	index.Months, index.Inflation = chainIndex(months, basket)
+
+	productsByCategory := make(map[string][]basketProduct)
+	for _, product := range basket {
+		category := categoryByProduct[product.product]
+		if category != "" {
+			productsByCategory[category] = append(productsByCategory[category], product)
+		}
+
+		basketProduct := model.PriceIndexProduct{
+			Product:  product.product,
+			Category: category,
+			Unit:     product.unit,
+			Weight:   umath.RoundFloat(product.weight, 4),
+			Months:   len(product.quantity),
+		}
+		for _, month := range months {
+			if price, ok := product.unitPrice(month); ok {
+				if basketProduct.FirstUnitPrice == 0 {
+					basketProduct.FirstUnitPrice = umath.RoundFloat(price, 2)
+				}
+				basketProduct.LastUnitPrice = umath.RoundFloat(price, 2)
+			}
+		}
+		index.Basket = append(index.Basket, basketProduct)
+	}
+
+	for category, products := range productsByCategory {
+		categoryIndex := model.CategoryPriceIndex{Category: category}
+		categoryIndex.Months, categoryIndex.Inflation = chainIndex(months, products)
+		index.Categories = append(index.Categories, categoryIndex)
+	}
+	slices.SortFunc(index.Categories, func(a, b model.CategoryPriceIndex) int {
+		return cmp.Compare(a.Category, b.Category)
+	})
+
+	return index
+}
+
+// groupByProduct sums spending and quantities of purchases by product and month
+// and spending of the base period by product.
+func groupByProduct(purchases []model.PurchasedProduct, from, to time.Time) map[string]*productMonths {
+	baseFrom := BasePeriodStart(from)
+	purchasesByProduct := make(map[string][]model.PurchasedProduct)
+	for _, purchase := range purchases {
+		month := monthStart(purchase.Date)
+		if purchase.Date.IsZero() || month.Before(baseFrom) || month.After(to) || purchase.Price <= 0 {
+			continue
+		}
+		purchasesByProduct[purchase.Name] = append(purchasesByProduct[purchase.Name], purchase)
+	}
+
+	products := make(map[string]*productMonths, len(purchasesByProduct))
+	for name, productPurchases := range purchasesByProduct {
+		unit := getProductUnit(productPurchases)
+		if unit == "" {
+			continue
+		}
+
+		product := &productMonths{
+			product:  name,
+			unit:     unit,
+			spending: make(map[time.Time]float64),
+			quantity: make(map[time.Time]float64),
+		}
+		for _, purchase := range productPurchases {
+			quantity, _, err := unitconv.Convert(purchase.Quantity, unit, model.UnitConversion{})
+			if err != nil || quantity.Amount <= 0 {
+				continue
+			}
+			month := monthStart(purchase.Date)
+			if !month.After(monthStart(from)) {
+				product.baseSpending += purchase.Price
+			}
+			if month.Before(monthStart(from)) {
+				continue
+			}
+			product.spending[month] += purchase.Price
+			product.quantity[month] += quantity.Amount
+			product.total += purchase.Price
+		}
+		products[name] = product
+	}
+	return products
+}
+
+// getProductUnit returns the unit most of the purchases can be converted to without product specific factors.
+func getProductUnit(purchases []model.PurchasedProduct) string {
+	counts := make(map[string]int, len(units))
+	for _, purchase := range purchases {
+		for _, unit := range units {
+			if quantity, _, err := unitconv.Convert(purchase.Quantity, unit, model.UnitConversion{}); err == nil && quantity.Amount > 0 {
+				counts[unit]++
+				break
+			}
+		}
+	}
+
+	var productUnit string
+	for _, unit := range units {
+		if counts[unit] > counts[productUnit] {
+			productUnit = unit
+		}
+	}
+	return productUnit
+}
+
+// selectBasket selects listed products, or products bought in enough months with the largest spending.
+func selectBasket(products map[string]*productMonths, request model.PriceIndexRequest) []basketProduct {
+	var selected []*productMonths
+	if len(request.Products) > 0 {
+		for _, name := range request.Products {
+			if product, ok := products[name]; ok {
+				selected = append(selected, product)
+			}
+		}
+	} else {
+		for _, product := range products {
+			if len(product.quantity) >= request.MinMonths {
+				selected = append(selected, product)
+			}
+		}
+		slices.SortFunc(selected, func(a, b *productMonths) int {
+			if c := cmp.Compare(b.total, a.total); c != 0 {
+				return c
+			}
+			return cmp.Compare(a.product, b.product)
+		})
+		if request.Limit > 0 && len(selected) > request.Limit {
+			selected = selected[:request.Limit]
+		}
+	}
+
+	var baseTotal float64
+	for _, product := range selected {
+		baseTotal += product.baseSpending
+	}
+
+	basket := make([]basketProduct, 0, len(selected))
+	for _, product := range selected {
+		var weight float64
+		if baseTotal > 0 {
+			weight = product.baseSpending / baseTotal
+		}
+		basket = append(basket, basketProduct{productMonths: *product, weight: weight})
+	}
+	return basket
+}
+
+// chainIndex chains monthly changes of basket unit prices starting from 100 and returns the index and its
+// change over the whole period. A month without purchases of the basket products keeps the previous index.
+func chainIndex(months []time.Time, basket []basketProduct) ([]model.PriceIndexMonth, float64) {
+	indexMonths := make([]model.PriceIndexMonth, 0, len(months))
+	indexes := make([]float64, 0, len(months))
+	lastPrices := make([]float64, len(basket))
+	index := 100.0
+	for i, month := range months {
+		var weightedChange, weights float64
+		for j, product := range basket {
+			price, ok := product.unitPrice(month)
+			if !ok {
+				continue
+			}
+			if lastPrices[j] > 0 {
+				weightedChange += product.weight * price / lastPrices[j]
+				weights += product.weight
+			}
+			lastPrices[j] = price
+		}
+		if i > 0 && weights > 0 {
+			index *= weightedChange / weights
+		}
+		indexes = append(indexes, index)
+
+		indexMonth := model.PriceIndexMonth{Month: month.Format(monthLayout), Index: umath.RoundFloat(index, 2)}
+		if i > 0 {
+			indexMonth.MonthlyChange = getChange(indexes[i-1], index)
+		}
+		if i >= 12 {
+			indexMonth.AnnualChange = getChange(indexes[i-12], index)
+		}
+		indexMonths = append(indexMonths, indexMonth)
+	}
+	return indexMonths, umath.RoundFloat(index-100, 2)
+}
+
+func getChange(from, to float64) *float64 {
+	change := umath.RoundFloat((to/from-1)*100, 2)
+	return &change
+}
+
+func getMonths(from, to time.Time) []time.Time {
+	var months []time.Time
+	for month := monthStart(from); !month.After(to); month = month.AddDate(0, 1, 0) {
+		months = append(months, month)
+	}
+	return months
+}
+
+func monthStart(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
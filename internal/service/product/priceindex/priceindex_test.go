@@ -0,0 +1,78 @@
+package priceindex
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SarunasBucius/nutri-price-server/internal/model"
+	"github.com/SarunasBucius/nutri-price-server/internal/service/recipe/unitconv"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalculate(t *testing.T) {
+	date := func(month, day int) time.Time {
+		return time.Date(2025, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	}
+	grams := func(amount float64) model.Quantity {
+		return model.Quantity{Unit: model.Grams, Amount: amount}
+	}
+	purchases := []model.PurchasedProduct{
+		{Name: "milk", Price: 1, Quantity: model.Quantity{Unit: model.Milliliters, Amount: 1000}, Date: date(1, 5)},
+		{Name: "milk", Price: 1.1, Quantity: model.Quantity{Unit: unitconv.Liters, Amount: 1}, Date: date(2, 5)},
+		{Name: "milk", Price: 1.21, Quantity: model.Quantity{Unit: model.Milliliters, Amount: 1000}, Date: date(3, 5)},
+		{Name: "cheese", Price: 2, Quantity: grams(200), Date: date(1, 10)},
+		{Name: "cheese", Price: 3, Quantity: grams(300), Date: date(1, 20)},
+		{Name: "cheese", Price: 4, Quantity: grams(500), Date: date(3, 10)},
+		{Name: "candy", Price: 5, Quantity: grams(100), Date: date(2, 1)},
+		{Name: "milk", Price: 9, Quantity: grams(100), Date: date(4, 1)},
+		{Name: "milk", Price: 4, Quantity: model.Quantity{Unit: unitconv.Liters, Amount: 4}, Date: time.Date(2024, 12, 5, 0, 0, 0, 0, time.UTC)},
+		{Name: "cheese", Price: 1, Quantity: grams(100), Date: time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)},
+	}
+	from, to := date(1, 1), date(3, 1)
+	categories := map[string]string{"milk": "dairy", "cheese": "dairy"}
+
+	got := Calculate(purchases, from, to, model.PriceIndexRequest{MinMonths: 2}, categories)
+
+	require.Equal(t, "2025-01", got.From)
+	require.Equal(t, "2025-03", got.To)
+
+	var indexes []float64
+	for _, month := range got.Months {
+		indexes = append(indexes, month.Index)
+	}
+	// Weights come from spending from February 2024 to January 2025, 5 on milk and 5 on cheese.
+	// February has only milk, 10% more expensive. March: milk is 10% more expensive again and cheese 20% cheaper than in January.
+	require.Equal(t, []float64{100, 110, 104.5}, indexes)
+	require.Nil(t, got.Months[0].MonthlyChange)
+	require.Equal(t, 10.0, *got.Months[1].MonthlyChange)
+	require.Equal(t, 4.5, got.Inflation)
+
+	require.Len(t, got.Basket, 2)
+	require.Equal(t, model.PriceIndexProduct{
+		Product: "cheese", Category: "dairy", Unit: unitconv.Kilograms, Weight: 0.5, Months: 2,
+		FirstUnitPrice: 10, LastUnitPrice: 8,
+	}, got.Basket[0])
+	require.Equal(t, unitconv.Liters, got.Basket[1].Unit)
+
+	require.Len(t, got.Categories, 1)
+	require.Equal(t, "dairy", got.Categories[0].Category)
+	require.Equal(t, 4.5, got.Categories[0].Inflation)
+}
+
+func TestCalculate_selectedProducts(t *testing.T) {
+	purchases := []model.PurchasedProduct{
+		{Name: "bread", Price: 1, Quantity: model.Quantity{Unit: model.Pieces, Amount: 1}, Date: time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC)},
+		{Name: "bread", Price: 1.5, Quantity: model.Quantity{Unit: model.Pieces, Amount: 1}, Date: time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)},
+		{Name: "eggs", Price: 3, Quantity: model.Quantity{Unit: model.Pieces, Amount: 10}, Date: time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	got := Calculate(purchases, time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC),
+		model.PriceIndexRequest{Products: []string{"bread"}}, nil)
+
+	require.Len(t, got.Months, 13)
+	require.Equal(t, 150.0, got.Months[12].Index)
+	require.Equal(t, 50.0, *got.Months[12].AnnualChange)
+	require.Equal(t, 0.0, *got.Months[11].MonthlyChange)
+	require.Len(t, got.Basket, 1)
+	require.Empty(t, got.Categories)
+}
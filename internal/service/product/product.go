@@ -4,42 +4,62 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"strings"
 	"time"
 
 	"github.com/SarunasBucius/nutri-price-server/internal/model"
+	"github.com/SarunasBucius/nutri-price-server/internal/utils/uerror"
+	"github.com/SarunasBucius/nutri-price-server/internal/utils/umath"
 )
 
 type Service struct {
 	ProductRepo          IProductRepository
 	ReceiptRepo          IReceiptRepository
 	NutritionalValueRepo INutritionalValueRepository
+	UnitConversionRepo   IUnitConversionRepository
+	BudgetAlerter        IBudgetAlerter
 }
 
-func NewProductService(productRepo IProductRepository, receiptRepo IReceiptRepository, nutritionalValueRepo INutritionalValueRepository) *Service {
+func NewProductService(productRepo IProductRepository, receiptRepo IReceiptRepository, nutritionalValueRepo INutritionalValueRepository, unitConversionRepo IUnitConversionRepository, budgetAlerter IBudgetAlerter) *Service {
 	return &Service{
 		ProductRepo:          productRepo,
 		ReceiptRepo:          receiptRepo,
 		NutritionalValueRepo: nutritionalValueRepo,
+		UnitConversionRepo:   unitConversionRepo,
+		BudgetAlerter:        budgetAlerter,
 	}
 }
 
 type IProductRepository interface {
-	InsertPurchases(ctx context.Context, retailer string, receiptDate time.Time, products []model.PurchasedProductNew) error
+	InsertPurchases(ctx context.Context, receiptID, retailer string, receiptDate time.Time, products []model.PurchasedProductNew) error
 	InsertProducts(ctx context.Context, productNames []string) error
 	GetProductIDsByName(ctx context.Context, productNames []string) (map[string]string, error)
+	UpsertProductCodes(ctx context.Context, retailer string, products []model.PurchasedProductNew) error
+	GetProductByCode(ctx context.Context, code string) (model.BarcodeProduct, error)
+	GetPurchasesByNamesOrGroups(ctx context.Context, productNames []string) ([]model.PurchasedProduct, error)
+	GetPurchasesByDateRange(ctx context.Context, from, to time.Time, filter model.ProductFilter) ([]model.PurchasedProduct, error)
+	GetProductRootCategories(ctx context.Context) (map[string]string, error)
 }
 
 type IReceiptRepository interface {
-	SetRawReceiptSubmittedProducts(ctx context.Context, receiptDate time.Time, submittedProducts []model.PurchasedProductNew) error
+	SetRawReceiptSubmittedProducts(ctx context.Context, receiptID string, submittedProducts []model.PurchasedProductNew) error
 	UpdateProductNameAlias(ctx context.Context, editedNameByParsedName map[string]model.ProductAndVarietyName) error
-	ConfirmReceipts(ctx context.Context, retailer, date string) error
+	IsReceiptConfirmed(ctx context.Context, receiptID string) (bool, error)
 }
 
 type INutritionalValueRepository interface {
 	InsertEmptyProducts(ctx context.Context, products []string) error
 }
 
-func (s *Service) InsertProducts(ctx context.Context, retailer, receiptDate string, purchases []model.PurchasedProductNew) error {
+type IBudgetAlerter interface {
+	CheckBudgetAlerts(ctx context.Context, receiptID string, receiptDate time.Time) ([]model.BudgetAlert, error)
+}
+
+type IUnitConversionRepository interface {
+	GetUnitConversionsByProducts(ctx context.Context, products []string) (map[string]model.UnitConversion, error)
+}
+
+func (s *Service) InsertProducts(ctx context.Context, receiptID, retailer, receiptDate string, purchases []model.PurchasedProductNew) error {
 	date, err := time.Parse(time.DateOnly, receiptDate)
 	if err != nil {
 		return err
@@ -61,6 +81,9 @@ func (s *Service) InsertProducts(ctx context.Context, retailer, receiptDate stri
 	}
 
 	for i, product := range purchases {
+		if product.FullPrice == 0 {
+			purchases[i].FullPrice = umath.RoundFloat(product.Price+product.Discount, 2)
+		}
 		if id, ok := productIDs[product.Name]; ok {
 			purchases[i].ProductID = id
 			continue
@@ -69,18 +92,34 @@ func (s *Service) InsertProducts(ctx context.Context, retailer, receiptDate stri
 
 	}
 
-	if err := s.ProductRepo.InsertPurchases(ctx, retailer, date, purchases); err != nil {
+	if err := s.ProductRepo.InsertPurchases(ctx, receiptID, retailer, date, purchases); err != nil {
 		return fmt.Errorf("insert purchases: %w", err)
 	}
 
-	if err := s.ReceiptRepo.SetRawReceiptSubmittedProducts(ctx, date, purchases); err != nil {
+	if err := s.ProductRepo.UpsertProductCodes(ctx, retailer, purchases); err != nil {
+		return fmt.Errorf("upsert product codes: %w", err)
+	}
+
+	if err := s.ReceiptRepo.SetRawReceiptSubmittedProducts(ctx, receiptID, purchases); err != nil {
 		slog.ErrorContext(ctx, "set raw receipt submitted products", "error", err)
 	}
 
 	return nil
 }
 
-func (s *Service) ConfirmPurchasedProducts(ctx context.Context, retailer, receiptDate string, products []model.PurchasedProductNew) error {
+func (s *Service) ConfirmPurchasedProducts(ctx context.Context, receiptID, retailer, receiptDate string, products []model.PurchasedProductNew) error {
+	if receiptID == "" {
+		return uerror.NewBadRequest("missing receipt id", nil)
+	}
+
+	isConfirmed, err := s.ReceiptRepo.IsReceiptConfirmed(ctx, receiptID)
+	if err != nil {
+		return fmt.Errorf("check if receipt is confirmed: %w", err)
+	}
+	if isConfirmed {
+		return uerror.NewConflict(fmt.Sprintf("receipt with id %q is already confirmed", receiptID), nil)
+	}
+
 	editedNameByParsedName := make(map[string]model.ProductAndVarietyName, len(products))
 	for _, product := range products {
 		editedNameByParsedName[product.ParsedName] = model.ProductAndVarietyName{
@@ -89,7 +128,7 @@ func (s *Service) ConfirmPurchasedProducts(ctx context.Context, retailer, receip
 		}
 	}
 
-	if err := s.InsertProducts(ctx, retailer, receiptDate, products); err != nil {
+	if err := s.InsertProducts(ctx, receiptID, retailer, receiptDate, products); err != nil {
 		return fmt.Errorf("insert products: %w", err)
 	}
 
@@ -97,8 +136,32 @@ func (s *Service) ConfirmPurchasedProducts(ctx context.Context, retailer, receip
 		slog.ErrorContext(ctx, "update product name alias", "error", err)
 	}
 
-	if err := s.ReceiptRepo.ConfirmReceipts(ctx, retailer, receiptDate); err != nil {
-		return fmt.Errorf("confirm receipt: %w", err)
+	// Products are already confirmed, so failing budget checks are only logged.
+	date, err := time.Parse(time.DateOnly, receiptDate)
+	if err == nil {
+		_, err = s.BudgetAlerter.CheckBudgetAlerts(ctx, receiptID, date)
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "check budget alerts", "error", err)
 	}
 	return nil
 }
+
+// GetProductByBarcode returns the product last confirmed with the scanned barcode.
+// UPC-A barcodes are looked up in their EAN-13 form, as printed on receipts.
+// Retailer article codes are not barcodes, so they cannot be looked up.
+func (s *Service) GetProductByBarcode(ctx context.Context, barcode string) (model.BarcodeProduct, error) {
+	barcode = strings.TrimSpace(barcode)
+	if len(barcode) == 12 {
+		barcode = "0" + barcode
+	}
+	if !model.IsEAN(barcode) {
+		return model.BarcodeProduct{}, uerror.NewBadRequest("barcode must be a valid EAN-8, EAN-13 or UPC-A code", nil)
+	}
+
+	product, err := s.ProductRepo.GetProductByCode(ctx, barcode)
+	if err != nil {
+		return model.BarcodeProduct{}, fmt.Errorf("get product by code: %w", err)
+	}
+	return product, nil
+}
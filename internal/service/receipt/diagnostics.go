@@ -0,0 +1,74 @@
+package receipt
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/SarunasBucius/nutri-price-server/internal/model"
+	"github.com/SarunasBucius/nutri-price-server/internal/utils/umath"
+)
+
+// sumTolerance absorbs rounding of per-line prices that were calculated from discounts.
+const sumTolerance = 0.01
+
+// newReceiptDiagnostics compares parsed products against the totals printed on the receipt.
+func newReceiptDiagnostics(products model.ReceiptProducts, totals model.ReceiptTotals, skippedLines []string) model.ReceiptDiagnostics {
+	linesSum := umath.RoundFloat(products.GetPriceSum(), 2)
+	diagnostics := model.ReceiptDiagnostics{
+		LinesSum:     linesSum,
+		Total:        totals.Total,
+		Difference:   umath.RoundFloat(linesSum-totals.Total, 2),
+		SkippedLines: skippedLines,
+		Warnings:     getProductWarnings(products),
+	}
+	if diagnostics.SkippedLines == nil {
+		diagnostics.SkippedLines = []string{}
+	}
+
+	if totals.Total == 0 {
+		diagnostics.Difference = 0
+		diagnostics.Warnings = append(diagnostics.Warnings, model.ReceiptWarning{Message: "receipt total not found"})
+		return diagnostics
+	}
+
+	if math.Abs(diagnostics.Difference) > sumTolerance {
+		diagnostics.Warnings = append(diagnostics.Warnings, model.ReceiptWarning{
+			Message: fmt.Sprintf("sum of products %.2f differs from receipt total %.2f", linesSum, totals.Total),
+		})
+	}
+
+	if totals.Paid != 0 && math.Abs(totals.Paid-totals.Total) > sumTolerance {
+		diagnostics.Warnings = append(diagnostics.Warnings, model.ReceiptWarning{
+			Message: fmt.Sprintf("paid sum %.2f differs from receipt total %.2f", totals.Paid, totals.Total),
+		})
+	}
+
+	if vatGross := getVATGrossSum(totals.VAT); vatGross != 0 && math.Abs(vatGross-totals.Total) > sumTolerance {
+		diagnostics.Warnings = append(diagnostics.Warnings, model.ReceiptWarning{
+			Message: fmt.Sprintf("sum of VAT breakdown %.2f differs from receipt total %.2f", vatGross, totals.Total),
+		})
+	}
+
+	return diagnostics
+}
+
+func getProductWarnings(products model.ReceiptProducts) []model.ReceiptWarning {
+	warnings := make([]model.ReceiptWarning, 0)
+	for _, product := range products {
+		if product.Price <= 0 {
+			warnings = append(warnings, model.ReceiptWarning{
+				Product: product.VarietyName,
+				Message: "price is not positive, discount might be applied twice",
+			})
+		}
+	}
+	return warnings
+}
+
+func getVATGrossSum(vatAmounts []model.VATAmount) float64 {
+	var sum float64
+	for _, vat := range vatAmounts {
+		sum += vat.Gross
+	}
+	return umath.RoundFloat(sum, 2)
+}
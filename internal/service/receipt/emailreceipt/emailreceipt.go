@@ -0,0 +1,159 @@
+package emailreceipt
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"strings"
+
+	"golang.org/x/net/html/charset"
+)
+
+// Email is a receipt received as an RFC 822 message.
+type Email struct {
+	MessageID string
+	Receipt   string
+}
+
+type bodyPart struct {
+	mediaType string
+	body      string
+}
+
+// Parse reads the raw message and returns its Message-ID and receipt text.
+// Plain text bodies are preferred, HTML bodies are converted to text otherwise.
+func Parse(rawEmail []byte) (Email, error) {
+	message, err := mail.ReadMessage(bytes.NewReader(rawEmail))
+	if err != nil {
+		return Email{}, fmt.Errorf("read message: %w", err)
+	}
+
+	parts, err := readParts(textproto.MIMEHeader(message.Header), message.Body)
+	if err != nil {
+		return Email{}, fmt.Errorf("read message parts: %w", err)
+	}
+
+	receipt, err := getReceiptText(parts)
+	if err != nil {
+		return Email{}, err
+	}
+
+	return Email{
+		MessageID: strings.Trim(strings.TrimSpace(message.Header.Get("Message-ID")), "<>"),
+		Receipt:   receipt,
+	}, nil
+}
+
+func getReceiptText(parts []bodyPart) (string, error) {
+	var html string
+	for _, part := range parts {
+		switch part.mediaType {
+		case "text/plain":
+			if strings.TrimSpace(part.body) != "" {
+				return normaliseText(part.body), nil
+			}
+		case "text/html":
+			if html == "" {
+				html = part.body
+			}
+		}
+	}
+
+	if html == "" {
+		return "", errors.New("message has no text or html body")
+	}
+
+	receipt, err := htmlToText(html)
+	if err != nil {
+		return "", fmt.Errorf("convert html to text: %w", err)
+	}
+	return receipt, nil
+}
+
+// readParts returns decoded text parts of the message, descending into nested multiparts.
+func readParts(header textproto.MIMEHeader, body io.Reader) ([]bodyPart, error) {
+	mediaType, params, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		mediaType = "text/plain"
+	}
+
+	if !strings.HasPrefix(mediaType, "multipart/") {
+		if !strings.HasPrefix(mediaType, "text/") || isAttachment(header) {
+			return nil, nil
+		}
+		decodedBody := decodeCharset(params["charset"], decodeTransferEncoding(header.Get("Content-Transfer-Encoding"), body))
+		decoded, err := io.ReadAll(decodedBody)
+		if err != nil {
+			return nil, fmt.Errorf("decode %s body: %w", mediaType, err)
+		}
+		return []bodyPart{{mediaType: mediaType, body: string(decoded)}}, nil
+	}
+
+	var parts []bodyPart
+	reader := multipart.NewReader(body, params["boundary"])
+	for {
+		part, err := reader.NextRawPart()
+		if errors.Is(err, io.EOF) {
+			return parts, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read next part: %w", err)
+		}
+
+		nestedParts, err := readParts(part.Header, part)
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, nestedParts...)
+	}
+}
+
+func decodeTransferEncoding(encoding string, body io.Reader) io.Reader {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "quoted-printable":
+		return quotedprintable.NewReader(body)
+	case "base64":
+		return base64.NewDecoder(base64.StdEncoding, body)
+	default:
+		return body
+	}
+}
+
+// decodeCharset converts the body to UTF-8, as Lithuanian mailers often send windows-1257 or ISO-8859-13 text.
+// Bodies in unknown charsets are read as is.
+func decodeCharset(label string, body io.Reader) io.Reader {
+	if label == "" {
+		return body
+	}
+	decoded, err := charset.NewReaderLabel(label, body)
+	if err != nil {
+		return body
+	}
+	return decoded
+}
+
+func isAttachment(header textproto.MIMEHeader) bool {
+	disposition, _, err := mime.ParseMediaType(header.Get("Content-Disposition"))
+	return err == nil && disposition == "attachment"
+}
+
+// normaliseText trims trailing spaces and drops empty lines, as in pasted receipts.
+func normaliseText(text string) string {
+	text = strings.ReplaceAll(text, "\r", "")
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimRight(line, " \t")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
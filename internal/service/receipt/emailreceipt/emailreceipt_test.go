@@ -0,0 +1,118 @@
+package emailreceipt
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const quotedPrintableEmail = `From: kvitai@rimi.lt
+To: me@example.com
+Subject: =?UTF-8?Q?J=C5=ABs=C5=B3_kvitas?=
+Message-ID: <20240514183211.0123@rimi.lt>
+MIME-Version: 1.0
+Content-Type: text/plain; charset=UTF-8
+Content-Transfer-Encoding: quoted-printable
+
+UAB "RIMI LIETUVA"
+Pienas ROKI=C5=A0KIO NAMINIS 2,5%, 1 l        1,39 A
+
+2024-05-14 18:32:11
+`
+
+const windows1257Email = `From: kvitai@norfa.lt
+Message-ID: <norfa-receipt@norfa.lt>
+MIME-Version: 1.0
+Content-Type: text/plain; charset=windows-1257
+Content-Transfer-Encoding: quoted-printable
+
+UAB NORFOS MA=DEMENA
+Pienas ROKI=D0KIO NAMINIS 2,5%, 1 l 1,39 M1
+`
+
+const multipartEmail = `From: kvitai@rimi.lt
+Message-ID: <html-receipt@rimi.lt>
+MIME-Version: 1.0
+Content-Type: multipart/mixed; boundary="outer"
+
+--outer
+Content-Type: multipart/alternative; boundary="inner"
+
+--inner
+Content-Type: text/plain; charset=UTF-8
+
+--inner
+Content-Type: text/html; charset=UTF-8
+Content-Transfer-Encoding: base64
+
+PGh0bWw+PGhlYWQ+PHRpdGxlPkt2aXRhczwvdGl0bGU+PHN0eWxlPnRke2NvbG9yOnJlZH08L3N0
+eWxlPjwvaGVhZD48Ym9keT4KPHRhYmxlPgo8dHI+PHRkPlVBQiAiUklNSSBMSUVUVVZBIjwvdGQ+
+PC90cj4KPHRyPjx0ZD5QaWVuYXMgUk9LScWgS0lPIE5BTUlOSVMgMiw1JSwgMSZuYnNwO2w8L3Rk
+Pjx0ZD4xLDM5IEE8L3RkPjwvdHI+Cjx0cj48dGQ+MjAyNC0wNS0xNCAxODozMjoxMTwvdGQ+PC90
+cj4KPC90YWJsZT4KPC9ib2R5PjwvaHRtbD4=
+--inner--
+--outer
+Content-Type: text/plain; charset=UTF-8
+Content-Disposition: attachment; filename="terms.txt"
+
+Terms and conditions
+--outer--
+`
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		rawEmail string
+		want     Email
+		wantErr  bool
+	}{
+		{
+			name:     "quoted_printable_text",
+			rawEmail: quotedPrintableEmail,
+			want: Email{
+				MessageID: "20240514183211.0123@rimi.lt",
+				Receipt:   "UAB \"RIMI LIETUVA\"\nPienas ROKIŠKIO NAMINIS 2,5%, 1 l        1,39 A\n2024-05-14 18:32:11",
+			},
+		},
+		{
+			name:     "windows_1257_text",
+			rawEmail: windows1257Email,
+			want: Email{
+				MessageID: "norfa-receipt@norfa.lt",
+				Receipt:   "UAB NORFOS MAŽMENA\nPienas ROKIŠKIO NAMINIS 2,5%, 1 l 1,39 M1",
+			},
+		},
+		{
+			name:     "base64_html_in_nested_multipart",
+			rawEmail: multipartEmail,
+			want: Email{
+				MessageID: "html-receipt@rimi.lt",
+				Receipt:   "UAB \"RIMI LIETUVA\"\nPienas ROKIŠKIO NAMINIS 2,5%, 1 l 1,39 A\n2024-05-14 18:32:11",
+			},
+		},
+		{
+			name:     "no_text_body",
+			rawEmail: "Message-ID: <image@example.com>\nContent-Type: image/png\n\nPNG",
+			wantErr:  true,
+		},
+		{
+			name:     "not_an_email",
+			rawEmail: "UAB \"RIMI LIETUVA\"",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rawEmail := strings.ReplaceAll(tt.rawEmail, "\n", "\r\n")
+			got, err := Parse([]byte(rawEmail))
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
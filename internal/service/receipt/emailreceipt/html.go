@@ -0,0 +1,103 @@
+package emailreceipt
+
+import (
+	"errors"
+	"io"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// htmlToText returns visible text of the HTML body. Table rows and block elements
+// start new lines, table cells of the same row are separated by a space.
+// Source line breaks are kept inside pre elements only.
+func htmlToText(body string) (string, error) {
+	tokenizer := html.NewTokenizer(strings.NewReader(body))
+
+	var text strings.Builder
+	skipDepth := 0
+	preDepth := 0
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			if err := tokenizer.Err(); !errors.Is(err, io.EOF) {
+				return "", err
+			}
+			return collapseLines(text.String()), nil
+		case html.StartTagToken:
+			tag := getTag(tokenizer)
+			if isHidden(tag) {
+				skipDepth++
+			}
+			if tag == atom.Pre {
+				preDepth++
+			}
+			writeSeparator(&text, tag)
+		case html.SelfClosingTagToken:
+			writeSeparator(&text, getTag(tokenizer))
+		case html.EndTagToken:
+			tag := getTag(tokenizer)
+			if isHidden(tag) && skipDepth > 0 {
+				skipDepth--
+			}
+			if tag == atom.Pre && preDepth > 0 {
+				preDepth--
+			}
+			writeSeparator(&text, tag)
+		case html.TextToken:
+			if skipDepth > 0 {
+				continue
+			}
+			if preDepth > 0 {
+				preSpacesReplacer.WriteString(&text, string(tokenizer.Text()))
+				continue
+			}
+			spacesReplacer.WriteString(&text, string(tokenizer.Text()))
+		}
+	}
+}
+
+func getTag(tokenizer *html.Tokenizer) atom.Atom {
+	name, _ := tokenizer.TagName()
+	return atom.Lookup(name)
+}
+
+func isHidden(tag atom.Atom) bool {
+	switch tag {
+	case atom.Head, atom.Script, atom.Style, atom.Title:
+		return true
+	default:
+		return false
+	}
+}
+
+func writeSeparator(text *strings.Builder, tag atom.Atom) {
+	switch tag {
+	case atom.Br, atom.P, atom.Div, atom.Tr, atom.Li, atom.Table, atom.Pre,
+		atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+		text.WriteString("\n")
+	case atom.Td, atom.Th:
+		text.WriteString(" ")
+	}
+}
+
+// spacesReplacer turns source line breaks and non-breaking spaces into plain spaces,
+// as only tags break lines in rendered HTML.
+var spacesReplacer = strings.NewReplacer("\r", " ", "\n", " ", "\u00a0", " ")
+
+// preSpacesReplacer keeps source line breaks, as pre elements are rendered as written.
+var preSpacesReplacer = strings.NewReplacer("\r", "", "\u00a0", " ")
+
+// collapseLines collapses whitespace runs in every line and drops empty lines.
+func collapseLines(text string) string {
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.Join(strings.Fields(line), " ")
+		if line == "" {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
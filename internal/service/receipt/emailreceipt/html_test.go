@@ -0,0 +1,43 @@
+package emailreceipt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_htmlToText(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{
+			name: "table_rows_to_lines",
+			body: "<table><tr><td>Pienas</td><td>1,39 A</td></tr><tr><th>Iš viso</th><th>1,39</th></tr></table>",
+			want: "Pienas 1,39 A\nIš viso 1,39",
+		},
+		{
+			name: "hidden_elements_skipped",
+			body: "<head><style>p{}</style></head><script>var a = 1;</script><p>Kvitas&nbsp;0123</p>",
+			want: "Kvitas 0123",
+		},
+		{
+			name: "line_breaks_and_source_whitespace",
+			body: "<div>Bananai,\n  1 kg<br/>0,856 kg x 1,19</div>",
+			want: "Bananai, 1 kg\n0,856 kg x 1,19",
+		},
+		{
+			name: "pre_keeps_line_breaks",
+			body: "<p>Kvitas</p><pre>Pienas        1,39 A\r\nDuona         0,99 A\n</pre><p>Ačiū</p>",
+			want: "Kvitas\nPienas 1,39 A\nDuona 0,99 A\nAčiū",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := htmlToText(tt.body)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
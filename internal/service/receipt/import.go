@@ -0,0 +1,214 @@
+package receipt
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/SarunasBucius/nutri-price-server/internal/model"
+	"github.com/SarunasBucius/nutri-price-server/internal/service/receipt/retailer"
+	"github.com/SarunasBucius/nutri-price-server/internal/utils/uerror"
+)
+
+// receiptFileExtensions are the file types picked up by bulk import, other files are ignored.
+var receiptFileExtensions = []string{".txt", ".pdf", ".eml"}
+
+// Limits of uncompressed zip contents, so a small archive cannot expand into more than the server can hold in memory.
+const (
+	maxZipEntrySize = 20 << 20
+	maxZipSize      = 500 << 20
+)
+
+// ImportReceipts processes receipt files, at most concurrency of them at a time, and reports the outcome per file.
+// Importing the same files again reports them as duplicates, as stored receipts are keyed by their contents.
+func (s *Service) ImportReceipts(ctx context.Context, files []model.ReceiptFile, retailerHint string, concurrency int) model.ReceiptImportReport {
+	results := make([]model.ReceiptImportResult, len(files))
+
+	semaphore := make(chan struct{}, max(concurrency, 1))
+	var wg sync.WaitGroup
+	for i, file := range files {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+			// Panics in goroutines are not caught by the router, so a parser bug would crash the server.
+			defer func() {
+				if r := recover(); r != nil {
+					slog.ErrorContext(ctx, "import receipt panicked", "file", file.Name, "panic", r, "stack", string(debug.Stack()))
+					results[i] = model.ReceiptImportResult{
+						File:   file.Name,
+						Status: model.ImportStatusParseError,
+						Error:  fmt.Sprintf("unexpected error: %v", r),
+					}
+				}
+			}()
+			results[i] = s.importReceipt(ctx, file, retailerHint)
+		}()
+	}
+	wg.Wait()
+
+	report := model.ReceiptImportReport{Results: results}
+	for _, result := range results {
+		switch result.Status {
+		case model.ImportStatusParsed:
+			report.Parsed++
+		case model.ImportStatusDuplicate:
+			report.Duplicate++
+		case model.ImportStatusUnknownRetailer:
+			report.UnknownRetailer++
+		case model.ImportStatusParseError:
+			report.ParseError++
+		}
+	}
+	return report
+}
+
+// ImportReceiptZip imports receipt files of the zip archive.
+func (s *Service) ImportReceiptZip(ctx context.Context, archive []byte, retailerHint string, concurrency int) (model.ReceiptImportReport, error) {
+	files, err := ReadReceiptZip(archive)
+	var apiErr *uerror.APIError
+	if errors.As(err, &apiErr) {
+		return model.ReceiptImportReport{}, err
+	}
+	if err != nil {
+		return model.ReceiptImportReport{}, uerror.NewBadRequest("invalid zip archive", err)
+	}
+	return s.ImportReceipts(ctx, files, retailerHint, concurrency), nil
+}
+
+func (s *Service) importReceipt(ctx context.Context, file model.ReceiptFile, retailerHint string) model.ReceiptImportResult {
+	var processed model.ParseReceiptFromTextResponse
+	var err error
+	switch strings.ToLower(filepath.Ext(file.Name)) {
+	case ".pdf":
+		processed, err = s.ProcessPDFReceipt(ctx, file.Content, retailerHint)
+	case ".eml":
+		processed, err = s.ProcessEmailReceipt(ctx, file.Content, retailerHint)
+	default:
+		processed, err = s.ProcessReceipt(ctx, string(file.Content), retailerHint)
+	}
+
+	result := model.ReceiptImportResult{
+		File:      file.Name,
+		ReceiptID: processed.ReceiptID,
+		Retailer:  processed.Retailer,
+	}
+	switch {
+	case errors.Is(err, retailer.ErrUnknownRetailer):
+		result.Status = model.ImportStatusUnknownRetailer
+		result.Error = err.Error()
+	case errors.Is(err, ErrEmailAlreadyProcessed):
+		result.Status = model.ImportStatusDuplicate
+	case err != nil:
+		result.Status = model.ImportStatusParseError
+		result.Error = err.Error()
+	case processed.ReceiptID == "":
+		result.Status = model.ImportStatusParseError
+		result.Error = "receipt was parsed but not stored"
+	case processed.AlreadyStored:
+		result.Status = model.ImportStatusDuplicate
+	default:
+		result.Status = model.ImportStatusParsed
+	}
+	return result
+}
+
+// ReadReceiptZip returns receipt files of the zip archive.
+// Archives with a file above maxZipEntrySize or contents above maxZipSize are rejected as bad requests.
+func ReadReceiptZip(archive []byte) ([]model.ReceiptFile, error) {
+	return readReceiptZip(archive, maxZipEntrySize, maxZipSize)
+}
+
+func readReceiptZip(archive []byte, maxEntrySize, maxSize int64) ([]model.ReceiptFile, error) {
+	reader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		return nil, fmt.Errorf("open zip: %w", err)
+	}
+
+	var files []model.ReceiptFile
+	remaining := maxSize
+	for _, zipFile := range reader.File {
+		if zipFile.FileInfo().IsDir() || !isReceiptFile(zipFile.Name) {
+			continue
+		}
+
+		content, err := readZipFile(zipFile, min(maxEntrySize, remaining))
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", zipFile.Name, err)
+		}
+		if int64(len(content)) > maxEntrySize {
+			message := fmt.Sprintf("file %s is larger than %d bytes", zipFile.Name, maxEntrySize)
+			return nil, uerror.NewBadRequest(message, nil)
+		}
+		if int64(len(content)) > remaining {
+			message := fmt.Sprintf("zip contents are larger than %d bytes", maxSize)
+			return nil, uerror.NewBadRequest(message, nil)
+		}
+		remaining -= int64(len(content))
+		files = append(files, model.ReceiptFile{Name: zipFile.Name, Content: content})
+	}
+	return files, nil
+}
+
+// readZipFile reads at most limit+1 bytes of the file, so callers can tell that the file exceeds the limit.
+// The size in the zip header is not trusted, as it is set by whoever made the archive.
+func readZipFile(zipFile *zip.File, limit int64) ([]byte, error) {
+	file, err := zipFile.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return io.ReadAll(io.LimitReader(file, limit+1))
+}
+
+// ReadReceiptDir returns receipt files of the directory and its subdirectories.
+// File names are relative to dir.
+func ReadReceiptDir(dir string) ([]model.ReceiptFile, error) {
+	var files []model.ReceiptFile
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		name, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || !isReceiptFile(name) {
+			return nil
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		files = append(files, model.ReceiptFile{Name: name, Content: content})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("walk %s: %w", dir, err)
+	}
+	return files, nil
+}
+
+// isReceiptFile skips hidden files, such as macOS metadata added to zip archives.
+func isReceiptFile(path string) bool {
+	for _, part := range strings.Split(filepath.ToSlash(path), "/") {
+		if strings.HasPrefix(part, ".") || strings.HasPrefix(part, "__MACOSX") {
+			return false
+		}
+	}
+	return slices.Contains(receiptFileExtensions, strings.ToLower(filepath.Ext(path)))
+}
@@ -0,0 +1,125 @@
+package receipt
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/SarunasBucius/nutri-price-server/internal/model"
+	"github.com/SarunasBucius/nutri-price-server/internal/utils/uerror"
+	"github.com/stretchr/testify/require"
+)
+
+type zipEntry struct {
+	name    string
+	content []byte
+}
+
+func newZip(t *testing.T, entries ...zipEntry) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	writer := zip.NewWriter(&buf)
+	for _, entry := range entries {
+		file, err := writer.Create(entry.name)
+		require.NoError(t, err)
+		_, err = file.Write(entry.content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+	return buf.Bytes()
+}
+
+func TestReadReceiptZip(t *testing.T) {
+	tests := []struct {
+		name         string
+		entries      []zipEntry
+		maxEntrySize int64
+		maxSize      int64
+		want         []model.ReceiptFile
+		wantErr      bool
+	}{
+		{
+			name: "read_receipt_files",
+			entries: []zipEntry{
+				{name: "2024/rimi.txt", content: []byte("rimi")},
+				{name: "__MACOSX/2024/._rimi.txt", content: []byte("metadata")},
+				{name: "notes.md", content: []byte("notes")},
+			},
+			maxEntrySize: 10,
+			maxSize:      10,
+			want:         []model.ReceiptFile{{Name: "2024/rimi.txt", Content: []byte("rimi")}},
+		},
+		{
+			name:         "file_above_entry_limit",
+			entries:      []zipEntry{{name: "rimi.txt", content: []byte("receipt text")}},
+			maxEntrySize: 10,
+			maxSize:      100,
+			wantErr:      true,
+		},
+		{
+			name: "files_above_total_limit",
+			entries: []zipEntry{
+				{name: "rimi.txt", content: []byte("receipt")},
+				{name: "lidl.txt", content: []byte("receipt")},
+			},
+			maxEntrySize: 10,
+			maxSize:      10,
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := readReceiptZip(newZip(t, tt.entries...), tt.maxEntrySize, tt.maxSize)
+			if tt.wantErr {
+				var apiErr *uerror.APIError
+				require.True(t, errors.As(err, &apiErr))
+				require.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestService_ImportReceiptZip_ZipBomb(t *testing.T) {
+	archive := newZip(t, zipEntry{name: "bomb.txt", content: make([]byte, maxZipEntrySize+1)})
+	require.Less(t, len(archive), 1<<20)
+
+	s := NewReceiptService(&fakeReceiptRepo{}, nil)
+	_, err := s.ImportReceiptZip(context.Background(), archive, "", 1)
+
+	var apiErr *uerror.APIError
+	require.True(t, errors.As(err, &apiErr))
+	require.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+	require.Contains(t, apiErr.ConsumerMessage, "bomb.txt")
+}
+
+// panickingReceiptRepo panics when a receipt is stored, as a bug in receipt processing would.
+type panickingReceiptRepo struct {
+	fakeReceiptRepo
+}
+
+func (p *panickingReceiptRepo) InsertRawReceipt(context.Context, model.RawReceipt) (string, bool, error) {
+	panic("unexpected receipt")
+}
+
+func TestService_ImportReceipts_Panic(t *testing.T) {
+	s := NewReceiptService(&panickingReceiptRepo{}, nil)
+	files := []model.ReceiptFile{
+		{Name: "rimi.txt", Content: []byte(rimiReceipt)},
+		{Name: "rimi-copy.txt", Content: []byte(rimiReceipt)},
+	}
+
+	report := s.ImportReceipts(context.Background(), files, "", 2)
+
+	require.Equal(t, 2, report.ParseError)
+	for _, result := range report.Results {
+		require.Equal(t, model.ImportStatusParseError, result.Status)
+		require.Contains(t, result.Error, "unexpected receipt")
+	}
+}
@@ -0,0 +1,64 @@
+package receipt
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// PollMaildir processes emails in the new and cur folders of the Maildir every interval until ctx is done.
+// Messages are left in place, already processed ones are skipped by Message-ID.
+func (s *Service) PollMaildir(ctx context.Context, dir string, interval time.Duration) {
+	handled := make(map[string]bool)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		s.scanMaildir(ctx, dir, handled)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// scanMaildir processes messages not yet handled during this run. Handled messages are remembered,
+// so failing ones are not logged and messages without Message-ID are not reprocessed on every scan.
+func (s *Service) scanMaildir(ctx context.Context, dir string, handled map[string]bool) {
+	for _, folder := range []string{"new", "cur"} {
+		entries, err := os.ReadDir(filepath.Join(dir, folder))
+		if err != nil {
+			slog.ErrorContext(ctx, "read maildir folder", "folder", folder, "error", err)
+			continue
+		}
+
+		for _, entry := range entries {
+			if entry.IsDir() || handled[entry.Name()] {
+				continue
+			}
+
+			path := filepath.Join(dir, folder, entry.Name())
+			rawEmail, err := os.ReadFile(path)
+			if err != nil {
+				slog.ErrorContext(ctx, "read maildir message", "path", path, "error", err)
+				continue
+			}
+
+			processed, err := s.ProcessEmailReceipt(ctx, rawEmail, "")
+			handled[entry.Name()] = true
+			if errors.Is(err, ErrEmailAlreadyProcessed) {
+				continue
+			}
+			if err != nil {
+				slog.ErrorContext(ctx, "process maildir message", "path", path, "error", err)
+				continue
+			}
+			slog.InfoContext(ctx, "processed maildir message", "path", path, "receiptID", processed.ReceiptID)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package namematch
+
+import (
+	"math"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/SarunasBucius/nutri-price-server/internal/model"
+	"github.com/SarunasBucius/nutri-price-server/internal/utils/umath"
+)
+
+const (
+	// minScore is the lowest score of a suggestion worth showing.
+	minScore = 0.3
+	// trigramWeight and tokenWeight split the text similarity between trigram and whole token overlap.
+	trigramWeight = 0.6
+	tokenWeight   = 0.4
+	// historyWeight is the share of the score given to how often the candidate was bought.
+	historyWeight = 0.1
+)
+
+var diacriticsReplacer = strings.NewReplacer(
+	"ą", "a", "č", "c", "ę", "e", "ė", "e", "į", "i", "š", "s", "ų", "u", "ū", "u", "ž", "z",
+)
+
+var (
+	decimalSeparator = regexp.MustCompile(`(\d)[.,](\d)`)
+	nonAlphaNums     = regexp.MustCompile(`[^a-z0-9]+`)
+	// packSizeToken matches amounts and units left from pack sizes, e.g. "1", "500g", "4x120" or "kg".
+	packSizeToken = regexp.MustCompile(`^(\d+(x\d+)?(kg|g|ml|l|vnt)?|x|kg|g|ml|l|vnt)$`)
+)
+
+// Normalise returns lowercase tokens of the name with Lithuanian diacritics folded
+// and pack sizes removed, so that names differing only in packaging match.
+func Normalise(name string) []string {
+	name = diacriticsReplacer.Replace(strings.ToLower(name))
+	name = decimalSeparator.ReplaceAllString(name, "$1$2")
+	name = nonAlphaNums.ReplaceAllString(name, " ")
+	return slices.DeleteFunc(strings.Fields(name), packSizeToken.MatchString)
+}
+
+// Suggest ranks candidates by similarity to the parsed name and returns at most limit best ones.
+// Each candidate is compared by its variety name and by parsed names that were confirmed as it before.
+func Suggest(parsedName string, candidates []model.NameMatchCandidate, limit int) []model.NameSuggestion {
+	parsedTokens := Normalise(parsedName)
+	if len(parsedTokens) == 0 {
+		return nil
+	}
+
+	maxPurchases := 0
+	for _, candidate := range candidates {
+		maxPurchases = max(maxPurchases, candidate.Purchases)
+	}
+
+	var suggestions []model.NameSuggestion
+	for _, candidate := range candidates {
+		textScore := similarity(parsedTokens, Normalise(candidate.VarietyName))
+		for _, confirmedName := range candidate.ParsedNames {
+			textScore = max(textScore, similarity(parsedTokens, Normalise(confirmedName)))
+		}
+
+		score := (1-historyWeight)*textScore + historyWeight*getPopularity(candidate.Purchases, maxPurchases)
+		if score < minScore {
+			continue
+		}
+		suggestions = append(suggestions, model.NameSuggestion{
+			Name:        candidate.Name,
+			VarietyName: candidate.VarietyName,
+			Score:       umath.RoundFloat(score, 3),
+		})
+	}
+
+	slices.SortStableFunc(suggestions, func(a, b model.NameSuggestion) int {
+		switch {
+		case a.Score > b.Score:
+			return -1
+		case a.Score < b.Score:
+			return 1
+		default:
+			return strings.Compare(a.VarietyName, b.VarietyName)
+		}
+	})
+
+	if len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+	return suggestions
+}
+
+func similarity(a, b []string) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	return trigramWeight*jaccard(getTrigrams(a), getTrigrams(b)) + tokenWeight*jaccard(toSet(a), toSet(b))
+}
+
+// getTrigrams returns trigrams of every token padded like in pg_trgm, so short tokens still produce trigrams.
+func getTrigrams(tokens []string) map[string]bool {
+	trigrams := make(map[string]bool)
+	for _, token := range tokens {
+		padded := []rune("  " + token + " ")
+		for i := 0; i+3 <= len(padded); i++ {
+			trigrams[string(padded[i:i+3])] = true
+		}
+	}
+	return trigrams
+}
+
+func toSet(tokens []string) map[string]bool {
+	set := make(map[string]bool, len(tokens))
+	for _, token := range tokens {
+		set[token] = true
+	}
+	return set
+}
+
+func jaccard(a, b map[string]bool) float64 {
+	intersection := 0
+	for item := range a {
+		if b[item] {
+			intersection++
+		}
+	}
+	union := len(a) + len(b) - intersection
+	if union == 0 {
+		return 0
+	}
+	return float64(intersection) / float64(union)
+}
+
+// getPopularity scales purchase count logarithmically, so frequent products do not outweigh similar names.
+func getPopularity(purchases, maxPurchases int) float64 {
+	if maxPurchases == 0 {
+		return 0
+	}
+	return math.Log1p(float64(purchases)) / math.Log1p(float64(maxPurchases))
+}
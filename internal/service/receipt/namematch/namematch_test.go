@@ -0,0 +1,103 @@
+package namematch
+
+import (
+	"testing"
+
+	"github.com/SarunasBucius/nutri-price-server/internal/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalise(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want []string
+	}{
+		{
+			name: "diacritics_folded",
+			in:   "Varškės sūrelis ŽEMAITIJOS",
+			want: []string{"varskes", "surelis", "zemaitijos"},
+		},
+		{
+			name: "pack_sizes_removed",
+			in:   "Pienas ROKIŠKIO NAMINIS 2,5%, 1 l",
+			want: []string{"pienas", "rokiskio", "naminis"},
+		},
+		{
+			name: "multipack_removed",
+			in:   "Jogurtas ACTIVIA su braškėmis, 4x120 g",
+			want: []string{"jogurtas", "activia", "su", "braskemis"},
+		},
+		{
+			name: "punctuation_split",
+			in:   "Obuol. Crimson Snow",
+			want: []string{"obuol", "crimson", "snow"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, Normalise(tt.in))
+		})
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	candidates := []model.NameMatchCandidate{
+		{
+			Name:        "milk",
+			VarietyName: "Pienas ROKIŠKIO NAMINIS 2,5%, 1 l",
+			Purchases:   12,
+		},
+		{
+			Name:        "milk",
+			VarietyName: "Pienas DVARO 3,2%, 1 l",
+			Purchases:   3,
+		},
+		{
+			Name:        "apples",
+			VarietyName: "Crimson Snow",
+			ParsedNames: []string{"Obuol. Crimson Snow"},
+			Purchases:   5,
+		},
+		{
+			Name:        "bread",
+			VarietyName: "Juoda duona su saulėgrąžomis",
+			Purchases:   1,
+		},
+	}
+
+	tests := []struct {
+		name       string
+		parsedName string
+		limit      int
+		want       []model.NameSuggestion
+	}{
+		{
+			name:       "new_pack_size_matches_variety",
+			parsedName: "Pienas ROKISKIO NAMINIS 2,5%, 1,5 l",
+			limit:      1,
+			want:       []model.NameSuggestion{{Name: "milk", VarietyName: "Pienas ROKIŠKIO NAMINIS 2,5%, 1 l", Score: 1}},
+		},
+		{
+			name:       "matches_confirmed_parsed_name",
+			parsedName: "Obuol. Crimson Snow, kg",
+			limit:      3,
+			want:       []model.NameSuggestion{{Name: "apples", VarietyName: "Crimson Snow", Score: 0.97}},
+		},
+		{
+			name:       "no_similar_products",
+			parsedName: "Dantų pasta",
+			limit:      3,
+		},
+		{
+			name:       "only_pack_size",
+			parsedName: "1 kg",
+			limit:      3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, Suggest(tt.parsedName, candidates, tt.limit))
+		})
+	}
+}
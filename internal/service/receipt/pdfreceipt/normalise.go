@@ -0,0 +1,165 @@
+package pdfreceipt
+
+import (
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/SarunasBucius/nutri-price-server/internal/utils/uerror"
+)
+
+const (
+	retailerBarbora = "barbora"
+	retailerLidl    = "lidl"
+)
+
+var (
+	dateRegexp     = regexp.MustCompile(`\d{4}-\d{2}-\d{2}`)
+	dateTimeRegexp = regexp.MustCompile(`\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}`)
+	separatorLine  = regexp.MustCompile(`^-{10,}$`)
+	lidlProduct    = regexp.MustCompile(`^\d{7} `)
+)
+
+// Normalise rearranges text extracted from a PDF e-receipt into the layout of the pasted
+// receipt text expected by the retailer parser. Retailer is detected when retailerHint is empty.
+func Normalise(text, retailerHint string) (string, error) {
+	lines := splitLines(text)
+
+	retailer := strings.ToLower(retailerHint)
+	if retailer == "" {
+		retailer = detectRetailer(text)
+	}
+
+	switch retailer {
+	case retailerBarbora:
+		return normaliseBarbora(lines)
+	case retailerLidl:
+		return normaliseLidl(lines)
+	default:
+		return "", uerror.NewBadRequest("pdf receipts are supported only for barbora and lidl", nil)
+	}
+}
+
+func detectRetailer(text string) string {
+	switch {
+	case strings.Contains(text, "Lidl Lietuva"):
+		return retailerLidl
+	case strings.Contains(text, "Barbora"):
+		return retailerBarbora
+	default:
+		return ""
+	}
+}
+
+func splitLines(text string) []string {
+	text = strings.ReplaceAll(text, "\r", "")
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.Join(strings.Fields(line), " ")
+		if line == "" {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// normaliseBarbora puts the retailer name and order date on top, followed by product rows,
+// discounts and totals. Product name continuation rows are merged into the product row.
+func normaliseBarbora(lines []string) (string, error) {
+	const pricingColumns = 7
+
+	date := ""
+	productsStart := -1
+	for i, line := range lines {
+		if date == "" {
+			date = dateRegexp.FindString(line)
+			continue
+		}
+		if strings.HasPrefix(line, "1 ") {
+			productsStart = i
+			break
+		}
+	}
+	if date == "" || productsStart == -1 {
+		return "", uerror.NewBadRequest("barbora pdf receipt is missing date or products", nil)
+	}
+
+	normalised := []string{"Barbora", date}
+	var products []string
+	i := productsStart
+	for ; i < len(lines); i++ {
+		line := lines[i]
+		if strings.HasPrefix(line, "Pritaikytos nuolaidos") || isBarboraTotalsLine(line) {
+			break
+		}
+		if strings.HasPrefix(line, strconv.Itoa(len(products)+1)+" ") {
+			products = append(products, line)
+			continue
+		}
+		lastProduct := strings.Split(products[len(products)-1], " ")
+		if len(lastProduct) <= pricingColumns {
+			products[len(products)-1] += " " + line
+			continue
+		}
+		nameEnd := len(lastProduct) - pricingColumns
+		merged := append(lastProduct[:nameEnd:nameEnd], line)
+		products[len(products)-1] = strings.Join(append(merged, lastProduct[nameEnd:]...), " ")
+	}
+	normalised = append(normalised, products...)
+
+	lastTotalsLine := i - 1
+	for j := i; j < len(lines); j++ {
+		if isBarboraTotalsLine(lines[j]) {
+			lastTotalsLine = j
+		}
+	}
+	normalised = append(normalised, lines[i:lastTotalsLine+1]...)
+
+	return strings.Join(normalised, "\n"), nil
+}
+
+func isBarboraTotalsLine(line string) bool {
+	return strings.HasPrefix(line, "PVM ") || strings.HasPrefix(line, "Iš viso") || strings.HasPrefix(line, "Apmokėta")
+}
+
+// normaliseLidl keeps the four header lines the parser skips before the product list,
+// starting with the retailer line used for detection, restores the full width products separator and ends the receipt with the date line.
+func normaliseLidl(lines []string) (string, error) {
+	const headerLength = 4
+	const productsEndSeparator = "------------------------------------------------------"
+
+	productsStart := -1
+	dateLine := -1
+	for i, line := range lines {
+		if productsStart == -1 && lidlProduct.MatchString(line) {
+			productsStart = i
+		}
+		if dateTimeRegexp.MatchString(line) {
+			dateLine = i
+		}
+	}
+	if productsStart == -1 || dateLine < productsStart {
+		return "", uerror.NewBadRequest("lidl pdf receipt is missing products or date", nil)
+	}
+
+	header := slices.Clone(lines[:productsStart])
+	if len(header) > headerLength {
+		retailerLine := slices.IndexFunc(header, func(l string) bool { return strings.Contains(l, "Lidl Lietuva") })
+		header = append([]string{header[max(retailerLine, 0)]}, header[len(header)-headerLength+1:]...)
+	}
+	for len(header) < headerLength {
+		header = append(header, "...")
+	}
+
+	normalised := append([]string{}, header...)
+	for _, line := range lines[productsStart : dateLine+1] {
+		if separatorLine.MatchString(line) {
+			line = productsEndSeparator
+		}
+		normalised = append(normalised, line)
+	}
+
+	return strings.Join(normalised, "\n"), nil
+}
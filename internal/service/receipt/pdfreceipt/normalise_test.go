@@ -0,0 +1,120 @@
+package pdfreceipt
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/SarunasBucius/nutri-price-server/internal/service/receipt/retailer/barbora"
+	"github.com/SarunasBucius/nutri-price-server/internal/service/receipt/retailer/lidl"
+	"github.com/stretchr/testify/require"
+)
+
+const barboraPDFText = `Sąskaita faktūra
+UAB "Maxima LT" Barbora
+Užsakymo data 2023-04-16
+Nr. Prekė Kiekis Mato vnt. Kaina su PVM Kaina be PVM PVM % PVM suma Suma
+1 Nektarinai, 1 kg 0.612 kg €1.6569 €1.3693 21,00 €0.84 €1.01
+2 Salotos ROMAINE, 1 vnt. €1.9900 €1.6446 21,00 €1.64 €1.99
+300 g
+Pritaikytos nuolaidos
+Nektarinai, 1 kg -€1.10
+PVM 21,00% €0.52
+Iš viso €3.00
+Apmokėta €3.00
+Puslapis 1 iš 1`
+
+const barboraNormalised = `Barbora
+2023-04-16
+1 Nektarinai, 1 kg 0.612 kg €1.6569 €1.3693 21,00 €0.84 €1.01
+2 Salotos ROMAINE, 300 g 1 vnt. €1.9900 €1.6446 21,00 €1.64 €1.99
+Pritaikytos nuolaidos
+Nektarinai, 1 kg -€1.10
+PVM 21,00% €0.52
+Iš viso €3.00
+Apmokėta €3.00`
+
+const lidlPDFText = `Lidl Plus
+UAB "Lidl Lietuva" Į. k.: 111791015
+Parduotuvė Vilnius
+...
+PVM mokėtojo kodas LT117910113
+Kvitas 64/128 #00017499
+0080505 Vynuogės žal.be kaul 1,29 A
+0080206 Obuol. Crimson Snow
+0,99 X 1,232 KG 1,22 A
+------------------------
+Mokėti 2,51
+LF NM0000006239BC 2023-04-16 15:47:08
+Atsisiųsta iš Lidl Plus programėlės`
+
+const lidlNormalised = `UAB "Lidl Lietuva" Į. k.: 111791015
+...
+PVM mokėtojo kodas LT117910113
+Kvitas 64/128 #00017499
+0080505 Vynuogės žal.be kaul 1,29 A
+0080206 Obuol. Crimson Snow
+0,99 X 1,232 KG 1,22 A
+------------------------------------------------------
+Mokėti 2,51
+LF NM0000006239BC 2023-04-16 15:47:08`
+
+func TestNormalise(t *testing.T) {
+	tests := []struct {
+		name         string
+		text         string
+		retailerHint string
+		want         string
+		wantErr      bool
+	}{
+		{
+			name: "barbora",
+			text: barboraPDFText,
+			want: barboraNormalised,
+		},
+		{
+			name:         "lidl_with_hint",
+			text:         lidlPDFText,
+			retailerHint: "Lidl",
+			want:         lidlNormalised,
+		},
+		{
+			name:    "barbora_without_products",
+			text:    "Barbora\n2023-04-16\nIš viso €0.00",
+			wantErr: true,
+		},
+		{
+			name:    "unsupported_retailer",
+			text:    "UAB \"RIMI LIETUVA\"\n2024-05-14 18:32:11",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Normalise(tt.text, tt.retailerHint)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNormalise_ParsableByRetailerParser(t *testing.T) {
+	barboraReceipt, err := Normalise(barboraPDFText, "")
+	require.NoError(t, err)
+	barboraProducts, _, err := barbora.NewParser(strings.Split(barboraReceipt, "\n")).ParseProducts()
+	require.NoError(t, err)
+	require.Len(t, barboraProducts, 2)
+
+	lidlReceipt, err := Normalise(lidlPDFText, "")
+	require.NoError(t, err)
+	lidlParser := lidl.NewParser(strings.Split(lidlReceipt, "\n"))
+	_, err = lidlParser.ParseDate()
+	require.NoError(t, err)
+	lidlProducts, _, err := lidlParser.ParseProducts()
+	require.NoError(t, err)
+	require.Len(t, lidlProducts, 2)
+}
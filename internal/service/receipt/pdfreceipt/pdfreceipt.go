@@ -0,0 +1,113 @@
+package pdfreceipt
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/SarunasBucius/nutri-price-server/internal/utils/uerror"
+	"github.com/ledongthuc/pdf"
+)
+
+const (
+	// sameRowTolerance is the vertical distance in points within which glyphs are treated as one row.
+	sameRowTolerance = 2.0
+	// wordGapRatio is the horizontal gap, relative to the font size, that separates two words.
+	wordGapRatio = 0.2
+)
+
+const unreadablePDFMessage = "unable to read pdf receipt"
+
+// ExtractText returns the text layer of the PDF, one line per row of text, pages in order.
+func ExtractText(pdfReceipt []byte) (text string, err error) {
+	// The pdf package panics on truncated files and malformed content streams.
+	defer func() {
+		if r := recover(); r != nil {
+			err = uerror.NewBadRequest(unreadablePDFMessage, fmt.Errorf("read pdf: %v", r))
+		}
+	}()
+
+	reader, err := pdf.NewReader(bytes.NewReader(pdfReceipt), int64(len(pdfReceipt)))
+	if err != nil {
+		return "", uerror.NewBadRequest(unreadablePDFMessage, fmt.Errorf("open pdf: %w", err))
+	}
+
+	var lines []string
+	for i := 1; i <= reader.NumPage(); i++ {
+		page := reader.Page(i)
+		if page.V.IsNull() {
+			continue
+		}
+		lines = append(lines, joinRows(page.Content().Text)...)
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
+
+// joinRows groups glyphs into rows from top to bottom and joins each row into a line.
+func joinRows(glyphs []pdf.Text) []string {
+	glyphs = slices.DeleteFunc(slices.Clone(glyphs), func(g pdf.Text) bool {
+		return strings.TrimSpace(g.S) == "" && g.S != " "
+	})
+
+	var rows [][]pdf.Text
+	for _, glyph := range glyphs {
+		rowIndex := slices.IndexFunc(rows, func(row []pdf.Text) bool {
+			return math.Abs(row[0].Y-glyph.Y) <= sameRowTolerance
+		})
+		if rowIndex == -1 {
+			rows = append(rows, []pdf.Text{glyph})
+			continue
+		}
+		rows[rowIndex] = append(rows[rowIndex], glyph)
+	}
+
+	slices.SortStableFunc(rows, func(a, b []pdf.Text) int {
+		switch {
+		case a[0].Y > b[0].Y:
+			return -1
+		case a[0].Y < b[0].Y:
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	lines := make([]string, 0, len(rows))
+	for _, row := range rows {
+		line := joinRow(row)
+		if line == "" {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func joinRow(row []pdf.Text) string {
+	slices.SortStableFunc(row, func(a, b pdf.Text) int {
+		switch {
+		case a.X < b.X:
+			return -1
+		case a.X > b.X:
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	var line strings.Builder
+	for i, glyph := range row {
+		if i > 0 {
+			previous := row[i-1]
+			gap := glyph.X - (previous.X + previous.W)
+			if gap > glyph.FontSize*wordGapRatio {
+				line.WriteString(" ")
+			}
+		}
+		line.WriteString(glyph.S)
+	}
+	return strings.Join(strings.Fields(line.String()), " ")
+}
@@ -0,0 +1,109 @@
+package pdfreceipt
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/SarunasBucius/nutri-price-server/internal/utils/uerror"
+	"github.com/stretchr/testify/require"
+)
+
+type pdfText struct {
+	x, y float64
+	text string
+}
+
+// buildPDF returns a single page PDF with the texts placed at given coordinates.
+// Every glyph is 500 units wide, so at font size 10 each character takes 5 points.
+func buildPDF(texts []pdfText) []byte {
+	var content strings.Builder
+	for _, t := range texts {
+		fmt.Fprintf(&content, "BT /F1 10 Tf 1 0 0 1 %.2f %.2f Tm (%s) Tj ET\n", t.x, t.y, t.text)
+	}
+
+	widths := strings.TrimSpace(strings.Repeat("500 ", 256-32))
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 300 400] /Resources << /Font << /F1 4 0 R >> >> /Contents 5 0 R >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding /FirstChar 32 /LastChar 255 /Widths [" + widths + "] >>",
+		fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", content.Len(), content.String()),
+	}
+
+	var pdf bytes.Buffer
+	pdf.WriteString("%PDF-1.4\n")
+	offsets := make([]int, 0, len(objects))
+	for i, object := range objects {
+		offsets = append(offsets, pdf.Len())
+		fmt.Fprintf(&pdf, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+
+	xrefOffset := pdf.Len()
+	fmt.Fprintf(&pdf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&pdf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&pdf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xrefOffset)
+	return pdf.Bytes()
+}
+
+func TestExtractText(t *testing.T) {
+	tests := []struct {
+		name    string
+		pdf     []byte
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "rows_ordered_top_to_bottom",
+			pdf: buildPDF([]pdfText{
+				{x: 10, y: 300, text: "Barbora"},
+				{x: 10, y: 280, text: "2023-04-16"},
+				{x: 10, y: 350, text: "Saskaita faktura"},
+			}),
+			want: "Saskaita faktura\nBarbora\n2023-04-16",
+		},
+		{
+			name: "columns_joined_with_space",
+			pdf: buildPDF([]pdfText{
+				{x: 100, y: 300, text: "1,29 A"},
+				{x: 10, y: 300, text: "0080505 Vynuoges"},
+				{x: 10, y: 280, text: "Mok"},
+				{x: 25, y: 281, text: "eti"},
+			}),
+			want: "0080505 Vynuoges 1,29 A\nMoketi",
+		},
+		{
+			name:    "not_a_pdf",
+			pdf:     []byte("Barbora\n2023-04-16"),
+			wantErr: true,
+		},
+		{
+			name: "truncated_pdf",
+			// Objects are cut off while the cross-reference table still points past the end of the file.
+			pdf: func() []byte {
+				pdf := buildPDF([]pdfText{{x: 10, y: 300, text: "Barbora"}})
+				xref := bytes.Index(pdf, []byte("xref"))
+				return append(pdf[:xref/2:xref/2], pdf[xref:]...)
+			}(),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ExtractText(tt.pdf)
+			if tt.wantErr {
+				require.Error(t, err)
+				_, statusCode := uerror.SanitizeError(err)
+				require.Equal(t, http.StatusBadRequest, statusCode)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -2,72 +2,224 @@ package receipt
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"strings"
 	"time"
 
 	"github.com/SarunasBucius/nutri-price-server/internal/model"
+	"github.com/SarunasBucius/nutri-price-server/internal/service/receipt/emailreceipt"
+	"github.com/SarunasBucius/nutri-price-server/internal/service/receipt/namematch"
+	"github.com/SarunasBucius/nutri-price-server/internal/service/receipt/pdfreceipt"
 	"github.com/SarunasBucius/nutri-price-server/internal/service/receipt/retailer"
+	"github.com/SarunasBucius/nutri-price-server/internal/utils/uerror"
+	"github.com/SarunasBucius/nutri-price-server/internal/utils/umath"
 )
 
 type Service struct {
 	ReceiptRepo IReceiptRepository
+	DepositRepo IDepositRepository
 }
 
-func NewReceiptService(receiptRepo IReceiptRepository) *Service {
+func NewReceiptService(receiptRepo IReceiptRepository, depositRepo IDepositRepository) *Service {
 	return &Service{
 		ReceiptRepo: receiptRepo,
+		DepositRepo: depositRepo,
 	}
 }
 
 type IReceiptRepository interface {
-	InsertRawReceipt(ctx context.Context, date time.Time, receiptLines, retailer string, products model.ReceiptProducts) error
+	InsertRawReceipt(ctx context.Context, rawReceipt model.RawReceipt) (string, bool, error)
+	IsEmailReceiptProcessed(ctx context.Context, messageID string) (bool, error)
 	GetUnprocessedReceipt(ctx context.Context) (string, error)
-	GetRawReceiptByDate(ctx context.Context, date time.Time) (string, error)
-	GetUnconfirmedReceipt(ctx context.Context, retailer, date string) ([]model.PurchasedProductNew, error)
+	GetRawReceiptByID(ctx context.Context, receiptID string) (string, error)
+	GetStoredReceipts(ctx context.Context) ([]model.StoredReceipt, error)
+	GetUnconfirmedReceipt(ctx context.Context, receiptID string) ([]model.PurchasedProductNew, string, error)
 	GetUnconfirmedReceiptSummaries(ctx context.Context) ([]model.UnconfirmedReceiptSummary, error)
 	GetProductNameAlias(ctx context.Context, parsedNames []string) (map[string]model.ProductAndVarietyName, error)
+	GetProductNamesByArticleCodes(ctx context.Context, retailer string, codes []string) (map[string]model.ProductAndVarietyName, error)
+	GetNameMatchCandidates(ctx context.Context) ([]model.NameMatchCandidate, error)
 	GetLastReceiptDates(ctx context.Context) ([]model.LastReceiptDate, error)
-	GetProductsWithMissingInfo(ctx context.Context, dateFrom string) ([]model.ProductAndVarietyName, error)
+	GetProductsWithMissingInfo(ctx context.Context, dateFrom string, filter model.ProductFilter) ([]model.ProductAndVarietyName, error)
 }
 
-func (s *Service) ProcessReceipt(ctx context.Context, receipt string) (model.ParseReceiptFromTextResponse, error) {
-	receiptParser, err := retailer.NewReceiptParser(receipt)
+type IDepositRepository interface {
+	InsertDepositRefund(ctx context.Context, refund model.DepositRefund) error
+	GetDepositBalance(ctx context.Context) (model.DepositBalance, error)
+}
+
+// ErrEmailAlreadyProcessed is returned for emails whose Message-ID is already stored.
+var ErrEmailAlreadyProcessed = errors.New("email receipt already processed")
+
+func (s *Service) ProcessReceipt(ctx context.Context, receipt, retailerHint string) (model.ParseReceiptFromTextResponse, error) {
+	return s.processReceipt(ctx, receipt, retailerHint, "")
+}
+
+// processReceipt parses and stores the receipt. MessageID is empty for receipts not received by email.
+// Line endings are normalised first, so copies of a receipt with CRLF and LF line endings are stored once.
+func (s *Service) processReceipt(ctx context.Context, receipt, retailerHint, messageID string) (model.ParseReceiptFromTextResponse, error) {
+	receipt = strings.ReplaceAll(receipt, "\r", "")
+
+	receiptParser, err := retailer.NewReceiptParser(receipt, retailerHint)
 	if err != nil {
 		return model.ParseReceiptFromTextResponse{}, fmt.Errorf("create receipt parser: %w", err)
 	}
 
+	if refundParser, ok := receiptParser.(retailer.DepositRefundParser); ok {
+		return s.processDepositRefund(ctx, receipt, messageID, refundParser)
+	}
+
 	date, err := receiptParser.ParseDate()
 	if err != nil {
 		return model.ParseReceiptFromTextResponse{}, fmt.Errorf("parse date: %w", err)
 	}
 
-	products, err := receiptParser.ParseProducts()
+	products, skippedLines, err := receiptParser.ParseProducts()
 	if err != nil {
 		return model.ParseReceiptFromTextResponse{}, fmt.Errorf("parse products: %w", err)
 	}
 
-	if err := s.ReceiptRepo.InsertRawReceipt(ctx, date, receipt, receiptParser.GetRetailer(), products); err != nil {
-		slog.ErrorContext(ctx, "insert raw receipt", "error", err)
+	// Totals are only used for diagnostics, so the receipt is still stored when they cannot be parsed.
+	totals, err := receiptParser.ParseTotals()
+	if err != nil {
+		slog.ErrorContext(ctx, "parse receipt totals", "retailer", receiptParser.GetRetailer(), "error", err)
+		totals = model.ReceiptTotals{}
+	}
+
+	rawReceipt := model.RawReceipt{
+		Date:           date,
+		Time:           receiptParser.ParseTime(),
+		ReceiptNumber:  receiptParser.ParseReceiptNumber(),
+		Retailer:       receiptParser.GetRetailer(),
+		Receipt:        receipt,
+		ParsedProducts: products,
+		MessageID:      messageID,
+	}
+	receiptID, alreadyStored, err := s.ReceiptRepo.InsertRawReceipt(ctx, rawReceipt)
+	if err != nil {
+		return model.ParseReceiptFromTextResponse{}, fmt.Errorf("insert raw receipt: %w", err)
 	}
 
 	return model.ParseReceiptFromTextResponse{
-		Date:     date.Format(time.DateOnly),
-		Retailer: receiptParser.GetRetailer(),
-		Products: products,
+		ReceiptID:     receiptID,
+		AlreadyStored: alreadyStored,
+		Date:          date.Format(time.DateOnly),
+		Time:          rawReceipt.Time,
+		ReceiptNumber: rawReceipt.ReceiptNumber,
+		Retailer:      rawReceipt.Retailer,
+		Products:      products,
+		Totals:        totals,
+		Diagnostics:   newReceiptDiagnostics(products, totals, skippedLines),
 	}, nil
 }
 
-func (s *Service) ProcessReceiptFromDB(ctx context.Context, receiptDate string) (model.ParseReceiptFromTextResponse, error) {
-	receipt, err := s.getReceipt(ctx, receiptDate)
+// ProcessPDFReceipt extracts text from a PDF e-receipt and processes it like a pasted receipt.
+func (s *Service) ProcessPDFReceipt(ctx context.Context, pdfReceipt []byte, retailerHint string) (model.ParseReceiptFromTextResponse, error) {
+	text, err := pdfreceipt.ExtractText(pdfReceipt)
+	if err != nil {
+		return model.ParseReceiptFromTextResponse{}, fmt.Errorf("extract pdf text: %w", err)
+	}
+
+	receipt, err := pdfreceipt.Normalise(text, retailerHint)
+	if err != nil {
+		return model.ParseReceiptFromTextResponse{}, fmt.Errorf("normalise pdf receipt: %w", err)
+	}
+
+	return s.ProcessReceipt(ctx, receipt, retailerHint)
+}
+
+// ProcessEmailReceipt processes the receipt from a raw RFC 822 message.
+// Emails with an already stored Message-ID are rejected with ErrEmailAlreadyProcessed.
+func (s *Service) ProcessEmailReceipt(ctx context.Context, rawEmail []byte, retailerHint string) (model.ParseReceiptFromTextResponse, error) {
+	email, err := emailreceipt.Parse(rawEmail)
+	if err != nil {
+		return model.ParseReceiptFromTextResponse{}, uerror.NewBadRequest("unable to read email receipt", err)
+	}
+
+	if email.MessageID != "" {
+		processed, err := s.ReceiptRepo.IsEmailReceiptProcessed(ctx, email.MessageID)
+		if err != nil {
+			return model.ParseReceiptFromTextResponse{}, fmt.Errorf("check if email receipt is processed: %w", err)
+		}
+		if processed {
+			return model.ParseReceiptFromTextResponse{}, uerror.NewConflict(ErrEmailAlreadyProcessed.Error(), ErrEmailAlreadyProcessed)
+		}
+	}
+
+	return s.processReceipt(ctx, email.Receipt, retailerHint, email.MessageID)
+}
+
+func (s *Service) processDepositRefund(ctx context.Context, receipt, messageID string, refundParser retailer.DepositRefundParser) (model.ParseReceiptFromTextResponse, error) {
+	date, err := refundParser.ParseDate()
+	if err != nil {
+		return model.ParseReceiptFromTextResponse{}, fmt.Errorf("parse date: %w", err)
+	}
+
+	refund, err := refundParser.ParseDepositRefund()
+	if err != nil {
+		return model.ParseReceiptFromTextResponse{}, fmt.Errorf("parse deposit refund: %w", err)
+	}
+
+	rawReceipt := model.RawReceipt{
+		Date:           date,
+		Time:           refundParser.ParseTime(),
+		ReceiptNumber:  refundParser.ParseReceiptNumber(),
+		Retailer:       refundParser.GetRetailer(),
+		Receipt:        receipt,
+		ParsedProducts: model.ReceiptProducts{},
+		MessageID:      messageID,
+	}
+	receiptID, alreadyStored, err := s.ReceiptRepo.InsertRawReceipt(ctx, rawReceipt)
+	if err != nil {
+		return model.ParseReceiptFromTextResponse{}, fmt.Errorf("insert raw receipt: %w", err)
+	}
+
+	depositRefund := model.DepositRefund{
+		ReceiptID: receiptID,
+		Retailer:  rawReceipt.Retailer,
+		Date:      date,
+		Amount:    refund,
+	}
+	if err := s.DepositRepo.InsertDepositRefund(ctx, depositRefund); err != nil {
+		return model.ParseReceiptFromTextResponse{}, fmt.Errorf("insert deposit refund: %w", err)
+	}
+
+	return model.ParseReceiptFromTextResponse{
+		ReceiptID:     receiptID,
+		AlreadyStored: alreadyStored,
+		Date:          date.Format(time.DateOnly),
+		Time:          rawReceipt.Time,
+		ReceiptNumber: rawReceipt.ReceiptNumber,
+		Retailer:      rawReceipt.Retailer,
+		Products:      rawReceipt.ParsedProducts,
+		DepositRefund: refund,
+		Totals:        model.ReceiptTotals{Total: -refund},
+		Diagnostics:   model.ReceiptDiagnostics{SkippedLines: []string{}, Warnings: []model.ReceiptWarning{}},
+	}, nil
+}
+
+func (s *Service) GetDepositBalance(ctx context.Context) (model.DepositBalance, error) {
+	balance, err := s.DepositRepo.GetDepositBalance(ctx)
+	if err != nil {
+		return model.DepositBalance{}, fmt.Errorf("get deposit balance: %w", err)
+	}
+	balance.Balance = umath.RoundFloat(balance.Paid-balance.Refunded, 2)
+	return balance, nil
+}
+
+func (s *Service) ProcessReceiptFromDB(ctx context.Context, receiptID string) (model.ParseReceiptFromTextResponse, error) {
+	receipt, err := s.getReceipt(ctx, receiptID)
 	if err != nil {
 		return model.ParseReceiptFromTextResponse{}, fmt.Errorf("get receipt: %w", err)
 	}
-	return s.ProcessReceipt(ctx, receipt)
+	return s.ProcessReceipt(ctx, receipt, "")
 }
 
-func (s *Service) GetUnconfirmedReceipt(ctx context.Context, retailer, date string) ([]model.PurchasedProductNew, error) {
-	unconfirmedProducts, err := s.ReceiptRepo.GetUnconfirmedReceipt(ctx, retailer, date)
+// GetUnconfirmedReceipt returns parsed products of the receipt with names resolved by article codes and aliases.
+// Products matched by neither get the best matching existing products as suggestions.
+func (s *Service) GetUnconfirmedReceipt(ctx context.Context, receiptID string) ([]model.UnconfirmedProduct, error) {
+	unconfirmedProducts, retailer, err := s.ReceiptRepo.GetUnconfirmedReceipt(ctx, receiptID)
 	if err != nil {
 		return nil, fmt.Errorf("get unconfirmed receipt: %w", err)
 	}
@@ -81,15 +233,66 @@ func (s *Service) GetUnconfirmedReceipt(ctx context.Context, retailer, date stri
 
 	products.UpdateProductNames(aliasByParsedName)
 
-	return products, nil
+	if articleCodes := products.GetArticleCodes(); len(articleCodes) > 0 {
+		productByArticleCode, err := s.ReceiptRepo.GetProductNamesByArticleCodes(ctx, retailer, articleCodes)
+		if err != nil {
+			return nil, fmt.Errorf("get product names by article codes: %w", err)
+		}
+		products.UpdateProductNamesByArticleCode(productByArticleCode)
+	}
+
+	suggestionsByParsedName, err := s.getNameSuggestions(ctx, products)
+	if err != nil {
+		return nil, fmt.Errorf("get name suggestions: %w", err)
+	}
+
+	result := make([]model.UnconfirmedProduct, 0, len(products))
+	for _, product := range products {
+		suggestions := suggestionsByParsedName[product.ParsedName]
+		if suggestions == nil {
+			suggestions = []model.NameSuggestion{}
+		}
+		result = append(result, model.UnconfirmedProduct{
+			PurchasedProductNew: product,
+			Suggestions:         suggestions,
+		})
+	}
+
+	return result, nil
+}
+
+// getNameSuggestions ranks existing products for parsed names that were not matched by an alias or article code.
+func (s *Service) getNameSuggestions(ctx context.Context, products model.ReceiptProducts) (map[string][]model.NameSuggestion, error) {
+	const suggestionsLimit = 3
+
+	var unmatchedNames []string
+	for _, product := range products {
+		if product.Name == "" {
+			unmatchedNames = append(unmatchedNames, product.ParsedName)
+		}
+	}
+	if len(unmatchedNames) == 0 {
+		return nil, nil
+	}
+
+	candidates, err := s.ReceiptRepo.GetNameMatchCandidates(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get name match candidates: %w", err)
+	}
+
+	suggestionsByParsedName := make(map[string][]model.NameSuggestion, len(unmatchedNames))
+	for _, parsedName := range unmatchedNames {
+		suggestionsByParsedName[parsedName] = namematch.Suggest(parsedName, candidates, suggestionsLimit)
+	}
+	return suggestionsByParsedName, nil
 }
 
 func (s *Service) GetUnconfirmedReceiptSummaries(ctx context.Context) ([]model.UnconfirmedReceiptSummary, error) {
 	return s.ReceiptRepo.GetUnconfirmedReceiptSummaries(ctx)
 }
 
-func (s *Service) getReceipt(ctx context.Context, receiptDate string) (string, error) {
-	if len(receiptDate) == 0 {
+func (s *Service) getReceipt(ctx context.Context, receiptID string) (string, error) {
+	if len(receiptID) == 0 {
 		receipt, err := s.ReceiptRepo.GetUnprocessedReceipt(ctx)
 		if err != nil {
 			return "", fmt.Errorf("get unprocessed receipt: %w", err)
@@ -97,13 +300,9 @@ func (s *Service) getReceipt(ctx context.Context, receiptDate string) (string, e
 		return receipt, nil
 	}
 
-	parsedDate, err := time.Parse(time.DateOnly, receiptDate)
-	if err != nil {
-		return "", fmt.Errorf("parse date: %w", err)
-	}
-	receipt, err := s.ReceiptRepo.GetRawReceiptByDate(ctx, parsedDate)
+	receipt, err := s.ReceiptRepo.GetRawReceiptByID(ctx, receiptID)
 	if err != nil {
-		return "", fmt.Errorf("get raw receipt by date: %w", err)
+		return "", fmt.Errorf("get raw receipt by id: %w", err)
 	}
 
 	return receipt, nil
@@ -117,8 +316,8 @@ func (s *Service) GetLastReceiptDates(ctx context.Context) ([]model.LastReceiptD
 	return lastReceiptDates, nil
 }
 
-func (s *Service) GetProductsWithMissingInfo(ctx context.Context, dateFrom string) ([]model.ProductAndVarietyName, error) {
-	products, err := s.ReceiptRepo.GetProductsWithMissingInfo(ctx, dateFrom)
+func (s *Service) GetProductsWithMissingInfo(ctx context.Context, dateFrom string, filter model.ProductFilter) ([]model.ProductAndVarietyName, error) {
+	products, err := s.ReceiptRepo.GetProductsWithMissingInfo(ctx, dateFrom, filter)
 	if err != nil {
 		return nil, fmt.Errorf("get products with missing info: %w", err)
 	}
@@ -0,0 +1,110 @@
+package receipt
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/SarunasBucius/nutri-price-server/internal/model"
+	"github.com/stretchr/testify/require"
+)
+
+const rimiReceipt = `UAB "RIMI LIETUVA"
+Kasa 05                           Kvitas 0123
+------------------------------------------------
+Pienas ROKIŠKIO NAMINIS 2,5%, 1 l        1,39 A
+------------------------------------------------
+Iš viso mokėti                           1,39 EUR
+Mokėta banko kortele                     1,39 EUR
+------------------------------------------------
+2024-05-14 18:32:11`
+
+// fakeReceiptRepo stores raw receipts in memory. Methods not overridden panic when called.
+type fakeReceiptRepo struct {
+	IReceiptRepository
+	receipts       []model.RawReceipt
+	insertErr      error
+	storedReceipts []model.StoredReceipt
+	aliases        map[string]model.ProductAndVarietyName
+}
+
+func (f *fakeReceiptRepo) GetStoredReceipts(context.Context) ([]model.StoredReceipt, error) {
+	return f.storedReceipts, nil
+}
+
+func (f *fakeReceiptRepo) GetProductNameAlias(_ context.Context, parsedNames []string) (map[string]model.ProductAndVarietyName, error) {
+	aliases := make(map[string]model.ProductAndVarietyName)
+	for _, name := range parsedNames {
+		if alias, ok := f.aliases[name]; ok {
+			aliases[name] = alias
+		}
+	}
+	return aliases, nil
+}
+
+func (f *fakeReceiptRepo) InsertRawReceipt(_ context.Context, rawReceipt model.RawReceipt) (string, bool, error) {
+	if f.insertErr != nil {
+		return "", false, f.insertErr
+	}
+	for _, stored := range f.receipts {
+		if stored.Receipt == rawReceipt.Receipt {
+			return stored.ID, true, nil
+		}
+	}
+	rawReceipt.ID = strconv.Itoa(len(f.receipts) + 1)
+	f.receipts = append(f.receipts, rawReceipt)
+	return rawReceipt.ID, false, nil
+}
+
+func TestService_ProcessReceipt(t *testing.T) {
+	tests := []struct {
+		name              string
+		receipts          []string
+		insertErr         error
+		wantAlreadyStored bool
+		wantStored        int
+		wantErr           bool
+	}{
+		{
+			name:       "store_receipt",
+			receipts:   []string{rimiReceipt},
+			wantStored: 1,
+		},
+		{
+			name:              "crlf_copy_is_stored_once",
+			receipts:          []string{rimiReceipt, strings.ReplaceAll(rimiReceipt, "\n", "\r\n")},
+			wantAlreadyStored: true,
+			wantStored:        1,
+		},
+		{
+			name:      "insert_error",
+			receipts:  []string{rimiReceipt},
+			insertErr: errors.New("connection refused"),
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeReceiptRepo{insertErr: tt.insertErr}
+			s := NewReceiptService(repo, nil)
+
+			var got model.ParseReceiptFromTextResponse
+			var err error
+			for _, receipt := range tt.receipts {
+				got, err = s.ProcessReceipt(context.Background(), receipt, "")
+			}
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotEmpty(t, got.ReceiptID)
+			require.Equal(t, tt.wantAlreadyStored, got.AlreadyStored)
+			require.Len(t, repo.receipts, tt.wantStored)
+			require.NotContains(t, repo.receipts[0].Receipt, "\r")
+		})
+	}
+}
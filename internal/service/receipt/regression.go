@@ -0,0 +1,202 @@
+package receipt
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/SarunasBucius/nutri-price-server/internal/model"
+	"github.com/SarunasBucius/nutri-price-server/internal/service/receipt/retailer"
+	"github.com/SarunasBucius/nutri-price-server/internal/utils/umath"
+)
+
+const (
+	priceTolerance  = 0.005
+	amountTolerance = 0.01
+)
+
+// RunParserRegression re-parses every stored receipt with the current parsers. Output of confirmed receipts
+// is compared with the submitted products to measure accuracy per retailer, and receipts whose output
+// differs from the stored parsed products are listed. Nothing is written to the database.
+func (s *Service) RunParserRegression(ctx context.Context) (model.ParserRegressionReport, error) {
+	receipts, err := s.ReceiptRepo.GetStoredReceipts(ctx)
+	if err != nil {
+		return model.ParserRegressionReport{}, fmt.Errorf("get stored receipts: %w", err)
+	}
+
+	accuracyByRetailer := make(map[string]*model.ParserAccuracy)
+	report := model.ParserRegressionReport{ChangedReceipts: []model.ChangedReceipt{}}
+	for _, receipt := range receipts {
+		accuracy, ok := accuracyByRetailer[receipt.Retailer]
+		if !ok {
+			accuracy = &model.ParserAccuracy{Retailer: receipt.Retailer}
+			accuracyByRetailer[receipt.Retailer] = accuracy
+		}
+
+		changedReceipt := model.ChangedReceipt{
+			ReceiptID: receipt.ID,
+			Retailer:  receipt.Retailer,
+			Date:      receipt.Date.Format(time.DateOnly),
+		}
+
+		products, skippedLines, err := reparseProducts(receipt)
+		if err != nil {
+			if receipt.IsSubmitted {
+				accuracy.Receipts++
+				accuracy.ParseErrors++
+				accuracy.SubmittedProducts += len(receipt.SubmittedProducts)
+			}
+			changedReceipt.Changes = []string{err.Error()}
+			report.ChangedReceipts = append(report.ChangedReceipts, changedReceipt)
+			continue
+		}
+
+		if changes := compareParsedProducts(receipt.ParsedProducts, products); len(changes) > 0 {
+			changedReceipt.Changes = changes
+			changedReceipt.SkippedLines = skippedLines
+			report.ChangedReceipts = append(report.ChangedReceipts, changedReceipt)
+		}
+
+		if !receipt.IsSubmitted {
+			continue
+		}
+
+		aliasByParsedName, err := s.ReceiptRepo.GetProductNameAlias(ctx, products.GetVarietyNames())
+		if err != nil {
+			return model.ParserRegressionReport{}, fmt.Errorf("get product name alias: %w", err)
+		}
+		products.UpdateProductNames(aliasByParsedName)
+
+		accuracy.SkippedLines += len(skippedLines)
+		addReceiptAccuracy(accuracy, receipt.SubmittedProducts, products)
+	}
+
+	for _, accuracy := range accuracyByRetailer {
+		if accuracy.Receipts == 0 {
+			continue
+		}
+		if accuracy.SubmittedProducts > 0 {
+			accuracy.NameAccuracy = getShare(accuracy.MatchedNames, accuracy.SubmittedProducts)
+			accuracy.QuantityAccuracy = getShare(accuracy.MatchedQuantities, accuracy.SubmittedProducts)
+			accuracy.PriceAccuracy = getShare(accuracy.MatchedPrices, accuracy.SubmittedProducts)
+		}
+		report.Accuracy = append(report.Accuracy, *accuracy)
+	}
+	slices.SortFunc(report.Accuracy, func(a, b model.ParserAccuracy) int {
+		return strings.Compare(a.Retailer, b.Retailer)
+	})
+	if report.Accuracy == nil {
+		report.Accuracy = []model.ParserAccuracy{}
+	}
+
+	return report, nil
+}
+
+func reparseProducts(receipt model.StoredReceipt) (model.ReceiptProducts, []string, error) {
+	receiptParser, err := retailer.NewReceiptParser(receipt.Receipt, receipt.Retailer)
+	if err != nil {
+		return nil, nil, fmt.Errorf("create receipt parser: %w", err)
+	}
+
+	products, skippedLines, err := receiptParser.ParseProducts()
+	if err != nil {
+		return nil, nil, fmt.Errorf("parse products: %w", err)
+	}
+	return products, skippedLines, nil
+}
+
+// addReceiptAccuracy matches every submitted product to a parsed product with the same name
+// and counts matching names, quantities and prices.
+func addReceiptAccuracy(accuracy *model.ParserAccuracy, submitted, parsed model.ReceiptProducts) {
+	accuracy.Receipts++
+	accuracy.SubmittedProducts += len(submitted)
+	accuracy.ParsedProducts += len(parsed)
+
+	isExact := len(submitted) == len(parsed)
+	used := make([]bool, len(parsed))
+	for _, submittedProduct := range submitted {
+		match := -1
+		for i, parsedProduct := range parsed {
+			if !used[i] && parsedProduct.Name == submittedProduct.Name && parsedProduct.VarietyName == submittedProduct.VarietyName {
+				match = i
+				break
+			}
+		}
+		if match == -1 {
+			isExact = false
+			continue
+		}
+		used[match] = true
+		accuracy.MatchedNames++
+
+		sameQuantity := isSameQuantity(parsed[match].Quantity, submittedProduct.Quantity)
+		if sameQuantity {
+			accuracy.MatchedQuantities++
+		}
+		samePrice := isSamePrice(getPriceWithDeposit(parsed[match]), getPriceWithDeposit(submittedProduct))
+		if samePrice {
+			accuracy.MatchedPrices++
+		}
+		isExact = isExact && sameQuantity && samePrice
+	}
+
+	if isExact {
+		accuracy.ExactReceipts++
+	}
+}
+
+// compareParsedProducts lists differences in names, quantities and prices between stored and current parser output.
+func compareParsedProducts(stored, current model.ReceiptProducts) []string {
+	var changes []string
+	if len(stored) != len(current) {
+		changes = append(changes, fmt.Sprintf("product count %d -> %d", len(stored), len(current)))
+	}
+
+	for i := range max(len(stored), len(current)) {
+		switch {
+		case i >= len(stored):
+			changes = append(changes, fmt.Sprintf("line %d: added %q", i+1, current[i].VarietyName))
+		case i >= len(current):
+			changes = append(changes, fmt.Sprintf("line %d: removed %q", i+1, stored[i].VarietyName))
+		default:
+			changes = append(changes, compareProduct(i+1, stored[i], current[i])...)
+		}
+	}
+	return changes
+}
+
+func compareProduct(line int, stored, current model.PurchasedProductNew) []string {
+	var changes []string
+	if stored.VarietyName != current.VarietyName {
+		changes = append(changes, fmt.Sprintf("line %d: name %q -> %q", line, stored.VarietyName, current.VarietyName))
+	}
+	if !isSameQuantity(stored.Quantity, current.Quantity) {
+		changes = append(changes, fmt.Sprintf("line %d: quantity %v %s -> %v %s",
+			line, stored.Quantity.Amount, stored.Quantity.Unit, current.Quantity.Amount, current.Quantity.Unit))
+	}
+	if storedPrice, currentPrice := getPriceWithDeposit(stored), getPriceWithDeposit(current); !isSamePrice(storedPrice, currentPrice) {
+		changes = append(changes, fmt.Sprintf("line %d: price %.2f -> %.2f", line, storedPrice, currentPrice))
+	}
+	return changes
+}
+
+func isSameQuantity(a, b model.Quantity) bool {
+	return a.Unit == b.Unit && math.Abs(a.Amount-b.Amount) < amountTolerance
+}
+
+// getPriceWithDeposit returns the price together with the container deposit. Products parsed and submitted
+// before deposits were tracked separately have the deposit included in the price.
+func getPriceWithDeposit(product model.PurchasedProductNew) float64 {
+	return product.Price + product.Deposit
+}
+
+func isSamePrice(a, b float64) bool {
+	return math.Abs(a-b) < priceTolerance
+}
+
+func getShare(part, total int) float64 {
+	return umath.RoundFloat(float64(part)/float64(total), 4)
+}
@@ -0,0 +1,170 @@
+package receipt
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/SarunasBucius/nutri-price-server/internal/model"
+	"github.com/stretchr/testify/require"
+)
+
+const rimiDepositReceipt = `UAB "RIMI LIETUVA"
+Kasa 05                           Kvitas 0124
+------------------------------------------------
+Gazuotas gėrimas COCA-COLA, 0,5 l        1,19 A
+Tara                                     0,10 A
+------------------------------------------------
+Iš viso mokėti                           1,29 EUR
+------------------------------------------------
+2024-05-15 10:02:44`
+
+func TestService_RunParserRegression(t *testing.T) {
+	milk := model.PurchasedProductNew{VarietyName: "Pienas ROKIŠKIO NAMINIS 2,5%, 1 l", Price: 1.39, FullPrice: 1.39}
+	confirmedMilk := model.PurchasedProductNew{Name: "milk", VarietyName: "Rokiškio naminis", Price: 1.39, FullPrice: 1.39}
+	// Stored before deposits were tracked separately, so the deposit is part of the price.
+	cola := model.PurchasedProductNew{VarietyName: "Gazuotas gėrimas COCA-COLA, 0,5 l", Price: 1.29, FullPrice: 1.29}
+
+	repo := &fakeReceiptRepo{
+		storedReceipts: []model.StoredReceipt{
+			{
+				ID: "1", Date: time.Date(2024, 5, 14, 0, 0, 0, 0, time.UTC), Retailer: "rimi", Receipt: rimiReceipt,
+				ParsedProducts: model.ReceiptProducts{milk}, SubmittedProducts: model.ReceiptProducts{confirmedMilk}, IsSubmitted: true,
+			},
+			{
+				ID: "2", Date: time.Date(2024, 5, 15, 0, 0, 0, 0, time.UTC), Retailer: "rimi", Receipt: rimiDepositReceipt,
+				ParsedProducts: model.ReceiptProducts{cola}, SubmittedProducts: model.ReceiptProducts{cola}, IsSubmitted: true,
+			},
+			{
+				ID: "3", Date: time.Date(2024, 5, 16, 0, 0, 0, 0, time.UTC), Retailer: "rimi", Receipt: "UAB \"RIMI LIETUVA\"\nbroken",
+			},
+		},
+		aliases: map[string]model.ProductAndVarietyName{
+			milk.VarietyName: {Name: confirmedMilk.Name, VarietyName: confirmedMilk.VarietyName},
+		},
+	}
+
+	got, err := NewReceiptService(repo, nil).RunParserRegression(context.Background())
+	require.NoError(t, err)
+
+	require.Equal(t, []model.ParserAccuracy{{
+		Retailer:          "rimi",
+		Receipts:          2,
+		ExactReceipts:     2,
+		SubmittedProducts: 2,
+		ParsedProducts:    2,
+		MatchedNames:      2,
+		MatchedQuantities: 2,
+		MatchedPrices:     2,
+		NameAccuracy:      1,
+		QuantityAccuracy:  1,
+		PriceAccuracy:     1,
+	}}, got.Accuracy)
+	require.Len(t, got.ChangedReceipts, 1)
+	require.Equal(t, "3", got.ChangedReceipts[0].ReceiptID)
+	require.Len(t, got.ChangedReceipts[0].Changes, 1)
+}
+
+func TestAddReceiptAccuracy(t *testing.T) {
+	grams := func(amount float64) model.Quantity { return model.Quantity{Unit: model.Grams, Amount: amount} }
+
+	tests := []struct {
+		name      string
+		submitted model.ReceiptProducts
+		parsed    model.ReceiptProducts
+		want      model.ParserAccuracy
+	}{
+		{
+			name:      "exact_receipt",
+			submitted: model.ReceiptProducts{{Name: "apples", VarietyName: "Gala", Price: 1.5, Quantity: grams(850)}},
+			parsed:    model.ReceiptProducts{{Name: "apples", VarietyName: "Gala", Price: 1.5, Quantity: grams(850)}},
+			want: model.ParserAccuracy{
+				Receipts: 1, ExactReceipts: 1, SubmittedProducts: 1, ParsedProducts: 1,
+				MatchedNames: 1, MatchedQuantities: 1, MatchedPrices: 1,
+			},
+		},
+		{
+			name:      "deposit_included_in_submitted_price",
+			submitted: model.ReceiptProducts{{Name: "cola", Price: 1.29}},
+			parsed:    model.ReceiptProducts{{Name: "cola", Price: 1.19, Deposit: 0.1}},
+			want: model.ParserAccuracy{
+				Receipts: 1, ExactReceipts: 1, SubmittedProducts: 1, ParsedProducts: 1,
+				MatchedNames: 1, MatchedQuantities: 1, MatchedPrices: 1,
+			},
+		},
+		{
+			name:      "different_quantity_and_price",
+			submitted: model.ReceiptProducts{{Name: "apples", Price: 1.5, Quantity: grams(850)}},
+			parsed:    model.ReceiptProducts{{Name: "apples", Price: 1.6, Quantity: grams(800)}},
+			want:      model.ParserAccuracy{Receipts: 1, SubmittedProducts: 1, ParsedProducts: 1, MatchedNames: 1},
+		},
+		{
+			name: "duplicate_products_are_matched_once",
+			submitted: model.ReceiptProducts{
+				{Name: "bread", Price: 1},
+				{Name: "bread", Price: 1},
+			},
+			parsed: model.ReceiptProducts{{Name: "bread", Price: 1}},
+			want: model.ParserAccuracy{
+				Receipts: 1, SubmittedProducts: 2, ParsedProducts: 1,
+				MatchedNames: 1, MatchedQuantities: 1, MatchedPrices: 1,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got model.ParserAccuracy
+			addReceiptAccuracy(&got, tt.submitted, tt.parsed)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCompareParsedProducts(t *testing.T) {
+	tests := []struct {
+		name    string
+		stored  model.ReceiptProducts
+		current model.ReceiptProducts
+		want    []string
+	}{
+		{
+			name:    "no_changes",
+			stored:  model.ReceiptProducts{{VarietyName: "Gala", Price: 1.5}},
+			current: model.ReceiptProducts{{VarietyName: "Gala", Price: 1.5}},
+		},
+		{
+			name:    "deposit_moved_out_of_price",
+			stored:  model.ReceiptProducts{{VarietyName: "COCA-COLA", Price: 1.29}},
+			current: model.ReceiptProducts{{VarietyName: "COCA-COLA", Price: 1.19, Deposit: 0.1}},
+		},
+		{
+			name:   "changed_product",
+			stored: model.ReceiptProducts{{VarietyName: "Gala", Price: 1.5}},
+			current: model.ReceiptProducts{{
+				VarietyName: "Gala apples", Price: 1.2, Quantity: model.Quantity{Unit: model.Grams, Amount: 850},
+			}},
+			want: []string{
+				`line 1: name "Gala" -> "Gala apples"`,
+				"line 1: quantity 0  -> 850 grams",
+				"line 1: price 1.50 -> 1.20",
+			},
+		},
+		{
+			name:    "added_product",
+			stored:  model.ReceiptProducts{{VarietyName: "Gala"}},
+			current: model.ReceiptProducts{{VarietyName: "Gala"}, {VarietyName: "Bread"}},
+			want:    []string{"product count 1 -> 2", `line 2: added "Bread"`},
+		},
+		{
+			name:    "removed_product",
+			stored:  model.ReceiptProducts{{VarietyName: "Gala"}, {VarietyName: "Bread"}},
+			current: model.ReceiptProducts{{VarietyName: "Gala"}},
+			want:    []string{"product count 2 -> 1", `line 2: removed "Bread"`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, compareParsedProducts(tt.stored, tt.current))
+		})
+	}
+}
@@ -42,28 +42,74 @@ func (p BarboraParser) ParseDate() (time.Time, error) {
 	return parsedDate, nil
 }
 
-func (p BarboraParser) ParseProducts() (model.ReceiptProducts, error) {
-	unparsedProducts, err := extractProductLines(p.ReceiptLines)
+// ParseProducts returns parsed products and product lines that could not be parsed.
+func (p BarboraParser) ParseProducts() (model.ReceiptProducts, []string, error) {
+	return parseProducts(p.ReceiptLines)
+}
+
+func (p BarboraParser) GetRetailer() string { return retailer }
+
+// Detect returns confidence that the receipt was issued by Barbora.
+// Barbora receipts name MAXIMA LT as the seller, so a Barbora mention scores above a plain Maxima mention.
+func Detect(receipt string) float64 {
+	switch {
+	case strings.HasPrefix(receipt, "Barbora\n"):
+		return 1
+	case strings.Contains(receipt, "Barbora"):
+		return 0.7
+	default:
+		return 0
+	}
+}
+
+func parseProducts(receiptLines []string) (model.ReceiptProducts, []string, error) {
+	unparsedProducts, err := extractProductLines(receiptLines)
 	if err != nil {
-		return nil, fmt.Errorf("extract product lines: %w", err)
+		return nil, nil, fmt.Errorf("extract product lines: %w", err)
 	}
 
 	parsedProducts := make([]model.PurchasedProductNew, 0, len(unparsedProducts))
+	productLines := make([]string, 0, len(unparsedProducts))
+	var skippedLines []string
 	for _, product := range unparsedProducts {
-		if isDeposit(product) {
-			parsedProducts[len(parsedProducts)-1].Price += 0.1
+		if isDeposit(product) && len(parsedProducts) > 0 {
+			lastProduct := &parsedProducts[len(parsedProducts)-1]
+			lastProduct.Deposit = umath.RoundFloat(lastProduct.Deposit+getDeposit(product), 2)
 			continue
 		}
 		parsedProduct, err := parseProduct(product)
 		if err != nil {
-			return nil, fmt.Errorf("parse product %+v: %w", product, err)
+			skippedLines = append(skippedLines, product)
+			continue
 		}
 		parsedProducts = append(parsedProducts, parsedProduct)
+		productLines = append(productLines, product)
+	}
+
+	productNames := make([]string, 0, len(parsedProducts))
+	for _, product := range parsedProducts {
+		productNames = append(productNames, product.VarietyName)
+	}
+	discountedProducts, unfinishedDiscount := extractDiscountedProducts(receiptLines, productNames)
+	discountsByProduct, invalidDiscounts := getDiscountsByProduct(discountedProducts)
+	skippedLines = append(skippedLines, invalidDiscounts...)
+	if unfinishedDiscount != "" {
+		skippedLines = append(skippedLines, unfinishedDiscount)
 	}
-	return parsedProducts, nil
+
+	discountedParsedProducts := make([]model.PurchasedProductNew, 0, len(parsedProducts))
+	for i, product := range parsedProducts {
+		if err := applyDiscount(&product, discountsByProduct); err != nil {
+			skippedLines = append(skippedLines, productLines[i])
+			continue
+		}
+		discountedParsedProducts = append(discountedParsedProducts, product)
+	}
+	return discountedParsedProducts, skippedLines, nil
 }
 
-func (p BarboraParser) GetRetailer() string { return retailer }
+// eanCode matches EAN-8 and EAN-13 codes that some receipts print between the line number and the product name.
+var eanCode = regexp.MustCompile(`^(\d{8}|\d{13})$`)
 
 func parseProduct(product string) (model.PurchasedProductNew, error) {
 	productSplitBySpace := strings.Split(product, " ")
@@ -71,7 +117,14 @@ func parseProduct(product string) (model.PurchasedProductNew, error) {
 		return model.PurchasedProductNew{}, fmt.Errorf("unexpected product line: %v", product)
 	}
 
-	productName := strings.Join(productSplitBySpace[1:len(productSplitBySpace)-7], " ")
+	nameStart := 1
+	var articleCode string
+	if len(productSplitBySpace) > 9 && eanCode.MatchString(productSplitBySpace[1]) {
+		articleCode = productSplitBySpace[1]
+		nameStart = 2
+	}
+
+	productName := strings.Join(productSplitBySpace[nameStart:len(productSplitBySpace)-7], " ")
 
 	unparsedPrice := strings.TrimPrefix(productSplitBySpace[len(productSplitBySpace)-1], "€")
 	price, err := parsePrice(unparsedPrice)
@@ -89,10 +142,31 @@ func parseProduct(product string) (model.PurchasedProductNew, error) {
 	return model.PurchasedProductNew{
 		VarietyName: productName,
 		Price:       price,
+		FullPrice:   price,
 		Quantity:    quantity,
+		ArticleCode: articleCode,
 	}, nil
 }
 
+// applyDiscount adds discount listed under "Pritaikytos nuolaidos" to the full price,
+// as Barbora prints product lines with discounts already applied.
+func applyDiscount(product *model.PurchasedProductNew, discountsByProduct map[string]string) error {
+	unparsedDiscount, ok := discountsByProduct[product.VarietyName]
+	if !ok {
+		return nil
+	}
+
+	discount, err := parsePrice(unparsedDiscount)
+	if err != nil {
+		return fmt.Errorf("parse discount: %w", err)
+	}
+
+	product.Discount = discount
+	product.FullPrice = umath.RoundFloat(product.Price+discount, 2)
+	product.DiscountType = model.DiscountTypeMarkdown
+	return nil
+}
+
 func getQuantity(amount, unit, product string) (model.Quantity, error) {
 	amountFloat, err := ustrconv.StringToPositiveFloat(amount)
 	if err != nil {
@@ -194,7 +268,10 @@ func extractProductLines(receiptLines []string) ([]string, error) {
 	return products, nil
 }
 
-func extractDiscountedProducts(receiptLines []string) []string {
+// extractDiscountedProducts returns discount lines, joining product names wrapped over several lines.
+// Discounts are listed by product name, so the list ends at the first line that does not continue a name
+// of the products, such as a footer. A discount line left without an amount at the end is returned separately.
+func extractDiscountedProducts(receiptLines []string, productNames []string) ([]string, string) {
 	const discountStartMarker = "Pritaikytos nuolaidos"
 	discountsListStart := 0
 	for i := range receiptLines {
@@ -204,36 +281,63 @@ func extractDiscountedProducts(receiptLines []string) []string {
 		}
 	}
 	if discountsListStart == 0 || len(receiptLines) <= discountsListStart {
-		return nil
+		return nil, ""
 	}
-	discountedProducts := make([]string, 0, len(receiptLines))
+
+	var discountedProducts []string
+	var unfinished string
 	for i := discountsListStart; i < len(receiptLines); i++ {
-		if len(discountedProducts) == 0 {
-			discountedProducts = append(discountedProducts, receiptLines[i])
-			continue
+		if isTotalsLine(receiptLines[i]) {
+			break
 		}
-		lastProduct := len(discountedProducts) - 1
-		productAndDiscount := strings.Split(discountedProducts[lastProduct], " -€")
-		if len(productAndDiscount) != 2 {
-			discountedProducts[lastProduct] += " " + receiptLines[i]
+		line := strings.TrimSpace(receiptLines[i])
+		if unfinished != "" {
+			line = unfinished + " " + line
+		}
+		name, _, isDiscount := strings.Cut(line, " -€")
+		if !isProductNamePrefix(name, productNames) {
+			break
+		}
+		if !isDiscount {
+			unfinished = line
 			continue
 		}
-		discountedProducts = append(discountedProducts, receiptLines[i])
+		discountedProducts = append(discountedProducts, line)
+		unfinished = ""
 	}
-	return discountedProducts
+	return discountedProducts, unfinished
 }
 
-func getDiscountsByProduct(discountedProducts []string) (map[string]string, error) {
+func isProductNamePrefix(name string, productNames []string) bool {
+	if name == "" {
+		return false
+	}
+	for _, productName := range productNames {
+		if strings.HasPrefix(productName, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func isTotalsLine(line string) bool {
+	return strings.HasPrefix(line, "PVM ") || strings.HasPrefix(line, "Iš viso") || strings.HasPrefix(line, "Apmokėta")
+}
+
+// getDiscountsByProduct returns discounts by product name and discount lines that could not be parsed.
+func getDiscountsByProduct(discountedProducts []string) (map[string]string, []string) {
 	discountsByProduct := make(map[string]string, len(discountedProducts))
+	var invalidLines []string
 	for i := range discountedProducts {
 		productAndDiscount := strings.Split(discountedProducts[i], " -€")
 		if len(productAndDiscount) != 2 {
-			return nil, fmt.Errorf("invalid discount line: %s", discountedProducts[i])
+			invalidLines = append(invalidLines, discountedProducts[i])
+			continue
 		}
 		discountsByProduct[productAndDiscount[0]] = productAndDiscount[1]
 	}
 
-	return discountsByProduct, nil
+	return discountsByProduct, invalidLines
 }
 
 func extractProduct(line string, products []string) []string {
@@ -252,6 +356,15 @@ func extractProduct(line string, products []string) []string {
 	return products
 }
 
+// getDeposit returns the price of the deposit line, which covers every container of the product above it.
+func getDeposit(product string) float64 {
+	deposit, err := parseProduct(product)
+	if err != nil || deposit.Price <= 0 {
+		return model.DepositAmount
+	}
+	return deposit.Price
+}
+
 func isDeposit(product string) bool {
 	return strings.Contains(product, "(depozitinis)")
 }
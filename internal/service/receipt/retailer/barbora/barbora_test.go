@@ -15,7 +15,10 @@ const receiptExample = `Barbora
 1 Nektarinai, 1 kg 0.612 kg €1.6569 €1.3693 21,00 €0.84 €1.01
 2 Salotos ROMAINE, 300 g 1 vnt. €1.9900 €1.6446 21,00 €1.64 €1.99
 Pritaikytos nuolaidos
-Nektarinai, 1 kg -€1.10`
+Nektarinai, 1 kg -€1.10
+PVM 21,00% €0.52
+Iš viso €3.00
+Apmokėta €3.00`
 
 func TestBarboraParser_ParseDate(t *testing.T) {
 	type fields struct {
@@ -58,26 +61,31 @@ func TestBarboraParser_ParseProducts(t *testing.T) {
 		Retailer     string
 	}
 	tests := []struct {
-		name    string
-		fields  fields
-		want    model.ReceiptProducts
-		wantErr bool
+		name        string
+		fields      fields
+		want        model.ReceiptProducts
+		wantSkipped []string
+		wantErr     bool
 	}{
 		{
 			name:   "parse_products",
 			fields: fields{ReceiptLines: strings.Split(receiptExample, "\n"), Retailer: "barbora"},
 			want: model.ReceiptProducts{
 				{
-					Name:  "Nektarinai, 1 kg",
-					Price: 1.01,
+					VarietyName:  "Nektarinai, 1 kg",
+					Price:        1.01,
+					FullPrice:    2.11,
+					Discount:     1.1,
+					DiscountType: model.DiscountTypeMarkdown,
 					Quantity: model.Quantity{
 						Amount: 612,
 						Unit:   model.Grams,
 					},
 				},
 				{
-					Name:  "Salotos ROMAINE, 300 g",
-					Price: 1.99,
+					VarietyName: "Salotos ROMAINE, 300 g",
+					Price:       1.99,
+					FullPrice:   1.99,
 					Quantity: model.Quantity{
 						Amount: 300,
 						Unit:   model.Grams,
@@ -85,6 +93,122 @@ func TestBarboraParser_ParseProducts(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "parse_article_codes",
+			fields: fields{ReceiptLines: []string{
+				"Barbora",
+				"2023-04-16",
+				"1 4770190047816 Pienas ROKIŠKIO NAMINIS 2,5%, 1 l 1 vnt. €1.2900 €1.0661 21,00 €0.22 €1.29",
+				"2 Nektarinai, 1 kg 0.612 kg €1.6569 €1.3693 21,00 €0.84 €1.01",
+				"Pritaikytos nuolaidos",
+				"Iš viso €2.30",
+			}, Retailer: "barbora"},
+			want: model.ReceiptProducts{
+				{
+					VarietyName: "Pienas ROKIŠKIO NAMINIS 2,5%, 1 l",
+					Price:       1.29,
+					FullPrice:   1.29,
+					Quantity: model.Quantity{
+						Amount: 1000,
+						Unit:   model.Milliliters,
+					},
+					ArticleCode: "4770190047816",
+				},
+				{
+					VarietyName: "Nektarinai, 1 kg",
+					Price:       1.01,
+					FullPrice:   1.01,
+					Quantity: model.Quantity{
+						Amount: 612,
+						Unit:   model.Grams,
+					},
+				},
+			},
+		},
+		{
+			name: "trailing_footer_after_discounts",
+			fields: fields{ReceiptLines: []string{
+				"Barbora",
+				"2023-04-16",
+				"1 Nektarinai, 1 kg 0.612 kg €1.6569 €1.3693 21,00 €0.84 €1.01",
+				"2 Salotos ROMAINE, 300 g 1 vnt. €1.9900 €1.6446 21,00 €1.64 €1.99",
+				"Pritaikytos nuolaidos",
+				"Nektarinai,",
+				"1 kg -€1.10",
+				"Salotos ROMAINE,",
+				"Sukaupta AČIŪ taškų: 12",
+				"PVM 21,00% €0.52",
+				"Iš viso €3.00",
+			}, Retailer: "barbora"},
+			want: model.ReceiptProducts{
+				{
+					VarietyName:  "Nektarinai, 1 kg",
+					Price:        1.01,
+					FullPrice:    2.11,
+					Discount:     1.1,
+					DiscountType: model.DiscountTypeMarkdown,
+					Quantity: model.Quantity{
+						Amount: 612,
+						Unit:   model.Grams,
+					},
+				},
+				{
+					VarietyName: "Salotos ROMAINE, 300 g",
+					Price:       1.99,
+					FullPrice:   1.99,
+					Quantity: model.Quantity{
+						Amount: 300,
+						Unit:   model.Grams,
+					},
+				},
+			},
+			wantSkipped: []string{"Salotos ROMAINE,"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := BarboraParser{
+				ReceiptLines: tt.fields.ReceiptLines,
+				Retailer:     tt.fields.Retailer,
+			}
+			got, skipped, err := p.ParseProducts()
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+			require.Equal(t, tt.wantSkipped, skipped)
+		})
+	}
+}
+
+func TestBarboraParser_ParseTotals(t *testing.T) {
+	type fields struct {
+		ReceiptLines []string
+		Retailer     string
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		want    model.ReceiptTotals
+		wantErr bool
+	}{
+		{
+			name:   "parse_totals",
+			fields: fields{ReceiptLines: strings.Split(receiptExample, "\n"), Retailer: "barbora"},
+			want: model.ReceiptTotals{
+				Total: 3,
+				Paid:  3,
+				VAT:   []model.VATAmount{{Rate: 21, Amount: 0.52}},
+			},
+		},
+		{
+			name:   "totals_not_found",
+			fields: fields{ReceiptLines: []string{"Barbora"}, Retailer: "barbora"},
+			want:   model.ReceiptTotals{},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -92,7 +216,7 @@ func TestBarboraParser_ParseProducts(t *testing.T) {
 				ReceiptLines: tt.fields.ReceiptLines,
 				Retailer:     tt.fields.Retailer,
 			}
-			got, err := p.ParseProducts()
+			got, err := p.ParseTotals()
 			if tt.wantErr {
 				require.Error(t, err)
 				return
@@ -103,3 +227,47 @@ func TestBarboraParser_ParseProducts(t *testing.T) {
 		})
 	}
 }
+
+func TestBarboraParser_ParseTime(t *testing.T) {
+	tests := []struct {
+		name         string
+		receiptLines []string
+		want         string
+	}{
+		{
+			name:         "time_not_printed",
+			receiptLines: strings.Split(receiptExample, "\n"),
+			want:         "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := BarboraParser{ReceiptLines: tt.receiptLines, Retailer: "barbora"}
+			if got := p.ParseTime(); got != tt.want {
+				t.Errorf("BarboraParser.ParseTime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBarboraParser_ParseReceiptNumber(t *testing.T) {
+	tests := []struct {
+		name         string
+		receiptLines []string
+		want         string
+	}{
+		{
+			name:         "receipt_number_not_printed",
+			receiptLines: strings.Split(receiptExample, "\n"),
+			want:         "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := BarboraParser{ReceiptLines: tt.receiptLines, Retailer: "barbora"}
+			if got := p.ParseReceiptNumber(); got != tt.want {
+				t.Errorf("BarboraParser.ParseReceiptNumber() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
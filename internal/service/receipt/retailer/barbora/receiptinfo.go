@@ -0,0 +1,39 @@
+package barbora
+
+import "regexp"
+
+var (
+	timeRegexp          = regexp.MustCompile(`\b(\d{2}:\d{2})(:\d{2})?\b`)
+	receiptNumberRegexp = regexp.MustCompile(`(?i)užsakymo\s+nr\.?:?\s*(\S+)`)
+)
+
+// ParseTime returns purchase time in HH:MM:SS format or an empty string if the receipt has none.
+func (p BarboraParser) ParseTime() string {
+	for _, line := range p.ReceiptLines {
+		if purchaseTime := findTime(line); purchaseTime != "" {
+			return purchaseTime
+		}
+	}
+	return ""
+}
+
+// ParseReceiptNumber returns the number printed on the receipt or an empty string if it is not found.
+func (p BarboraParser) ParseReceiptNumber() string {
+	for _, line := range p.ReceiptLines {
+		if match := receiptNumberRegexp.FindStringSubmatch(line); len(match) == 2 {
+			return match[1]
+		}
+	}
+	return ""
+}
+
+func findTime(line string) string {
+	match := timeRegexp.FindStringSubmatch(line)
+	if len(match) != 3 {
+		return ""
+	}
+	if match[2] == "" {
+		return match[1] + ":00"
+	}
+	return match[1] + match[2]
+}
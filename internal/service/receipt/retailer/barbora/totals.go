@@ -0,0 +1,62 @@
+package barbora
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/SarunasBucius/nutri-price-server/internal/model"
+	"github.com/SarunasBucius/nutri-price-server/internal/utils/umath"
+	"github.com/SarunasBucius/nutri-price-server/internal/utils/ustrconv"
+)
+
+// vatLine matches VAT rows, e.g. "PVM 21,00% €0.52".
+var vatLine = regexp.MustCompile(`^PVM\s+(\d+,\d{2})\s*%\s+€(\d+\.\d{2})$`)
+
+func (p BarboraParser) ParseTotals() (model.ReceiptTotals, error) {
+	var totals model.ReceiptTotals
+	for _, line := range p.ReceiptLines {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, "Iš viso"):
+			total, err := parseEuroAmount(line)
+			if err != nil {
+				return model.ReceiptTotals{}, fmt.Errorf("parse total: %w", err)
+			}
+			totals.Total = total
+		case strings.HasPrefix(line, "Apmokėta"):
+			paid, err := parseEuroAmount(line)
+			if err != nil {
+				return model.ReceiptTotals{}, fmt.Errorf("parse paid sum: %w", err)
+			}
+			totals.Paid = umath.RoundFloat(totals.Paid+paid, 2)
+		case vatLine.MatchString(line):
+			match := vatLine.FindStringSubmatch(line)
+			rate, err := ustrconv.StringToPositiveFloat(match[1])
+			if err != nil {
+				return model.ReceiptTotals{}, fmt.Errorf("parse vat rate: %w", err)
+			}
+			amount, err := ustrconv.StringToPositiveFloat(match[2])
+			if err != nil {
+				return model.ReceiptTotals{}, fmt.Errorf("parse vat amount: %w", err)
+			}
+			totals.VAT = append(totals.VAT, model.VATAmount{
+				Rate:   umath.RoundFloat(rate, 2),
+				Amount: umath.RoundFloat(amount, 2),
+			})
+		}
+	}
+	return totals, nil
+}
+
+func parseEuroAmount(line string) (float64, error) {
+	_, unparsedAmount, found := strings.Cut(line, "€")
+	if !found {
+		return 0, fmt.Errorf("amount not found in line: %s", line)
+	}
+	amount, err := ustrconv.StringToPositiveFloat(unparsedAmount)
+	if err != nil {
+		return 0, err
+	}
+	return umath.RoundFloat(amount, 2), nil
+}
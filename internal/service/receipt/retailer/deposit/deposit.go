@@ -0,0 +1,28 @@
+// Package deposit reads container deposits printed on receipts.
+package deposit
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/SarunasBucius/nutri-price-server/internal/utils/umath"
+	"github.com/SarunasBucius/nutri-price-server/internal/utils/ustrconv"
+)
+
+// Sum returns the deposit printed on the deposit lines, which cover every container of the product above them.
+// The amount is the second to last field of a line, followed by the VAT group.
+func Sum(depositLines []string) (float64, error) {
+	var deposit float64
+	for _, depositLine := range depositLines {
+		depositSplitBySpace := strings.Split(depositLine, " ")
+		if len(depositSplitBySpace) < 2 {
+			return 0, fmt.Errorf("too short deposit line")
+		}
+		depositPrice, err := ustrconv.StringToPositiveFloat(depositSplitBySpace[len(depositSplitBySpace)-2])
+		if err != nil {
+			return 0, err
+		}
+		deposit += depositPrice
+	}
+	return umath.RoundFloat(deposit, 2), nil
+}
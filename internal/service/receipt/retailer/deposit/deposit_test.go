@@ -0,0 +1,49 @@
+package deposit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSum(t *testing.T) {
+	tests := []struct {
+		name         string
+		depositLines []string
+		want         float64
+		wantErr      bool
+	}{
+		{
+			name:         "no_deposit",
+			depositLines: nil,
+			want:         0,
+		},
+		{
+			name:         "several_deposit_lines",
+			depositLines: []string{"Užstatas                                        0,60 A", "Užstatas 0,10 A"},
+			want:         0.7,
+		},
+		{
+			name:         "too_short_line",
+			depositLines: []string{"Užstatas"},
+			wantErr:      true,
+		},
+		{
+			name:         "amount_not_printed",
+			depositLines: []string{"Užstatas už tarą A"},
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Sum(tt.depositLines)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
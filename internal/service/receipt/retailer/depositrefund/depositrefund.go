@@ -0,0 +1,159 @@
+// Package depositrefund parses slips printed by deposit return machines.
+package depositrefund
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/SarunasBucius/nutri-price-server/internal/model"
+	"github.com/SarunasBucius/nutri-price-server/internal/utils/umath"
+	"github.com/SarunasBucius/nutri-price-server/internal/utils/ustrconv"
+)
+
+const retailer = model.DepositRefundRetailer
+
+var (
+	dateRegexp          = regexp.MustCompile(`\d{4}-\d{2}-\d{2}`)
+	timeRegexp          = regexp.MustCompile(`\b(\d{2}:\d{2})(:\d{2})?\b`)
+	amountRegexp        = regexp.MustCompile(`(\d+,\d{2})\s*(EUR|€)?$`)
+	receiptNumberRegexp = regexp.MustCompile(`(?i)kvito\s+nr\.?:?\s*(\S+)`)
+)
+
+type DepositRefundParser struct {
+	ReceiptLines []string
+	Retailer     string
+}
+
+func NewParser(receiptLines []string) DepositRefundParser {
+	return DepositRefundParser{
+		ReceiptLines: receiptLines,
+		Retailer:     retailer,
+	}
+}
+
+func (p DepositRefundParser) ParseDate() (time.Time, error) {
+	for _, line := range p.ReceiptLines {
+		slipDate := dateRegexp.FindString(line)
+		if slipDate == "" {
+			continue
+		}
+
+		parsedDate, err := time.Parse(time.DateOnly, slipDate)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("parse slip date: %w", err)
+		}
+		return parsedDate, nil
+	}
+	return time.Time{}, fmt.Errorf("slip date not found")
+}
+
+// ParseTime returns slip time in HH:MM:SS format or an empty string if the slip has none.
+func (p DepositRefundParser) ParseTime() string {
+	for _, line := range p.ReceiptLines {
+		if !dateRegexp.MatchString(line) {
+			continue
+		}
+		match := timeRegexp.FindStringSubmatch(line)
+		if len(match) != 3 {
+			return ""
+		}
+		if match[2] == "" {
+			return match[1] + ":00"
+		}
+		return match[1] + match[2]
+	}
+	return ""
+}
+
+// ParseReceiptNumber returns the slip number or an empty string if it is not found.
+func (p DepositRefundParser) ParseReceiptNumber() string {
+	for _, line := range p.ReceiptLines {
+		if match := receiptNumberRegexp.FindStringSubmatch(line); len(match) == 2 {
+			return match[1]
+		}
+	}
+	return ""
+}
+
+// ParseProducts returns no products, as refund slips list returned containers only.
+func (p DepositRefundParser) ParseProducts() (model.ReceiptProducts, []string, error) {
+	return model.ReceiptProducts{}, nil, nil
+}
+
+// ParseTotals returns the refund as a negative total, as the slip reduces the amount paid at the checkout.
+func (p DepositRefundParser) ParseTotals() (model.ReceiptTotals, error) {
+	refund, err := p.ParseDepositRefund()
+	if err != nil {
+		return model.ReceiptTotals{}, fmt.Errorf("parse deposit refund: %w", err)
+	}
+	return model.ReceiptTotals{Total: -refund}, nil
+}
+
+// ParseDepositRefund returns the amount that the slip can be redeemed for.
+func (p DepositRefundParser) ParseDepositRefund() (float64, error) {
+	for _, line := range p.ReceiptLines {
+		line = strings.TrimSpace(line)
+		if !isAmountLine(line) {
+			continue
+		}
+
+		match := amountRegexp.FindStringSubmatch(line)
+		if len(match) != 3 {
+			return 0, fmt.Errorf("amount not found in line %q", line)
+		}
+		amount, err := ustrconv.StringToPositiveFloat(match[1])
+		if err != nil {
+			return 0, fmt.Errorf("parse amount: %w", err)
+		}
+		return umath.RoundFloat(amount, 2), nil
+	}
+	return 0, fmt.Errorf("refund amount not found")
+}
+
+func (p DepositRefundParser) GetRetailer() string { return retailer }
+
+// slipHeaderLines is the number of lines at the top of a slip that hold the machine name and the slip title.
+const slipHeaderLines = 4
+
+// Detect returns confidence that the receipt is a deposit refund slip. The slip title or the machine name
+// must be a line of its own in the slip header and the slip must print the refund amount, so store receipts
+// that redeem a slip are not mistaken for one.
+func Detect(receipt string) float64 {
+	lines := strings.Split(receipt, "\n")
+	lines = slices.DeleteFunc(lines, func(l string) bool {
+		return strings.TrimSpace(l) == ""
+	})
+
+	var confidence float64
+	for _, line := range lines[:min(len(lines), slipHeaderLines)] {
+		switch strings.ToUpper(strings.TrimSpace(line)) {
+		case "UŽSTATO GRĄŽINIMO KVITAS":
+			confidence = 1
+		case "TOMRA":
+			// Slips printed by TOMRA machines carry the store header too, so the marker outscores retailer headers.
+			confidence = max(confidence, 0.95)
+		}
+	}
+	if confidence == 0 || !hasRefundAmount(lines) {
+		return 0
+	}
+	return confidence
+}
+
+func hasRefundAmount(lines []string) bool {
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if isAmountLine(line) && amountRegexp.MatchString(line) {
+			return true
+		}
+	}
+	return false
+}
+
+func isAmountLine(line string) bool {
+	lowerCaseLine := strings.ToLower(line)
+	return strings.HasPrefix(lowerCaseLine, "suma") || strings.HasPrefix(lowerCaseLine, "iš viso")
+}
@@ -0,0 +1,131 @@
+package depositrefund
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SarunasBucius/nutri-price-server/internal/model"
+	"github.com/stretchr/testify/require"
+)
+
+const slipExample = `TOMRA
+UŽSTATO GRĄŽINIMO KVITAS
+MAXIMA LT, UAB
+Savanorių pr. 247, Vilnius
+Plastikiniai buteliai        8 x 0,10
+Skardinės                    4 x 0,10
+Stikliniai buteliai          1 x 0,10
+Suma:                        1,30 EUR
+Kvito Nr. 004512
+2024-05-14 18:20:11`
+
+func TestDepositRefundParser_ParseDate(t *testing.T) {
+	tests := []struct {
+		name         string
+		receiptLines []string
+		want         time.Time
+		wantErr      bool
+	}{
+		{
+			name:         "parse_date",
+			receiptLines: strings.Split(slipExample, "\n"),
+			want:         time.Date(2024, 5, 14, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:         "date_not_found",
+			receiptLines: []string{"TOMRA"},
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewParser(tt.receiptLines)
+			got, err := p.ParseDate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DepositRefundParser.ParseDate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DepositRefundParser.ParseDate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDepositRefundParser_ParseDepositRefund(t *testing.T) {
+	tests := []struct {
+		name         string
+		receiptLines []string
+		want         float64
+		wantErr      bool
+	}{
+		{
+			name:         "parse_refund",
+			receiptLines: strings.Split(slipExample, "\n"),
+			want:         1.3,
+		},
+		{
+			name:         "refund_not_found",
+			receiptLines: []string{"TOMRA", "2024-05-14 18:20:11"},
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewParser(tt.receiptLines)
+			got, err := p.ParseDepositRefund()
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDepositRefundParser_ParseTotals(t *testing.T) {
+	p := NewParser(strings.Split(slipExample, "\n"))
+	got, err := p.ParseTotals()
+	require.NoError(t, err)
+	require.Equal(t, model.ReceiptTotals{Total: -1.3}, got)
+}
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name    string
+		receipt string
+		want    float64
+	}{
+		{
+			name:    "refund_slip",
+			receipt: slipExample,
+			want:    1,
+		},
+		{
+			name:    "store_receipt_with_deposit",
+			receipt: "UAB \"Lidl Lietuva\"\nUžstatas 0,10 A",
+			want:    0,
+		},
+		{
+			name:    "store_receipt_redeeming_slip",
+			receipt: "MAXIMA LT, UAB\nKvitas 198/1583\nPienas                    1,39 A\nUžstato grąžinimo kvitas 004512    -1,30 A\nMokėti                    0,09",
+			want:    0,
+		},
+		{
+			name:    "slip_without_refund_amount",
+			receipt: "TOMRA\nUŽSTATO GRĄŽINIMO KVITAS\n2024-05-14 18:20:11",
+			want:    0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Detect(tt.receipt); got != tt.want {
+				t.Errorf("Detect() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
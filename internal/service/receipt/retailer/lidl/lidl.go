@@ -8,6 +8,8 @@ import (
 	"time"
 
 	"github.com/SarunasBucius/nutri-price-server/internal/model"
+	"github.com/SarunasBucius/nutri-price-server/internal/service/receipt/retailer/deposit"
+	"github.com/SarunasBucius/nutri-price-server/internal/service/receipt/retailer/promo"
 	"github.com/SarunasBucius/nutri-price-server/internal/utils/umath"
 	"github.com/SarunasBucius/nutri-price-server/internal/utils/ustrconv"
 )
@@ -28,7 +30,7 @@ func NewParser(receiptLines []string) LidlParser {
 
 type unparsedProduct struct {
 	product       string
-	hasDeposit    bool
+	deposits      []string
 	discount      string
 	isHalf        bool
 	dynamicWeight []string
@@ -55,25 +57,25 @@ func (p LidlParser) ParseDate() (time.Time, error) {
 	return parsedDate, nil
 }
 
-func (p LidlParser) ParseProducts() (model.ReceiptProducts, error) {
-	unparsedProducts, err := extractProductLines(p.ReceiptLines)
-	if err != nil {
-		return nil, fmt.Errorf("extract product lines: %w", err)
-	}
-
-	parsedProducts := make([]model.PurchasedProductNew, 0, len(unparsedProducts))
-	for _, product := range unparsedProducts {
-		parsedProduct, err := parseProduct(product)
-		if err != nil {
-			return nil, fmt.Errorf("parse product %+v: %w", product, err)
-		}
-		parsedProducts = append(parsedProducts, parsedProduct)
-	}
-	return parsedProducts, nil
+// ParseProducts returns parsed products and product list lines that were not recognised or could not be parsed.
+func (p LidlParser) ParseProducts() (model.ReceiptProducts, []string, error) {
+	return parseProducts(p.ReceiptLines)
 }
 
 func (p LidlParser) GetRetailer() string { return retailer }
 
+// Detect returns confidence that the receipt was issued by Lidl.
+func Detect(receipt string) float64 {
+	switch {
+	case strings.Contains(receipt, "Lidl Lietuva"):
+		return 1
+	case strings.Contains(strings.ToUpper(receipt), "LIDL"):
+		return 0.4
+	default:
+		return 0
+	}
+}
+
 func getDateLine(receiptLines []string) (string, error) {
 	if len(receiptLines) < 6 {
 		return "", fmt.Errorf("unexpected receipt length")
@@ -85,36 +87,60 @@ func getDateLine(receiptLines []string) (string, error) {
 	return receiptLines[len(receiptLines)-1], nil
 }
 
-func extractProductLines(receiptLines []string) ([]unparsedProduct, error) {
+func parseProducts(receiptLines []string) (model.ReceiptProducts, []string, error) {
+	unparsedProducts, skippedLines, err := extractProductLines(receiptLines)
+	if err != nil {
+		return nil, nil, fmt.Errorf("extract product lines: %w", err)
+	}
+
+	parsedProducts := make([]model.PurchasedProductNew, 0, len(unparsedProducts))
+	for _, product := range unparsedProducts {
+		parsedProduct, err := parseProduct(product)
+		if err != nil {
+			skippedLines = append(skippedLines, strings.TrimSpace(product.product))
+			continue
+		}
+		parsedProducts = append(parsedProducts, parsedProduct)
+	}
+	return parsedProducts, skippedLines, nil
+}
+
+func extractProductLines(receiptLines []string) ([]unparsedProduct, []string, error) {
 	const productsEndSeparator = "------------------------------------------------------"
 	const linesBeforeProductsList = 4
 	if len(receiptLines) <= linesBeforeProductsList {
-		return nil, fmt.Errorf("too short receipt")
+		return nil, nil, fmt.Errorf("too short receipt")
 	}
 	receiptLines = receiptLines[linesBeforeProductsList:]
 
 	var products []unparsedProduct
+	var skippedLines []string
 	for i := range receiptLines {
 		if strings.HasSuffix(receiptLines[i], productsEndSeparator) {
 			break
 		}
 
-		products = extractProduct(receiptLines[i], products)
+		var isSkipped bool
+		products, isSkipped = extractProduct(receiptLines[i], products)
+		if isSkipped {
+			skippedLines = append(skippedLines, strings.TrimSpace(receiptLines[i]))
+		}
 	}
 
-	return products, nil
+	return products, skippedLines, nil
 }
 
-func extractProduct(line string, products []unparsedProduct) []unparsedProduct {
+// extractProduct adds the line to products and reports whether the line was skipped.
+func extractProduct(line string, products []unparsedProduct) ([]unparsedProduct, bool) {
 	if len(products) == 0 {
-		return appendProduct(line, products)
+		return appendProduct(line, products), false
 	}
 
 	lastProduct := len(products) - 1
 
 	if isDeposit(line) {
-		products[lastProduct].hasDeposit = true
-		return products
+		products[lastProduct].deposits = append(products[lastProduct].deposits, line)
+		return products, false
 	}
 
 	if products[lastProduct].isHalf {
@@ -126,23 +152,23 @@ func extractProduct(line string, products []unparsedProduct) []unparsedProduct {
 		if isDynamic {
 			products[lastProduct].dynamicWeight = lineSplitBySpace
 			products[lastProduct].isHalf = false
-			return products
+			return products, false
 		}
 		products[lastProduct].product += " " + line
 		products[lastProduct].isHalf = !strings.HasSuffix(line, "A")
-		return products
+		return products, false
 	}
 
 	if startsWithNumericCode(line) {
-		return appendProduct(line, products)
+		return appendProduct(line, products), false
 	}
 
 	if isDiscount(line) {
 		products[lastProduct].discount = line
-		return products
+		return products, false
 	}
 
-	return products
+	return products, true
 }
 
 func isDeposit(line string) bool {
@@ -174,15 +200,30 @@ func isDiscount(product string) bool {
 }
 
 func parseProduct(product unparsedProduct) (model.PurchasedProductNew, error) {
-	const irrelevantProductPrefixLength = 7
-	product.product = product.product[irrelevantProductPrefixLength:]
+	const articleCodeLength = 7
+	var articleCode string
+	if startsWithNumericCode(product.product) {
+		articleCode = product.product[:articleCodeLength]
+	}
+	product.product = product.product[articleCodeLength:]
 
 	unparsedPrice := getUnparsedPrice(product)
-	price, err := parsePrice(product, unparsedPrice)
+	fullPrice, err := parsePrice(product, unparsedPrice)
 	if err != nil {
 		return model.PurchasedProductNew{}, err
 	}
 
+	discount, err := parseDiscount(product.discount)
+	if err != nil {
+		return model.PurchasedProductNew{}, fmt.Errorf("parse product discount: %w", err)
+	}
+	discount = umath.RoundFloat(discount, 2)
+
+	depositAmount, err := deposit.Sum(product.deposits)
+	if err != nil {
+		return model.PurchasedProductNew{}, fmt.Errorf("parse product deposit: %w", err)
+	}
+
 	productName := trimPriceInfoFromProductName(product.product, unparsedPrice)
 
 	quantity, err := getQuantity(product)
@@ -191,9 +232,14 @@ func parseProduct(product unparsedProduct) (model.PurchasedProductNew, error) {
 	}
 
 	return model.PurchasedProductNew{
-		VarietyName: strings.TrimSpace(productName),
-		Price:       price,
-		Quantity:    quantity,
+		VarietyName:  strings.TrimSpace(productName),
+		Price:        umath.RoundFloat(fullPrice-discount, 2),
+		FullPrice:    fullPrice,
+		Discount:     discount,
+		DiscountType: promo.Classify(product.discount),
+		Deposit:      depositAmount,
+		Quantity:     quantity,
+		ArticleCode:  articleCode,
 	}, nil
 }
 
@@ -236,16 +282,7 @@ func parsePrice(product unparsedProduct, unparsedPrice string) (float64, error)
 	if err != nil {
 		return 0, fmt.Errorf("parse product price: %w", err)
 	}
-	if product.hasDeposit {
-		fullPrice += 0.10
-	}
-
-	discount, err := parseDiscount(product.discount)
-	if err != nil {
-		return 0, fmt.Errorf("parse product discount: %w", err)
-	}
-
-	return umath.RoundFloat(fullPrice-discount, 2), nil
+	return umath.RoundFloat(fullPrice, 2), nil
 }
 
 func parseDiscount(discountLine string) (float64, error) {
@@ -97,28 +97,59 @@ func TestLidlParser_ParseProducts(t *testing.T) {
 			fields: fields{ReceiptLines: strings.Split(receiptExample, "\n"), Retailer: "lidl"},
 			want: model.ReceiptProducts{
 				{
-					Name:  "Tamsusis šokoladas",
-					Price: 1.98,
+					VarietyName: "Tamsusis šokoladas",
+					ArticleCode: "0159177",
+					Price:       1.98,
+					FullPrice:   1.98,
 					Quantity: model.Quantity{
 						Unit:   model.Pieces,
 						Amount: 2,
 					},
 				},
 				{
-					Name:  "Vynuogės žal.be kaul",
-					Price: 1.29,
+					VarietyName: "Vynuogės žal.be kaul",
+					ArticleCode: "0080505",
+					Price:       1.29,
+					FullPrice:   1.29,
 				},
 				{
-					Name:  "Obuol. Crimson Snow",
-					Price: 2.33,
+					VarietyName: "Obuol. Crimson Snow",
+					ArticleCode: "0080206",
+					Price:       2.33,
+					FullPrice:   2.33,
 					Quantity: model.Quantity{
 						Unit:   model.Grams,
 						Amount: 1232,
 					},
 				},
 				{
-					Name:  "Juod.duon.su saulėg.",
-					Price: 1.25,
+					VarietyName:  "Juod.duon.su saulėg.",
+					ArticleCode:  "7605416",
+					Price:        1.25,
+					FullPrice:    1.79,
+					Discount:     0.54,
+					DiscountType: model.DiscountTypeMarkdown,
+				},
+			},
+		},
+		{
+			name: "deposit_from_printed_amount",
+			fields: fields{ReceiptLines: []string{
+				`UAB "Lidl Lietuva" Į. k.: 111791015`,
+				"...",
+				"PVM mokėtojo kodas LT117910113",
+				"Kvitas 64/128                                #00017499",
+				"0123456   Min. vanduo 6x1,5l                    2,49 A",
+				"Užstatas                                        0,60 A",
+				"------------------------------------------------------",
+			}, Retailer: "lidl"},
+			want: model.ReceiptProducts{
+				{
+					VarietyName: "Min. vanduo 6x1,5l",
+					ArticleCode: "0123456",
+					Price:       2.49,
+					FullPrice:   2.49,
+					Deposit:     0.6,
 				},
 			},
 		},
@@ -129,7 +160,7 @@ func TestLidlParser_ParseProducts(t *testing.T) {
 				ReceiptLines: tt.fields.ReceiptLines,
 				Retailer:     tt.fields.Retailer,
 			}
-			got, err := p.ParseProducts()
+			got, _, err := p.ParseProducts()
 			if tt.wantErr {
 				require.Error(t, err)
 				return
@@ -140,3 +171,110 @@ func TestLidlParser_ParseProducts(t *testing.T) {
 		})
 	}
 }
+
+func TestLidlParser_ParseTotals(t *testing.T) {
+	type fields struct {
+		ReceiptLines []string
+		Retailer     string
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		want    model.ReceiptTotals
+		wantErr bool
+	}{
+		{
+			name:   "parse_totals",
+			fields: fields{ReceiptLines: strings.Split(receiptExample, "\n"), Retailer: "lidl"},
+			want: model.ReceiptTotals{
+				Total: 20.32,
+				Paid:  20.32,
+				VAT:   []model.VATAmount{{Rate: 21, Amount: 3.53, Net: 16.79, Gross: 20.32}},
+			},
+		},
+		{
+			name: "split_payment",
+			fields: fields{ReceiptLines: []string{
+				"Mokėti                      0,30",
+				"Mokėta kortele              0,10",
+				"Mokėta grynais              0,20",
+			}, Retailer: "lidl"},
+			want: model.ReceiptTotals{Total: 0.3, Paid: 0.3},
+		},
+		{
+			name:   "totals_not_found",
+			fields: fields{ReceiptLines: []string{"UAB \"Lidl Lietuva\""}, Retailer: "lidl"},
+			want:   model.ReceiptTotals{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := LidlParser{
+				ReceiptLines: tt.fields.ReceiptLines,
+				Retailer:     tt.fields.Retailer,
+			}
+			got, err := p.ParseTotals()
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLidlParser_ParseTime(t *testing.T) {
+	tests := []struct {
+		name         string
+		receiptLines []string
+		want         string
+	}{
+		{
+			name:         "parse_time",
+			receiptLines: strings.Split(receiptExample, "\n"),
+			want:         "15:47:08",
+		},
+		{
+			name:         "time_not_found",
+			receiptLines: []string{"UAB \"Lidl Lietuva\""},
+			want:         "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := LidlParser{ReceiptLines: tt.receiptLines, Retailer: "lidl"}
+			if got := p.ParseTime(); got != tt.want {
+				t.Errorf("LidlParser.ParseTime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLidlParser_ParseReceiptNumber(t *testing.T) {
+	tests := []struct {
+		name         string
+		receiptLines []string
+		want         string
+	}{
+		{
+			name:         "parse_receipt_number",
+			receiptLines: strings.Split(receiptExample, "\n"),
+			want:         "0226-017637-86-20240416",
+		},
+		{
+			name:         "receipt_number_not_found",
+			receiptLines: []string{"UAB \"Lidl Lietuva\""},
+			want:         "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := LidlParser{ReceiptLines: tt.receiptLines, Retailer: "lidl"}
+			if got := p.ParseReceiptNumber(); got != tt.want {
+				t.Errorf("LidlParser.ParseReceiptNumber() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
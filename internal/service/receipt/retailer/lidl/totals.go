@@ -0,0 +1,70 @@
+package lidl
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/SarunasBucius/nutri-price-server/internal/model"
+	"github.com/SarunasBucius/nutri-price-server/internal/utils/umath"
+	"github.com/SarunasBucius/nutri-price-server/internal/utils/ustrconv"
+)
+
+// vatLine matches VAT table rows, e.g. "A=21,00%   3,53   16,79   20,32".
+var vatLine = regexp.MustCompile(`^[A-Z]=(\d+,\d{2})%\s+(-?\d+,\d{2})\s+(-?\d+,\d{2})\s+(-?\d+,\d{2})$`)
+
+func (p LidlParser) ParseTotals() (model.ReceiptTotals, error) {
+	var totals model.ReceiptTotals
+	for _, line := range p.ReceiptLines {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, "Mokėti "):
+			total, err := parseLastNumber(line)
+			if err != nil {
+				return model.ReceiptTotals{}, fmt.Errorf("parse total: %w", err)
+			}
+			totals.Total = total
+		case strings.HasPrefix(line, "Mokėta"):
+			paid, err := parseLastNumber(line)
+			if err != nil {
+				return model.ReceiptTotals{}, fmt.Errorf("parse paid sum: %w", err)
+			}
+			totals.Paid = umath.RoundFloat(totals.Paid+paid, 2)
+		case vatLine.MatchString(line):
+			vat, err := parseVATLine(line)
+			if err != nil {
+				return model.ReceiptTotals{}, fmt.Errorf("parse vat line: %w", err)
+			}
+			totals.VAT = append(totals.VAT, vat)
+		}
+	}
+	return totals, nil
+}
+
+func parseVATLine(line string) (model.VATAmount, error) {
+	match := vatLine.FindStringSubmatch(line)
+	numbers := make([]float64, 0, len(match)-1)
+	for _, unparsedNumber := range match[1:] {
+		number, err := ustrconv.StringToPositiveFloat(unparsedNumber)
+		if err != nil {
+			return model.VATAmount{}, err
+		}
+		numbers = append(numbers, umath.RoundFloat(number, 2))
+	}
+
+	return model.VATAmount{
+		Rate:   numbers[0],
+		Amount: numbers[1],
+		Net:    numbers[2],
+		Gross:  numbers[3],
+	}, nil
+}
+
+func parseLastNumber(line string) (float64, error) {
+	lineSplitBySpace := strings.Fields(line)
+	number, err := ustrconv.StringToPositiveFloat(lineSplitBySpace[len(lineSplitBySpace)-1])
+	if err != nil {
+		return 0, err
+	}
+	return umath.RoundFloat(number, 2), nil
+}
@@ -8,6 +8,8 @@ import (
 	"time"
 
 	"github.com/SarunasBucius/nutri-price-server/internal/model"
+	"github.com/SarunasBucius/nutri-price-server/internal/service/receipt/retailer/deposit"
+	"github.com/SarunasBucius/nutri-price-server/internal/service/receipt/retailer/promo"
 	"github.com/SarunasBucius/nutri-price-server/internal/utils/umath"
 	"github.com/SarunasBucius/nutri-price-server/internal/utils/ustrconv"
 )
@@ -28,7 +30,7 @@ func NewParser(receiptLines []string) MaximaParser {
 
 type unparsedProduct struct {
 	product       string
-	hasDeposit    bool
+	deposits      []string
 	discount      string
 	isHalf        bool
 	dynamicWeight []string
@@ -54,25 +56,26 @@ func (p MaximaParser) ParseDate() (time.Time, error) {
 	return parsedDate, nil
 }
 
-func (p MaximaParser) ParseProducts() (model.ReceiptProducts, error) {
-	unparsedProducts, err := extractProductLines(p.ReceiptLines)
-	if err != nil {
-		return nil, fmt.Errorf("extract product lines: %w", err)
-	}
-
-	parsedProducts := make([]model.PurchasedProductNew, 0, len(unparsedProducts))
-	for _, product := range unparsedProducts {
-		parsedProduct, err := parseProduct(product)
-		if err != nil {
-			return nil, fmt.Errorf("parse product %+v: %w", product, err)
-		}
-		parsedProducts = append(parsedProducts, parsedProduct)
-	}
-	return parsedProducts, nil
+// ParseProducts returns parsed products and product lines that could not be parsed.
+func (p MaximaParser) ParseProducts() (model.ReceiptProducts, []string, error) {
+	return parseProducts(p.ReceiptLines)
 }
 
 func (p MaximaParser) GetRetailer() string { return retailer }
 
+// Detect returns confidence that the receipt was issued by Maxima.
+// Barbora receipts may mention Maxima as well, so a mention alone scores lower than Barbora header.
+func Detect(receipt string) float64 {
+	switch {
+	case strings.HasPrefix(receipt, "MAXIMA LT"):
+		return 0.9
+	case strings.Contains(receipt, "MAXIMA"):
+		return 0.6
+	default:
+		return 0
+	}
+}
+
 func getDateLine(receiptLines []string) string {
 	for i := len(receiptLines) - 1; i >= 0; i-- {
 		if strings.Contains(strings.ToLower(receiptLines[i]), "laikas") {
@@ -82,6 +85,25 @@ func getDateLine(receiptLines []string) string {
 	return ""
 }
 
+func parseProducts(receiptLines []string) (model.ReceiptProducts, []string, error) {
+	unparsedProducts, err := extractProductLines(receiptLines)
+	if err != nil {
+		return nil, nil, fmt.Errorf("extract product lines: %w", err)
+	}
+
+	parsedProducts := make([]model.PurchasedProductNew, 0, len(unparsedProducts))
+	var skippedLines []string
+	for _, product := range unparsedProducts {
+		parsedProduct, err := parseProduct(product)
+		if err != nil {
+			skippedLines = append(skippedLines, strings.TrimSpace(product.product))
+			continue
+		}
+		parsedProducts = append(parsedProducts, parsedProduct)
+	}
+	return parsedProducts, skippedLines, nil
+}
+
 func extractProductLines(receiptLines []string) ([]unparsedProduct, error) {
 	const productsEndSeparator = "========================"
 	productsListStart := findProductsListStart(receiptLines)
@@ -119,7 +141,7 @@ func extractProduct(line string, products []unparsedProduct) []unparsedProduct {
 	lastProduct := len(products) - 1
 
 	if isDeposit(line) {
-		products[lastProduct].hasDeposit = true
+		products[lastProduct].deposits = append(products[lastProduct].deposits, line)
 		return products
 	}
 
@@ -174,11 +196,22 @@ func isDiscount(product string) bool {
 
 func parseProduct(product unparsedProduct) (model.PurchasedProductNew, error) {
 	unparsedPrice := getUnparsedPrice(product)
-	price, err := parsePrice(product, unparsedPrice)
+	fullPrice, err := parsePrice(product, unparsedPrice)
 	if err != nil {
 		return model.PurchasedProductNew{}, err
 	}
 
+	discount, err := parseDiscount(product.discount)
+	if err != nil {
+		return model.PurchasedProductNew{}, fmt.Errorf("parse product discount: %w", err)
+	}
+	discount = umath.RoundFloat(discount, 2)
+
+	depositAmount, err := deposit.Sum(product.deposits)
+	if err != nil {
+		return model.PurchasedProductNew{}, fmt.Errorf("parse product deposit: %w", err)
+	}
+
 	productName := trimPriceInfoFromProductName(product.product, unparsedPrice)
 
 	quantity, err := getQuantity(product)
@@ -187,9 +220,13 @@ func parseProduct(product unparsedProduct) (model.PurchasedProductNew, error) {
 	}
 
 	return model.PurchasedProductNew{
-		VarietyName: strings.TrimSpace(productName),
-		Price:       price,
-		Quantity:    quantity,
+		VarietyName:  strings.TrimSpace(productName),
+		Price:        umath.RoundFloat(fullPrice-discount, 2),
+		FullPrice:    fullPrice,
+		Discount:     discount,
+		DiscountType: promo.Classify(product.discount),
+		Deposit:      depositAmount,
+		Quantity:     quantity,
 	}, nil
 }
 
@@ -232,16 +269,7 @@ func parsePrice(product unparsedProduct, unparsedPrice string) (float64, error)
 	if err != nil {
 		return 0, fmt.Errorf("parse product price: %w", err)
 	}
-	if product.hasDeposit {
-		fullPrice += 0.10
-	}
-
-	discount, err := parseDiscount(product.discount)
-	if err != nil {
-		return 0, fmt.Errorf("parse product discount: %w", err)
-	}
-
-	return umath.RoundFloat(fullPrice-discount, 2), nil
+	return umath.RoundFloat(fullPrice, 2), nil
 }
 
 func parseDiscount(discountLine string) (float64, error) {
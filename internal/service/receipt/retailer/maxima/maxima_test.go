@@ -28,6 +28,10 @@ Nuolaida:Raudonos saldžiosios paprikos, 80-100 mm
 Lietuviški trumpavaisiai agurkai
   2,49 X 0,514 kg                                 1,28 A
 ====================================================== #
+Mokėti                                            6,65 #
+Banko kortelė                                     6,65 #
+PVM   Be PVM   PVM suma   Su PVM                       #
+A        21,00      5,50     1,15     6,65             #
 LAIKAS             2024-09-12 19:26:07                 #`
 
 func TestMaximaParser_ParseDate(t *testing.T) {
@@ -81,32 +85,43 @@ func TestMaximaParser_ParseProducts(t *testing.T) {
 			fields: fields{ReceiptLines: strings.Split(receiptExample, "\n"), Retailer: "maxima"},
 			want: model.ReceiptProducts{
 				{
-					Name:  "Raudonėliai SALDVA",
-					Price: 0.64,
+					VarietyName:  "Raudonėliai SALDVA",
+					Price:        0.64,
+					FullPrice:    1.3,
+					Discount:     0.66,
+					DiscountType: model.DiscountTypeMarkdown,
 					Quantity: model.Quantity{
 						Unit:   model.Pieces,
 						Amount: 2,
 					},
 				},
 				{
-					Name:  "Visų grūdo dalių avižiniai dribsniai WELL DONE",
-					Price: 1.29,
+					VarietyName: "Visų grūdo dalių avižiniai dribsniai WELL DONE",
+					Price:       1.29,
+					FullPrice:   1.29,
 				},
 				{
-					Name:  "Juodasis šokoladas (72 %) PERGALĖ",
-					Price: 2.99,
+					VarietyName:  "Juodasis šokoladas (72 %) PERGALĖ",
+					Price:        2.99,
+					FullPrice:    4.99,
+					Discount:     2,
+					DiscountType: model.DiscountTypeMarkdown,
 				},
 				{
-					Name:  "Raudonos saldžiosios paprikos, 80-100 mm",
-					Price: 0.45,
+					VarietyName:  "Raudonos saldžiosios paprikos, 80-100 mm",
+					Price:        0.45,
+					FullPrice:    0.9,
+					Discount:     0.45,
+					DiscountType: model.DiscountTypeMarkdown,
 					Quantity: model.Quantity{
 						Unit:   model.Grams,
 						Amount: 300,
 					},
 				},
 				{
-					Name:  "Lietuviški trumpavaisiai agurkai",
-					Price: 1.28,
+					VarietyName: "Lietuviški trumpavaisiai agurkai",
+					Price:       1.28,
+					FullPrice:   1.28,
 					Quantity: model.Quantity{
 						Unit:   model.Grams,
 						Amount: 514,
@@ -114,6 +129,75 @@ func TestMaximaParser_ParseProducts(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "deposit_per_piece",
+			fields: fields{ReceiptLines: []string{
+				"MAXIMA LT, UAB",
+				"Kvitas 198/1583                                #00408752",
+				"Gazuotas gėrimas COCA-COLA, 0,5 l",
+				"  1,19 X 6 vnt.                                   7,14 A",
+				"Užstatas, depozitinė pakuotė                      0,60 A",
+				"====================================================== #",
+				"Mokėti                                            7,74 #",
+				"LAIKAS             2024-09-12 19:26:07                 #",
+			}, Retailer: "maxima"},
+			want: model.ReceiptProducts{
+				{
+					VarietyName: "Gazuotas gėrimas COCA-COLA, 0,5 l",
+					Price:       7.14,
+					FullPrice:   7.14,
+					Deposit:     0.6,
+					Quantity: model.Quantity{
+						Unit:   model.Pieces,
+						Amount: 6,
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := MaximaParser{
+				ReceiptLines: tt.fields.ReceiptLines,
+				Retailer:     tt.fields.Retailer,
+			}
+			got, _, err := p.ParseProducts()
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMaximaParser_ParseTotals(t *testing.T) {
+	type fields struct {
+		ReceiptLines []string
+		Retailer     string
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		want    model.ReceiptTotals
+		wantErr bool
+	}{
+		{
+			name:   "parse_totals",
+			fields: fields{ReceiptLines: strings.Split(receiptExample, "\n"), Retailer: "maxima"},
+			want: model.ReceiptTotals{
+				Total: 6.65,
+				Paid:  6.65,
+				VAT:   []model.VATAmount{{Rate: 21, Net: 5.5, Amount: 1.15, Gross: 6.65}},
+			},
+		},
+		{
+			name:   "totals_not_found",
+			fields: fields{ReceiptLines: []string{"MAXIMA LT, UAB"}, Retailer: "maxima"},
+			want:   model.ReceiptTotals{},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -121,7 +205,7 @@ func TestMaximaParser_ParseProducts(t *testing.T) {
 				ReceiptLines: tt.fields.ReceiptLines,
 				Retailer:     tt.fields.Retailer,
 			}
-			got, err := p.ParseProducts()
+			got, err := p.ParseTotals()
 			if tt.wantErr {
 				require.Error(t, err)
 				return
@@ -132,3 +216,57 @@ func TestMaximaParser_ParseProducts(t *testing.T) {
 		})
 	}
 }
+
+func TestMaximaParser_ParseTime(t *testing.T) {
+	tests := []struct {
+		name         string
+		receiptLines []string
+		want         string
+	}{
+		{
+			name:         "parse_time",
+			receiptLines: strings.Split(receiptExample, "\n"),
+			want:         "19:26:07",
+		},
+		{
+			name:         "time_not_found",
+			receiptLines: []string{"MAXIMA LT, UAB"},
+			want:         "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := MaximaParser{ReceiptLines: tt.receiptLines, Retailer: "maxima"}
+			if got := p.ParseTime(); got != tt.want {
+				t.Errorf("MaximaParser.ParseTime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMaximaParser_ParseReceiptNumber(t *testing.T) {
+	tests := []struct {
+		name         string
+		receiptLines []string
+		want         string
+	}{
+		{
+			name:         "parse_receipt_number",
+			receiptLines: strings.Split(receiptExample, "\n"),
+			want:         "198/1582",
+		},
+		{
+			name:         "receipt_number_not_found",
+			receiptLines: []string{"MAXIMA LT, UAB"},
+			want:         "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := MaximaParser{ReceiptLines: tt.receiptLines, Retailer: "maxima"}
+			if got := p.ParseReceiptNumber(); got != tt.want {
+				t.Errorf("MaximaParser.ParseReceiptNumber() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package maxima
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/SarunasBucius/nutri-price-server/internal/model"
+	"github.com/SarunasBucius/nutri-price-server/internal/utils/umath"
+	"github.com/SarunasBucius/nutri-price-server/internal/utils/ustrconv"
+)
+
+// vatLine matches VAT table rows, e.g. "A   21,00   10,20   2,14   12,34".
+var vatLine = regexp.MustCompile(`^[A-Z]\s+(\d+,\d{2})\s+(-?\d+,\d{2})\s+(-?\d+,\d{2})\s+(-?\d+,\d{2})$`)
+
+var paymentPrefixes = []string{"banko kortelė", "grynieji", "mokėta"}
+
+func (p MaximaParser) ParseTotals() (model.ReceiptTotals, error) {
+	var totals model.ReceiptTotals
+	for _, line := range p.ReceiptLines {
+		line = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(line), "#"))
+		lowerCaseLine := strings.ToLower(line)
+		switch {
+		case strings.HasPrefix(lowerCaseLine, "mokėti"):
+			total, err := parseLastNumber(line)
+			if err != nil {
+				return model.ReceiptTotals{}, fmt.Errorf("parse total: %w", err)
+			}
+			totals.Total = total
+		case isPaymentLine(lowerCaseLine):
+			paid, err := parseLastNumber(line)
+			if err != nil {
+				return model.ReceiptTotals{}, fmt.Errorf("parse paid sum: %w", err)
+			}
+			totals.Paid = umath.RoundFloat(totals.Paid+paid, 2)
+		case vatLine.MatchString(line):
+			vat, err := parseVATLine(line)
+			if err != nil {
+				return model.ReceiptTotals{}, fmt.Errorf("parse vat line: %w", err)
+			}
+			totals.VAT = append(totals.VAT, vat)
+		}
+	}
+	return totals, nil
+}
+
+func isPaymentLine(lowerCaseLine string) bool {
+	for _, prefix := range paymentPrefixes {
+		if strings.HasPrefix(lowerCaseLine, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func parseVATLine(line string) (model.VATAmount, error) {
+	match := vatLine.FindStringSubmatch(line)
+	numbers := make([]float64, 0, len(match)-1)
+	for _, unparsedNumber := range match[1:] {
+		number, err := ustrconv.StringToPositiveFloat(unparsedNumber)
+		if err != nil {
+			return model.VATAmount{}, err
+		}
+		numbers = append(numbers, umath.RoundFloat(number, 2))
+	}
+
+	return model.VATAmount{
+		Rate:   numbers[0],
+		Net:    numbers[1],
+		Amount: numbers[2],
+		Gross:  numbers[3],
+	}, nil
+}
+
+func parseLastNumber(line string) (float64, error) {
+	lineSplitBySpace := strings.Fields(line)
+	number, err := ustrconv.StringToPositiveFloat(lineSplitBySpace[len(lineSplitBySpace)-1])
+	if err != nil {
+		return 0, err
+	}
+	return umath.RoundFloat(number, 2), nil
+}
@@ -6,6 +6,8 @@ import (
 	"time"
 
 	"github.com/SarunasBucius/nutri-price-server/internal/model"
+	"github.com/SarunasBucius/nutri-price-server/internal/service/receipt/retailer/deposit"
+	"github.com/SarunasBucius/nutri-price-server/internal/service/receipt/retailer/promo"
 	"github.com/SarunasBucius/nutri-price-server/internal/utils/umath"
 	"github.com/SarunasBucius/nutri-price-server/internal/utils/ustrconv"
 )
@@ -25,10 +27,10 @@ func NewParser(receiptLines []string) NorfaParser {
 }
 
 type unparsedProduct struct {
-	product    string
-	hasDeposit bool
-	discount   string
-	isHalf     bool
+	product  string
+	deposits []string
+	discount string
+	isHalf   bool
 }
 
 func (p NorfaParser) ParseDate() (time.Time, error) {
@@ -51,25 +53,44 @@ func (p NorfaParser) ParseDate() (time.Time, error) {
 	return parsedDate, nil
 }
 
-func (p NorfaParser) ParseProducts() (model.ReceiptProducts, error) {
-	unparsedProducts, err := extractProductLines(p.ReceiptLines)
+// ParseProducts returns parsed products and product lines that could not be parsed.
+func (p NorfaParser) ParseProducts() (model.ReceiptProducts, []string, error) {
+	return parseProducts(p.ReceiptLines)
+}
+
+func (p NorfaParser) GetRetailer() string { return retailer }
+
+// Detect returns confidence that the receipt was issued by Norfa.
+func Detect(receipt string) float64 {
+	switch {
+	case strings.Contains(receipt, "UAB NORFOS MAŽMENA"):
+		return 1
+	case strings.Contains(strings.ToUpper(receipt), "NORFA"):
+		return 0.4
+	default:
+		return 0
+	}
+}
+
+func parseProducts(receiptLines []string) (model.ReceiptProducts, []string, error) {
+	unparsedProducts, err := extractProductLines(receiptLines)
 	if err != nil {
-		return nil, fmt.Errorf("extract product lines: %w", err)
+		return nil, nil, fmt.Errorf("extract product lines: %w", err)
 	}
 
 	parsedProducts := make([]model.PurchasedProductNew, 0, len(unparsedProducts))
+	var skippedLines []string
 	for _, product := range unparsedProducts {
 		parsedProduct, err := parseProduct(product)
 		if err != nil {
-			return nil, fmt.Errorf("parse product %+v: %w", product, err)
+			skippedLines = append(skippedLines, strings.TrimSpace(product.product))
+			continue
 		}
 		parsedProducts = append(parsedProducts, parsedProduct)
 	}
-	return parsedProducts, nil
+	return parsedProducts, skippedLines, nil
 }
 
-func (p NorfaParser) GetRetailer() string { return retailer }
-
 func extractProductLines(receiptLines []string) ([]unparsedProduct, error) {
 	const productsEndSeparator = "#"
 	const productsStartSeparator = "Kvito numeris"
@@ -94,11 +115,22 @@ func extractProductLines(receiptLines []string) ([]unparsedProduct, error) {
 
 func parseProduct(product unparsedProduct) (model.PurchasedProductNew, error) {
 	unparsedPrice := getUnparsedPrice(product.product)
-	price, err := parsePrice(product, unparsedPrice)
+	fullPrice, err := parsePrice(product, unparsedPrice)
 	if err != nil {
 		return model.PurchasedProductNew{}, err
 	}
 
+	discount, err := parseDiscount(product.discount)
+	if err != nil {
+		return model.PurchasedProductNew{}, fmt.Errorf("parse product discount: %w", err)
+	}
+	discount = umath.RoundFloat(discount, 2)
+
+	depositAmount, err := deposit.Sum(product.deposits)
+	if err != nil {
+		return model.PurchasedProductNew{}, fmt.Errorf("parse product deposit: %w", err)
+	}
+
 	productName := trimPriceInfoFromProductName(product.product, unparsedPrice)
 
 	weightParser := newWeightParser(productName)
@@ -109,9 +141,13 @@ func parseProduct(product unparsedProduct) (model.PurchasedProductNew, error) {
 	productName = weightParser.trimProductName()
 
 	return model.PurchasedProductNew{
-		VarietyName: strings.TrimSpace(productName),
-		Price:       price,
-		Quantity:    quantity,
+		VarietyName:  strings.TrimSpace(productName),
+		Price:        umath.RoundFloat(fullPrice-discount, 2),
+		FullPrice:    fullPrice,
+		Discount:     discount,
+		DiscountType: promo.Classify(product.discount),
+		Deposit:      depositAmount,
+		Quantity:     quantity,
 	}, nil
 }
 
@@ -125,16 +161,7 @@ func parsePrice(product unparsedProduct, unparsedPrice string) (float64, error)
 	if err != nil {
 		return 0, fmt.Errorf("parse product price: %w", err)
 	}
-	if product.hasDeposit {
-		fullPrice += 0.10
-	}
-
-	discount, err := parseDiscount(product.discount)
-	if err != nil {
-		return 0, fmt.Errorf("parse product discount: %w", err)
-	}
-
-	return umath.RoundFloat(fullPrice-discount, 2), nil
+	return umath.RoundFloat(fullPrice, 2), nil
 }
 
 func parseDiscount(discountLine string) (float64, error) {
@@ -164,7 +191,7 @@ func extractProduct(line string, products []unparsedProduct) []unparsedProduct {
 	lastProduct := len(products) - 1
 
 	if isDeposit(line) {
-		products[lastProduct].hasDeposit = true
+		products[lastProduct].deposits = append(products[lastProduct].deposits, line)
 		return products
 	}
 
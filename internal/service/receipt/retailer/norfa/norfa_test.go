@@ -54,19 +54,24 @@ func TestParser_ParseProducts(t *testing.T) {
 			fields: fields{ReceiptLines: strings.Split(receiptExample, "\n"), Retailer: "norfa"},
 			want: model.ReceiptProducts{
 				{
-					Name:     "Ledai AURUM 100ml su kakaviniu glaistu",
-					Price:    0.39,
-					Quantity: model.Quantity{Unit: model.Milliliters, Amount: 100},
+					VarietyName: "Ledai AURUM 100ml su kakaviniu glaistu",
+					Price:       0.39,
+					FullPrice:   0.39,
+					Quantity:    model.Quantity{Unit: model.Milliliters, Amount: 100},
 				},
 				{
-					Name:     "Salierų stiebai",
-					Price:    0.46,
-					Quantity: model.Quantity{Unit: model.Grams, Amount: 466},
+					VarietyName:  "Salierų stiebai",
+					Price:        0.46,
+					FullPrice:    0.91,
+					Discount:     0.45,
+					DiscountType: model.DiscountTypeMarkdown,
+					Quantity:     model.Quantity{Unit: model.Grams, Amount: 466},
 				},
 				{
-					Name:     "Raudonieji lęšiai SKANĖJA, 500g",
-					Price:    1.89,
-					Quantity: model.Quantity{Unit: model.Grams, Amount: 500},
+					VarietyName: "Raudonieji lęšiai SKANĖJA, 500g",
+					Price:       1.89,
+					FullPrice:   1.89,
+					Quantity:    model.Quantity{Unit: model.Grams, Amount: 500},
 				},
 			},
 		},
@@ -77,7 +82,7 @@ func TestParser_ParseProducts(t *testing.T) {
 				ReceiptLines: tt.fields.ReceiptLines,
 				Retailer:     tt.fields.Retailer,
 			}
-			got, err := p.ParseProducts()
+			got, _, err := p.ParseProducts()
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Parser.ParseProducts() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -141,3 +146,101 @@ func TestParser_ParseDate(t *testing.T) {
 		})
 	}
 }
+
+func TestParser_ParseTotals(t *testing.T) {
+	type fields struct {
+		ReceiptLines []string
+		Retailer     string
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		want    model.ReceiptTotals
+		wantErr bool
+	}{
+		{
+			name:   "parse_totals",
+			fields: fields{ReceiptLines: strings.Split(receiptExample, "\n"), Retailer: "norfa"},
+			want: model.ReceiptTotals{
+				Total: 4.23,
+				Paid:  4.23,
+				VAT:   []model.VATAmount{{Rate: 21, Amount: 0.73}},
+			},
+		},
+		{
+			name:   "totals_not_found",
+			fields: fields{ReceiptLines: []string{"UAB NORFOS MAŽMENA"}, Retailer: "norfa"},
+			want:   model.ReceiptTotals{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NorfaParser{
+				ReceiptLines: tt.fields.ReceiptLines,
+				Retailer:     tt.fields.Retailer,
+			}
+			got, err := p.ParseTotals()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Parser.ParseTotals() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parser.ParseTotals() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNorfaParser_ParseTime(t *testing.T) {
+	tests := []struct {
+		name         string
+		receiptLines []string
+		want         string
+	}{
+		{
+			name:         "parse_time",
+			receiptLines: strings.Split(receiptExample, "\n"),
+			want:         "17:03:00",
+		},
+		{
+			name:         "time_not_found",
+			receiptLines: []string{"UAB NORFOS MAŽMENA"},
+			want:         "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NorfaParser{ReceiptLines: tt.receiptLines, Retailer: "norfa"}
+			if got := p.ParseTime(); got != tt.want {
+				t.Errorf("NorfaParser.ParseTime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNorfaParser_ParseReceiptNumber(t *testing.T) {
+	tests := []struct {
+		name         string
+		receiptLines []string
+		want         string
+	}{
+		{
+			name:         "parse_receipt_number",
+			receiptLines: strings.Split(receiptExample, "\n"),
+			want:         "00000",
+		},
+		{
+			name:         "receipt_number_not_found",
+			receiptLines: []string{"UAB NORFOS MAŽMENA"},
+			want:         "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NorfaParser{ReceiptLines: tt.receiptLines, Retailer: "norfa"}
+			if got := p.ParseReceiptNumber(); got != tt.want {
+				t.Errorf("NorfaParser.ParseReceiptNumber() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
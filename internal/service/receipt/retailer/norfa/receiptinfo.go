@@ -0,0 +1,37 @@
+package norfa
+
+import "regexp"
+
+var (
+	timeRegexp          = regexp.MustCompile(`\b(\d{2}:\d{2})(:\d{2})?\b`)
+	receiptNumberRegexp = regexp.MustCompile(`Kvito numeris\s+(\d+)`)
+)
+
+// ParseTime returns purchase time in HH:MM:SS format or an empty string if the receipt has none.
+func (p NorfaParser) ParseTime() string {
+	if len(p.ReceiptLines) < 2 {
+		return ""
+	}
+	return findTime(p.ReceiptLines[len(p.ReceiptLines)-2])
+}
+
+// ParseReceiptNumber returns the number printed on the receipt or an empty string if it is not found.
+func (p NorfaParser) ParseReceiptNumber() string {
+	for _, line := range p.ReceiptLines {
+		if match := receiptNumberRegexp.FindStringSubmatch(line); len(match) == 2 {
+			return match[1]
+		}
+	}
+	return ""
+}
+
+func findTime(line string) string {
+	match := timeRegexp.FindStringSubmatch(line)
+	if len(match) != 3 {
+		return ""
+	}
+	if match[2] == "" {
+		return match[1] + ":00"
+	}
+	return match[1] + match[2]
+}
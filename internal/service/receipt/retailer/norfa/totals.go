@@ -0,0 +1,70 @@
+package norfa
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/SarunasBucius/nutri-price-server/internal/model"
+	"github.com/SarunasBucius/nutri-price-server/internal/utils/umath"
+	"github.com/SarunasBucius/nutri-price-server/internal/utils/ustrconv"
+)
+
+// vatLine matches VAT rows, e.g. "PVM1 (21,00 %) 0,73".
+var vatLine = regexp.MustCompile(`^PVM\d*\s*\((\d+,\d{2})\s*%\)\s+(\d+,\d{2})$`)
+
+func (p NorfaParser) ParseTotals() (model.ReceiptTotals, error) {
+	const totalPrefix = "SUMA "
+	const changePrefix = "GRĄŽA"
+
+	var totals model.ReceiptTotals
+	isPaymentSection := false
+	for _, line := range p.ReceiptLines {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, totalPrefix):
+			total, err := parseAmountBeforeCurrency(line)
+			if err != nil {
+				return model.ReceiptTotals{}, fmt.Errorf("parse total: %w", err)
+			}
+			totals.Total = total
+			isPaymentSection = true
+		case isPaymentSection && strings.HasSuffix(line, "EUR"):
+			amount, err := parseAmountBeforeCurrency(line)
+			if err != nil {
+				return model.ReceiptTotals{}, fmt.Errorf("parse paid sum: %w", err)
+			}
+			if strings.HasPrefix(line, changePrefix) {
+				amount = -amount
+			}
+			totals.Paid = umath.RoundFloat(totals.Paid+amount, 2)
+		case vatLine.MatchString(line):
+			match := vatLine.FindStringSubmatch(line)
+			rate, err := ustrconv.StringToPositiveFloat(match[1])
+			if err != nil {
+				return model.ReceiptTotals{}, fmt.Errorf("parse vat rate: %w", err)
+			}
+			amount, err := ustrconv.StringToPositiveFloat(match[2])
+			if err != nil {
+				return model.ReceiptTotals{}, fmt.Errorf("parse vat amount: %w", err)
+			}
+			totals.VAT = append(totals.VAT, model.VATAmount{
+				Rate:   umath.RoundFloat(rate, 2),
+				Amount: umath.RoundFloat(amount, 2),
+			})
+		}
+	}
+	return totals, nil
+}
+
+func parseAmountBeforeCurrency(line string) (float64, error) {
+	lineSplitBySpace := strings.Fields(line)
+	if len(lineSplitBySpace) < 2 {
+		return 0, fmt.Errorf("unexpected amount line: %s", line)
+	}
+	amount, err := ustrconv.StringToPositiveFloat(lineSplitBySpace[len(lineSplitBySpace)-2])
+	if err != nil {
+		return 0, err
+	}
+	return umath.RoundFloat(amount, 2), nil
+}
@@ -0,0 +1,34 @@
+// Package promo classifies discount lines printed on receipts.
+package promo
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/SarunasBucius/nutri-price-server/internal/model"
+)
+
+var (
+	loyaltyKeywords = []string{"lidl plus", "mano rimi", "ačiū", "aciu", "lojalumo", "kortel"}
+	multiBuyRegexp  = regexp.MustCompile(`\d+\s*\+\s*\d+|\d+\s*už\s*\d+|pirk\s*\d+|\d+\s*vnt\.?\s*už`)
+)
+
+// Classify returns discount type of the discount line or an empty string if the line is empty.
+func Classify(discountLine string) string {
+	if strings.TrimSpace(discountLine) == "" {
+		return ""
+	}
+
+	lowerCaseLine := strings.ToLower(discountLine)
+	for _, keyword := range loyaltyKeywords {
+		if strings.Contains(lowerCaseLine, keyword) {
+			return model.DiscountTypeLoyalty
+		}
+	}
+
+	if multiBuyRegexp.MatchString(lowerCaseLine) {
+		return model.DiscountTypeMultiBuy
+	}
+
+	return model.DiscountTypeMarkdown
+}
@@ -0,0 +1,48 @@
+package promo
+
+import (
+	"testing"
+
+	"github.com/SarunasBucius/nutri-price-server/internal/model"
+)
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name         string
+		discountLine string
+		want         string
+	}{
+		{
+			name:         "no_discount",
+			discountLine: "",
+			want:         "",
+		},
+		{
+			name:         "rimi_loyalty",
+			discountLine: "Mano Rimi nuolaida                      -0,64 A",
+			want:         model.DiscountTypeLoyalty,
+		},
+		{
+			name:         "lidl_plus",
+			discountLine: "Lidl Plus nuolaida                             -0,30 A",
+			want:         model.DiscountTypeLoyalty,
+		},
+		{
+			name:         "multi_buy",
+			discountLine: "Nuolaida 1+1                                   -1,29 A",
+			want:         model.DiscountTypeMultiBuy,
+		},
+		{
+			name:         "markdown",
+			discountLine: "Nuolaida:prieskonių pakuotėms -50%               -0,66 A",
+			want:         model.DiscountTypeMarkdown,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Classify(tt.discountLine); got != tt.want {
+				t.Errorf("Classify() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
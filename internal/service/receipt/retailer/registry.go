@@ -0,0 +1,19 @@
+package retailer
+
+import (
+	"github.com/SarunasBucius/nutri-price-server/internal/service/receipt/retailer/barbora"
+	"github.com/SarunasBucius/nutri-price-server/internal/service/receipt/retailer/depositrefund"
+	"github.com/SarunasBucius/nutri-price-server/internal/service/receipt/retailer/lidl"
+	"github.com/SarunasBucius/nutri-price-server/internal/service/receipt/retailer/maxima"
+	"github.com/SarunasBucius/nutri-price-server/internal/service/receipt/retailer/norfa"
+	"github.com/SarunasBucius/nutri-price-server/internal/service/receipt/retailer/rimi"
+)
+
+func init() {
+	Register(norfa.Detect, func(receiptLines []string) ReceiptParser { return norfa.NewParser(receiptLines) })
+	Register(lidl.Detect, func(receiptLines []string) ReceiptParser { return lidl.NewParser(receiptLines) })
+	Register(maxima.Detect, func(receiptLines []string) ReceiptParser { return maxima.NewParser(receiptLines) })
+	Register(barbora.Detect, func(receiptLines []string) ReceiptParser { return barbora.NewParser(receiptLines) })
+	Register(rimi.Detect, func(receiptLines []string) ReceiptParser { return rimi.NewParser(receiptLines) })
+	Register(depositrefund.Detect, func(receiptLines []string) ReceiptParser { return depositrefund.NewParser(receiptLines) })
+}
@@ -1,40 +1,116 @@
 package retailer
 
 import (
+	"errors"
+	"fmt"
 	"slices"
 	"strings"
 	"time"
 
 	"github.com/SarunasBucius/nutri-price-server/internal/model"
-	"github.com/SarunasBucius/nutri-price-server/internal/service/receipt/retailer/barbora"
-	"github.com/SarunasBucius/nutri-price-server/internal/service/receipt/retailer/lidl"
-	"github.com/SarunasBucius/nutri-price-server/internal/service/receipt/retailer/maxima"
-	"github.com/SarunasBucius/nutri-price-server/internal/service/receipt/retailer/norfa"
 	"github.com/SarunasBucius/nutri-price-server/internal/utils/uerror"
 )
 
-func NewReceiptParser(receipt string) (ReceiptParser, error) {
+// minConfidence is the lowest detector score that is accepted as a match.
+const minConfidence = 0.5
+
+// ErrUnknownRetailer is returned when no registered parser, or more than one, matches the receipt.
+var ErrUnknownRetailer = errors.New("unknown retailer")
+
+type ReceiptParser interface {
+	ParseDate() (time.Time, error)
+	ParseTime() string
+	ParseReceiptNumber() string
+	// ParseProducts returns parsed products and product lines that could not be parsed.
+	ParseProducts() (model.ReceiptProducts, []string, error)
+	ParseTotals() (model.ReceiptTotals, error)
+	GetRetailer() string
+}
+
+// DepositRefundParser is implemented by parsers of deposit refund slips.
+type DepositRefundParser interface {
+	ReceiptParser
+	ParseDepositRefund() (float64, error)
+}
+
+// DetectFunc returns a confidence score from 0 to 1 that the receipt belongs to the retailer.
+type DetectFunc func(receipt string) float64
+
+type NewParserFunc func(receiptLines []string) ReceiptParser
+
+type registration struct {
+	retailer  string
+	detect    DetectFunc
+	newParser NewParserFunc
+}
+
+var registry []registration
+
+// Register adds a retailer parser to the registry. Retailer name is taken from the parser itself.
+func Register(detect DetectFunc, newParser NewParserFunc) {
+	registry = append(registry, registration{
+		retailer:  newParser(nil).GetRetailer(),
+		detect:    detect,
+		newParser: newParser,
+	})
+}
+
+// NewReceiptParser returns a parser of the retailer that matches the receipt best.
+// Detection is skipped when retailerHint is provided.
+func NewReceiptParser(receipt, retailerHint string) (ReceiptParser, error) {
 	receipt = strings.ReplaceAll(receipt, "\r", "")
 	receiptLines := strings.Split(receipt, "\n")
 	receiptLines = slices.DeleteFunc(receiptLines, func(l string) bool {
 		return l == ""
 	})
-	switch {
-	case strings.Contains(receipt, "UAB NORFOS MAŽMENA"):
-		return norfa.NewParser(receiptLines), nil
-	case strings.Contains(receipt, "Lidl Lietuva"):
-		return lidl.NewParser(receiptLines), nil
-	case strings.Contains(receipt, "MAXIMA"):
-		return maxima.NewParser(receiptLines), nil
-	case strings.Contains(receipt, "Barbora"):
-		return barbora.NewParser(receiptLines), nil
-	default:
-		return nil, uerror.NewBadRequest("unknown retailer", nil)
+
+	if retailerHint != "" {
+		return newParserByRetailer(retailerHint, receiptLines)
+	}
+
+	match, err := detectRetailer(receipt)
+	if err != nil {
+		return nil, err
 	}
+	return match.newParser(receiptLines), nil
 }
 
-type ReceiptParser interface {
-	ParseDate() (time.Time, error)
-	ParseProducts() (model.ReceiptProducts, error)
-	GetRetailer() string
+func newParserByRetailer(retailer string, receiptLines []string) (ReceiptParser, error) {
+	for _, r := range registry {
+		if r.retailer == strings.ToLower(retailer) {
+			return r.newParser(receiptLines), nil
+		}
+	}
+	message := fmt.Sprintf("unknown retailer %q", retailer)
+	return nil, uerror.NewBadRequest(message, fmt.Errorf("%s: %w", message, ErrUnknownRetailer))
+}
+
+func detectRetailer(receipt string) (registration, error) {
+	var bestMatches []registration
+	var bestConfidence float64
+	for _, r := range registry {
+		confidence := r.detect(receipt)
+		if confidence < minConfidence || confidence < bestConfidence {
+			continue
+		}
+		if confidence > bestConfidence {
+			bestConfidence = confidence
+			bestMatches = bestMatches[:0]
+		}
+		bestMatches = append(bestMatches, r)
+	}
+
+	switch len(bestMatches) {
+	case 0:
+		return registration{}, uerror.NewBadRequest("unknown retailer", ErrUnknownRetailer)
+	case 1:
+		return bestMatches[0], nil
+	default:
+		retailers := make([]string, 0, len(bestMatches))
+		for _, r := range bestMatches {
+			retailers = append(retailers, r.retailer)
+		}
+		message := fmt.Sprintf("ambiguous retailer, could be one of: %s", strings.Join(retailers, ", "))
+		return registration{}, uerror.NewBadRequest(message, fmt.Errorf("%s: %w", message, ErrUnknownRetailer))
+	}
 }
@@ -0,0 +1,87 @@
+package retailer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewReceiptParser(t *testing.T) {
+	tests := []struct {
+		name         string
+		receipt      string
+		retailerHint string
+		want         string
+		wantErr      bool
+	}{
+		{
+			name:    "detect_norfa",
+			receipt: "D1_\nUAB NORFOS MAŽMENA\n...",
+			want:    "norfa",
+		},
+		{
+			name:    "detect_barbora_mentioning_maxima",
+			receipt: "Barbora\n2023-04-16\nMAXIMA LT, UAB\n1 Nektarinai, 1 kg 0.612 kg €1.6569 €1.3693 21,00 €0.84 €1.01",
+			want:    "barbora",
+		},
+		{
+			name:    "detect_barbora_without_header_mentioning_maxima",
+			receipt: "Užsakymas Barbora\n2023-04-16\nPardavėjas MAXIMA LT, UAB",
+			want:    "barbora",
+		},
+		{
+			name:    "detect_maxima",
+			receipt: "MAXIMA LT, UAB\n...\nKvitas 198/1582",
+			want:    "maxima",
+		},
+		{
+			name:    "detect_rimi_with_windows_line_endings",
+			receipt: "UAB \"RIMI LIETUVA\"\r\nKvitas 0123\r\n",
+			want:    "rimi",
+		},
+		{
+			name:    "detect_deposit_refund_slip_issued_in_maxima",
+			receipt: "TOMRA\nUŽSTATO GRĄŽINIMO KVITAS\nMAXIMA LT, UAB\nSuma: 1,30 EUR\n2024-05-14 18:20:11",
+			want:    "deposit_refund",
+		},
+		{
+			name:    "detect_tomra_slip_headed_by_maxima",
+			receipt: "MAXIMA LT, UAB\nTOMRA\nSuma: 0,60 EUR\n2024-05-14 18:20:11",
+			want:    "deposit_refund",
+		},
+		{
+			name:    "unknown_retailer",
+			receipt: "some shop\n2024-01-01",
+			wantErr: true,
+		},
+		{
+			name:    "ambiguous_retailer",
+			receipt: "UAB NORFOS MAŽMENA\nLidl Lietuva",
+			wantErr: true,
+		},
+		{
+			name:         "retailer_hint_overrides_detection",
+			receipt:      "UAB NORFOS MAŽMENA\nLidl Lietuva",
+			retailerHint: "Lidl",
+			want:         "lidl",
+		},
+		{
+			name:         "unknown_retailer_hint",
+			receipt:      "UAB NORFOS MAŽMENA",
+			retailerHint: "iki",
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewReceiptParser(tt.receipt, tt.retailerHint)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got.GetRetailer())
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package rimi
+
+import "regexp"
+
+var (
+	timeRegexp          = regexp.MustCompile(`\b(\d{2}:\d{2})(:\d{2})?\b`)
+	receiptNumberRegexp = regexp.MustCompile(`Kvitas\s+(\S+)`)
+)
+
+// ParseTime returns purchase time in HH:MM:SS format or an empty string if the receipt has none.
+func (p RimiParser) ParseTime() string {
+	for i := len(p.ReceiptLines) - 1; i >= 0; i-- {
+		if !dateRegexp.MatchString(p.ReceiptLines[i]) {
+			continue
+		}
+		return findTime(p.ReceiptLines[i])
+	}
+	return ""
+}
+
+// ParseReceiptNumber returns the number printed on the receipt or an empty string if it is not found.
+func (p RimiParser) ParseReceiptNumber() string {
+	for _, line := range p.ReceiptLines {
+		if match := receiptNumberRegexp.FindStringSubmatch(line); len(match) == 2 {
+			return match[1]
+		}
+	}
+	return ""
+}
+
+func findTime(line string) string {
+	match := timeRegexp.FindStringSubmatch(line)
+	if len(match) != 3 {
+		return ""
+	}
+	if match[2] == "" {
+		return match[1] + ":00"
+	}
+	return match[1] + match[2]
+}
@@ -0,0 +1,284 @@
+package rimi
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/SarunasBucius/nutri-price-server/internal/model"
+	"github.com/SarunasBucius/nutri-price-server/internal/service/receipt/retailer/promo"
+	"github.com/SarunasBucius/nutri-price-server/internal/utils/umath"
+	"github.com/SarunasBucius/nutri-price-server/internal/utils/ustrconv"
+)
+
+const retailer = "rimi"
+
+var (
+	priceAtLineEnd = regexp.MustCompile(`(-?\d+,\d{2})\s+[A-Z]$`)
+	dateRegexp     = regexp.MustCompile(`\d{4}-\d{2}-\d{2}`)
+	weightedLine   = regexp.MustCompile(`^(\d+,\d{3})\s*kg\s*[xX]\s*(\d+,\d{2})`)
+	multipliedLine = regexp.MustCompile(`^(\d+)\s*[xX]\s*(\d+,\d{2})`)
+)
+
+type RimiParser struct {
+	ReceiptLines []string
+	Retailer     string
+}
+
+func NewParser(receiptLines []string) RimiParser {
+	return RimiParser{
+		ReceiptLines: receiptLines,
+		Retailer:     retailer,
+	}
+}
+
+type unparsedProduct struct {
+	product      string
+	deposits     []string
+	discounts    []string
+	quantityLine string
+	isHalf       bool
+}
+
+func (p RimiParser) ParseDate() (time.Time, error) {
+	for i := len(p.ReceiptLines) - 1; i >= 0; i-- {
+		receiptDate := dateRegexp.FindString(p.ReceiptLines[i])
+		if receiptDate == "" {
+			continue
+		}
+
+		parsedDate, err := time.Parse(time.DateOnly, receiptDate)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("parse receipt date: %w", err)
+		}
+		return parsedDate, nil
+	}
+	return time.Time{}, fmt.Errorf("receipt date not found")
+}
+
+// ParseProducts returns parsed products and product lines that could not be parsed.
+func (p RimiParser) ParseProducts() (model.ReceiptProducts, []string, error) {
+	return parseProducts(p.ReceiptLines)
+}
+
+func (p RimiParser) GetRetailer() string { return retailer }
+
+// Detect returns confidence that the receipt was issued by Rimi.
+func Detect(receipt string) float64 {
+	switch {
+	case strings.Contains(receipt, "RIMI LIETUVA"):
+		return 1
+	case strings.Contains(strings.ToUpper(receipt), "RIMI"):
+		return 0.4
+	default:
+		return 0
+	}
+}
+
+func parseProducts(receiptLines []string) (model.ReceiptProducts, []string, error) {
+	unparsedProducts, err := extractProductLines(receiptLines)
+	if err != nil {
+		return nil, nil, fmt.Errorf("extract product lines: %w", err)
+	}
+
+	parsedProducts := make([]model.PurchasedProductNew, 0, len(unparsedProducts))
+	var skippedLines []string
+	for _, product := range unparsedProducts {
+		parsedProduct, err := parseProduct(product)
+		if err != nil {
+			skippedLines = append(skippedLines, strings.TrimSpace(product.product))
+			continue
+		}
+		parsedProducts = append(parsedProducts, parsedProduct)
+	}
+	return parsedProducts, skippedLines, nil
+}
+
+func extractProductLines(receiptLines []string) ([]unparsedProduct, error) {
+	const productsListEnd = "--------------------"
+	productsListStart := findProductsListStart(receiptLines)
+	if productsListStart < 0 || len(receiptLines) <= productsListStart {
+		return nil, fmt.Errorf("products list not found")
+	}
+	receiptLines = receiptLines[productsListStart:]
+
+	var products []unparsedProduct
+	for i := range receiptLines {
+		line := strings.TrimSpace(receiptLines[i])
+		if strings.HasPrefix(line, productsListEnd) {
+			if len(products) == 0 {
+				continue
+			}
+			break
+		}
+
+		products = extractProduct(line, products)
+	}
+
+	return products, nil
+}
+
+func findProductsListStart(receiptLines []string) int {
+	for i, line := range receiptLines {
+		if strings.Contains(strings.ToLower(line), "kvitas") {
+			return i + 1
+		}
+	}
+	return -1
+}
+
+func extractProduct(line string, products []unparsedProduct) []unparsedProduct {
+	if line == "" {
+		return products
+	}
+
+	if len(products) == 0 {
+		return appendProduct(line, products)
+	}
+
+	lastProduct := len(products) - 1
+
+	if isDeposit(line) {
+		products[lastProduct].deposits = append(products[lastProduct].deposits, line)
+		return products
+	}
+
+	if isDiscount(line) {
+		products[lastProduct].discounts = append(products[lastProduct].discounts, line)
+		return products
+	}
+
+	if products[lastProduct].isHalf {
+		if isQuantityLine(line) {
+			products[lastProduct].quantityLine = line
+			products[lastProduct].isHalf = false
+			return products
+		}
+		products[lastProduct].product += " " + line
+		products[lastProduct].isHalf = !priceAtLineEnd.MatchString(line)
+		return products
+	}
+
+	return appendProduct(line, products)
+}
+
+func appendProduct(productLine string, products []unparsedProduct) []unparsedProduct {
+	return append(products, unparsedProduct{
+		product: productLine,
+		isHalf:  !priceAtLineEnd.MatchString(productLine),
+	})
+}
+
+func isQuantityLine(line string) bool {
+	return weightedLine.MatchString(line) || multipliedLine.MatchString(line)
+}
+
+// isDeposit matches deposit lines, e.g. "Tara   0,10 A". Products named like "Taralli" are not deposits.
+func isDeposit(line string) bool {
+	lowerCaseLine := strings.ToLower(line)
+	fields := strings.Fields(lowerCaseLine)
+	return (len(fields) > 0 && fields[0] == "tara") || strings.Contains(lowerCaseLine, "užstatas")
+}
+
+func isDiscount(line string) bool {
+	lowerCaseLine := strings.ToLower(line)
+	return strings.HasPrefix(lowerCaseLine, "mano rimi") || strings.Contains(lowerCaseLine, "nuolaida")
+}
+
+func parseProduct(product unparsedProduct) (model.PurchasedProductNew, error) {
+	priceLine := product.product
+	if product.quantityLine != "" {
+		priceLine = product.quantityLine
+	}
+
+	fullPrice, err := parseLinePrice(priceLine)
+	if err != nil {
+		return model.PurchasedProductNew{}, fmt.Errorf("parse product price: %w", err)
+	}
+
+	var deposit float64
+	for _, depositLine := range product.deposits {
+		depositPrice, err := parseLinePrice(depositLine)
+		if err != nil {
+			return model.PurchasedProductNew{}, fmt.Errorf("parse deposit price: %w", err)
+		}
+		deposit += depositPrice
+	}
+
+	var discount float64
+	for _, discountLine := range product.discounts {
+		discountPrice, err := parseLinePrice(discountLine)
+		if err != nil {
+			return model.PurchasedProductNew{}, fmt.Errorf("parse discount: %w", err)
+		}
+		discount += discountPrice
+	}
+	fullPrice = umath.RoundFloat(fullPrice, 2)
+	discount = umath.RoundFloat(discount, 2)
+
+	quantity, err := getQuantity(product.quantityLine)
+	if err != nil {
+		return model.PurchasedProductNew{}, fmt.Errorf("extract quantity info: %w", err)
+	}
+
+	return model.PurchasedProductNew{
+		VarietyName:  trimPriceInfoFromProductName(product.product),
+		Price:        umath.RoundFloat(fullPrice-discount, 2),
+		FullPrice:    fullPrice,
+		Discount:     discount,
+		DiscountType: getDiscountType(product.discounts),
+		Deposit:      umath.RoundFloat(deposit, 2),
+		Quantity:     quantity,
+	}, nil
+}
+
+// getDiscountType returns the type of the first discount, as Rimi prints loyalty discounts after other ones.
+func getDiscountType(discountLines []string) string {
+	if len(discountLines) == 0 {
+		return ""
+	}
+	return promo.Classify(discountLines[0])
+}
+
+func parseLinePrice(line string) (float64, error) {
+	match := priceAtLineEnd.FindStringSubmatch(line)
+	if len(match) != 2 {
+		return 0, fmt.Errorf("price not found in line %q", line)
+	}
+	return ustrconv.StringToPositiveFloat(match[1])
+}
+
+func getQuantity(quantityLine string) (model.Quantity, error) {
+	if match := weightedLine.FindStringSubmatch(quantityLine); len(match) == 3 {
+		amount, err := ustrconv.StringToPositiveFloat(match[1])
+		if err != nil {
+			return model.Quantity{}, fmt.Errorf("parse product weight: %w", err)
+		}
+		return model.Quantity{
+			Unit:   model.Grams,
+			Amount: umath.RoundFloat(amount*1000, 0),
+		}, nil
+	}
+
+	if match := multipliedLine.FindStringSubmatch(quantityLine); len(match) == 3 {
+		amount, err := strconv.Atoi(match[1])
+		if err != nil {
+			return model.Quantity{}, fmt.Errorf("parse product amount: %w", err)
+		}
+		return model.Quantity{
+			Unit:   model.Pieces,
+			Amount: float64(amount),
+		}, nil
+	}
+
+	return model.Quantity{}, nil
+}
+
+func trimPriceInfoFromProductName(product string) string {
+	if loc := priceAtLineEnd.FindStringIndex(product); loc != nil {
+		product = product[:loc[0]]
+	}
+	return strings.TrimSpace(product)
+}
@@ -0,0 +1,270 @@
+package rimi
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SarunasBucius/nutri-price-server/internal/model"
+	"github.com/stretchr/testify/require"
+)
+
+const receiptExample = `UAB "RIMI LIETUVA"
+Spaudos g. 6-1, Vilnius
+PVM mokėtojo kodas LT116377716
+Kasa 05                           Kvitas 0123
+------------------------------------------------
+Pienas ROKIŠKIO NAMINIS 2,5%, 1 l        1,39 A
+Bananai, 1 kg
+  0,856 kg x 1,19 EUR/kg                 1,02 A
+Mano Rimi nuolaida                      -0,20 A
+Gazuotas gėrimas COCA-COLA, 0,5 l        1,19 A
+Tara                                     0,10 A
+Jogurtas ACTIVIA su braškėmis,
+4x120 g
+  2 x 1,59                               3,18 A
+Mano Rimi nuolaida                      -0,64 A
+------------------------------------------------
+Iš viso mokėti                           6,04 EUR
+Mokėta banko kortele                     6,04 EUR
+Mano Rimi sutaupėte                      0,84 EUR
+------------------------------------------------
+PVM A 21,00%          1,05       4,99       6,04
+------------------------------------------------
+Kasininkas (-ė) 1234
+2024-05-14 18:32:11`
+
+func TestRimiParser_ParseDate(t *testing.T) {
+	type fields struct {
+		ReceiptLines []string
+		Retailer     string
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		want    time.Time
+		wantErr bool
+	}{
+		{
+			name:   "parse_date",
+			fields: fields{ReceiptLines: strings.Split(receiptExample, "\n"), Retailer: "rimi"},
+			want:   time.Date(2024, 5, 14, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "date_not_found",
+			fields:  fields{ReceiptLines: []string{"UAB \"RIMI LIETUVA\"", "line1"}},
+			want:    time.Time{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := RimiParser{
+				ReceiptLines: tt.fields.ReceiptLines,
+				Retailer:     tt.fields.Retailer,
+			}
+			got, err := p.ParseDate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RimiParser.ParseDate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("RimiParser.ParseDate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRimiParser_ParseProducts(t *testing.T) {
+	type fields struct {
+		ReceiptLines []string
+		Retailer     string
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		want    model.ReceiptProducts
+		wantErr bool
+	}{
+		{
+			name:   "parse_products",
+			fields: fields{ReceiptLines: strings.Split(receiptExample, "\n"), Retailer: "rimi"},
+			want: model.ReceiptProducts{
+				{
+					VarietyName: "Pienas ROKIŠKIO NAMINIS 2,5%, 1 l",
+					Price:       1.39,
+					FullPrice:   1.39,
+				},
+				{
+					VarietyName:  "Bananai, 1 kg",
+					Price:        0.82,
+					FullPrice:    1.02,
+					Discount:     0.2,
+					DiscountType: model.DiscountTypeLoyalty,
+					Quantity: model.Quantity{
+						Unit:   model.Grams,
+						Amount: 856,
+					},
+				},
+				{
+					VarietyName: "Gazuotas gėrimas COCA-COLA, 0,5 l",
+					Price:       1.19,
+					FullPrice:   1.19,
+					Deposit:     0.1,
+				},
+				{
+					VarietyName:  "Jogurtas ACTIVIA su braškėmis, 4x120 g",
+					Price:        2.54,
+					FullPrice:    3.18,
+					Discount:     0.64,
+					DiscountType: model.DiscountTypeLoyalty,
+					Quantity: model.Quantity{
+						Unit:   model.Pieces,
+						Amount: 2,
+					},
+				},
+			},
+		},
+		{
+			name: "product_name_starting_with_tara",
+			fields: fields{ReceiptLines: []string{
+				"UAB \"RIMI LIETUVA\"",
+				"Kasa 05                           Kvitas 0124",
+				"------------------------------------------------",
+				"Taralli su alyvuogėmis, 250 g            2,49 A",
+				"Tara                                     0,10 A",
+				"------------------------------------------------",
+				"Iš viso mokėti                           2,59 EUR",
+				"2024-05-14 18:32:11",
+			}, Retailer: "rimi"},
+			want: model.ReceiptProducts{
+				{
+					VarietyName: "Taralli su alyvuogėmis, 250 g",
+					Price:       2.49,
+					FullPrice:   2.49,
+					Deposit:     0.1,
+				},
+			},
+		},
+		{
+			name:    "products_list_not_found",
+			fields:  fields{ReceiptLines: []string{"UAB \"RIMI LIETUVA\"", "2024-05-14 18:32:11"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := RimiParser{
+				ReceiptLines: tt.fields.ReceiptLines,
+				Retailer:     tt.fields.Retailer,
+			}
+			got, _, err := p.ParseProducts()
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRimiParser_ParseTotals(t *testing.T) {
+	type fields struct {
+		ReceiptLines []string
+		Retailer     string
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		want    model.ReceiptTotals
+		wantErr bool
+	}{
+		{
+			name:   "parse_totals",
+			fields: fields{ReceiptLines: strings.Split(receiptExample, "\n"), Retailer: "rimi"},
+			want: model.ReceiptTotals{
+				Total: 6.04,
+				Paid:  6.04,
+				VAT:   []model.VATAmount{{Rate: 21, Amount: 1.05, Net: 4.99, Gross: 6.04}},
+			},
+		},
+		{
+			name:   "totals_not_found",
+			fields: fields{ReceiptLines: []string{"UAB \"RIMI LIETUVA\""}, Retailer: "rimi"},
+			want:   model.ReceiptTotals{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := RimiParser{
+				ReceiptLines: tt.fields.ReceiptLines,
+				Retailer:     tt.fields.Retailer,
+			}
+			got, err := p.ParseTotals()
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRimiParser_ParseTime(t *testing.T) {
+	tests := []struct {
+		name         string
+		receiptLines []string
+		want         string
+	}{
+		{
+			name:         "parse_time",
+			receiptLines: strings.Split(receiptExample, "\n"),
+			want:         "18:32:11",
+		},
+		{
+			name:         "time_not_found",
+			receiptLines: []string{"UAB \"RIMI LIETUVA\""},
+			want:         "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := RimiParser{ReceiptLines: tt.receiptLines, Retailer: "rimi"}
+			if got := p.ParseTime(); got != tt.want {
+				t.Errorf("RimiParser.ParseTime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRimiParser_ParseReceiptNumber(t *testing.T) {
+	tests := []struct {
+		name         string
+		receiptLines []string
+		want         string
+	}{
+		{
+			name:         "parse_receipt_number",
+			receiptLines: strings.Split(receiptExample, "\n"),
+			want:         "0123",
+		},
+		{
+			name:         "receipt_number_not_found",
+			receiptLines: []string{"UAB \"RIMI LIETUVA\""},
+			want:         "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := RimiParser{ReceiptLines: tt.receiptLines, Retailer: "rimi"}
+			if got := p.ParseReceiptNumber(); got != tt.want {
+				t.Errorf("RimiParser.ParseReceiptNumber() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
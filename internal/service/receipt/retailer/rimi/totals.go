@@ -0,0 +1,73 @@
+package rimi
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/SarunasBucius/nutri-price-server/internal/model"
+	"github.com/SarunasBucius/nutri-price-server/internal/utils/umath"
+	"github.com/SarunasBucius/nutri-price-server/internal/utils/ustrconv"
+)
+
+// vatLine matches VAT rows, e.g. "PVM A 21,00%   1,05   4,99   6,04".
+var vatLine = regexp.MustCompile(`^PVM\s+[A-Z]\s+(\d+,\d{2})%\s+(-?\d+,\d{2})\s+(-?\d+,\d{2})\s+(-?\d+,\d{2})$`)
+
+func (p RimiParser) ParseTotals() (model.ReceiptTotals, error) {
+	var totals model.ReceiptTotals
+	for _, line := range p.ReceiptLines {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, "Iš viso mokėti"):
+			total, err := parseAmountBeforeCurrency(line)
+			if err != nil {
+				return model.ReceiptTotals{}, fmt.Errorf("parse total: %w", err)
+			}
+			totals.Total = total
+		case strings.HasPrefix(line, "Mokėta"):
+			paid, err := parseAmountBeforeCurrency(line)
+			if err != nil {
+				return model.ReceiptTotals{}, fmt.Errorf("parse paid sum: %w", err)
+			}
+			totals.Paid = umath.RoundFloat(totals.Paid+paid, 2)
+		case vatLine.MatchString(line):
+			vat, err := parseVATLine(line)
+			if err != nil {
+				return model.ReceiptTotals{}, fmt.Errorf("parse vat line: %w", err)
+			}
+			totals.VAT = append(totals.VAT, vat)
+		}
+	}
+	return totals, nil
+}
+
+func parseVATLine(line string) (model.VATAmount, error) {
+	match := vatLine.FindStringSubmatch(line)
+	numbers := make([]float64, 0, len(match)-1)
+	for _, unparsedNumber := range match[1:] {
+		number, err := ustrconv.StringToPositiveFloat(unparsedNumber)
+		if err != nil {
+			return model.VATAmount{}, err
+		}
+		numbers = append(numbers, umath.RoundFloat(number, 2))
+	}
+
+	return model.VATAmount{
+		Rate:   numbers[0],
+		Amount: numbers[1],
+		Net:    numbers[2],
+		Gross:  numbers[3],
+	}, nil
+}
+
+func parseAmountBeforeCurrency(line string) (float64, error) {
+	lineSplitBySpace := strings.Fields(line)
+	if len(lineSplitBySpace) < 2 {
+		return 0, fmt.Errorf("unexpected amount line: %s", line)
+	}
+	amount, err := ustrconv.StringToPositiveFloat(lineSplitBySpace[len(lineSplitBySpace)-2])
+	if err != nil {
+		return 0, err
+	}
+	return umath.RoundFloat(amount, 2), nil
+}
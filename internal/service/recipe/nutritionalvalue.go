@@ -2,14 +2,15 @@ package recipe
 
 import (
 	"github.com/SarunasBucius/nutri-price-server/internal/model"
+	"github.com/SarunasBucius/nutri-price-server/internal/service/recipe/unitconv"
 	"github.com/SarunasBucius/nutri-price-server/internal/utils/umath"
 )
 
-func calculateMealNutritionalValue(ingredients []model.Ingredient, productsNV []model.ProductNutritionalValue, recipeNamesByIDs map[int]string) model.CalculatedMealNutritionalValue {
+func calculateMealNutritionalValue(ingredients []model.Ingredient, productsNV []model.ProductNutritionalValue, recipeNamesByIDs map[int]string, conversions map[string]model.UnitConversion) model.CalculatedMealNutritionalValue {
 	calculatedProductsNV := make(map[int][]model.CalculatedProductNutritionalValue, len(ingredients))
 	var totalNV model.NutritionalValue
 	for _, ingredient := range ingredients {
-		calculatedProductNV := calculateIngredientNutritionalValue(ingredient, productsNV)
+		calculatedProductNV := calculateIngredientNutritionalValue(ingredient, productsNV, conversions[ingredient.Product])
 		calculatedProductsNV[ingredient.RecipeID] = append(calculatedProductsNV[ingredient.RecipeID], calculatedProductNV)
 		totalNV = addNutritionalValues(totalNV, calculatedProductNV.NutritionalValue)
 	}
@@ -34,19 +35,33 @@ func calculateMealNutritionalValue(ingredients []model.Ingredient, productsNV []
 	}
 }
 
-func calculateIngredientNutritionalValue(ingredient model.Ingredient, productsNutritionalValue []model.ProductNutritionalValue) model.CalculatedProductNutritionalValue {
+// calculateIngredientNutritionalValue uses the nutritional value in the same unit as the ingredient,
+// or the first one the ingredient amount can be converted to.
+func calculateIngredientNutritionalValue(ingredient model.Ingredient, productsNutritionalValue []model.ProductNutritionalValue, conversion model.UnitConversion) model.CalculatedProductNutritionalValue {
+	var calculated *model.CalculatedProductNutritionalValue
 	for _, productNV := range productsNutritionalValue {
 		if productNV.Product != ingredient.Product {
 			continue
 		}
-		if productNV.Unit == ingredient.Unit {
-			isPiece := productNV.Unit == model.Pieces
-			return model.CalculatedProductNutritionalValue{
-				Product:          ingredient.Product,
-				NutritionalValue: calculateNutritionalValue(ingredient.Amount, productNV.NutritionalValue, isPiece),
-			}
+
+		amount, description, err := unitconv.Convert(model.Quantity{Unit: ingredient.Unit, Amount: ingredient.Amount}, productNV.Unit, conversion)
+		if err != nil || (calculated != nil && description != "") {
+			continue
+		}
+
+		isPiece := productNV.Unit == model.Pieces
+		calculated = &model.CalculatedProductNutritionalValue{
+			Product:          ingredient.Product,
+			NutritionalValue: calculateNutritionalValue(amount.Amount, productNV.NutritionalValue, isPiece),
+			Conversion:       description,
+		}
+		if description == "" {
+			break
 		}
 	}
+	if calculated != nil {
+		return *calculated
+	}
 
 	return model.CalculatedProductNutritionalValue{
 		Product: ingredient.Product,
@@ -70,9 +85,21 @@ func calculateNutritionalValue(ingredientAmount float64, productNV model.Nutriti
 		InsolubleFibre:     umath.RoundFloat(productNV.InsolubleFibre/multiplier*ingredientAmount, 3),
 		Protein:            umath.RoundFloat(productNV.Protein/multiplier*ingredientAmount, 3),
 		Salt:               umath.RoundFloat(productNV.Salt/multiplier*ingredientAmount, 3),
+		Nutrients:          calculateNutrients(ingredientAmount/multiplier, productNV.Nutrients),
 	}
 }
 
+func calculateNutrients(multiplier float64, nutrients map[string]float64) map[string]float64 {
+	if len(nutrients) == 0 {
+		return nil
+	}
+	calculated := make(map[string]float64, len(nutrients))
+	for code, amount := range nutrients {
+		calculated[code] = umath.RoundFloat(amount*multiplier, 3)
+	}
+	return calculated
+}
+
 func addNutritionalValues(nutritionalValues ...model.NutritionalValue) model.NutritionalValue {
 	var total model.NutritionalValue
 	for _, nv := range nutritionalValues {
@@ -87,7 +114,34 @@ func addNutritionalValues(nutritionalValues ...model.NutritionalValue) model.Nut
 			InsolubleFibre:     total.InsolubleFibre + nv.InsolubleFibre,
 			Protein:            total.Protein + nv.Protein,
 			Salt:               total.Salt + nv.Salt,
+			Nutrients:          addNutrients(total.Nutrients, nv.Nutrients),
 		}
 	}
 	return total
 }
+
+func addNutrients(total, nutrients map[string]float64) map[string]float64 {
+	if len(nutrients) == 0 {
+		return total
+	}
+	if total == nil {
+		total = make(map[string]float64, len(nutrients))
+	}
+	for code, amount := range nutrients {
+		total[code] += amount
+	}
+	return total
+}
+
+// getDailyReferenceShares divides nutrient amounts by daily reference values of nutrients that have one.
+func getDailyReferenceShares(nv model.NutritionalValue, nutrients []model.Nutrient) map[string]float64 {
+	shares := make(map[string]float64)
+	for _, nutrient := range nutrients {
+		amount, ok := nv.Nutrients[nutrient.Code]
+		if !ok || nutrient.DailyReference == nil || *nutrient.DailyReference <= 0 {
+			continue
+		}
+		shares[nutrient.Code] = umath.RoundFloat(amount / *nutrient.DailyReference, 3)
+	}
+	return shares
+}
@@ -0,0 +1,131 @@
+package recipe
+
+import (
+	"testing"
+
+	"github.com/SarunasBucius/nutri-price-server/internal/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalculateNutritionalValue(t *testing.T) {
+	productNV := model.NutritionalValue{
+		EnergyValueKCAL: 200,
+		Fat:             10,
+		Fibre:           4,
+		SolubleFibre:    1,
+		InsolubleFibre:  3,
+		Nutrients:       map[string]float64{"calcium": 120, "vitamin_b12": 0.4},
+	}
+
+	tests := []struct {
+		name             string
+		ingredientAmount float64
+		productNV        model.NutritionalValue
+		isPiece          bool
+		want             model.NutritionalValue
+	}{
+		{
+			name:             "per_100_grams",
+			ingredientAmount: 250,
+			productNV:        productNV,
+			want: model.NutritionalValue{
+				EnergyValueKCAL: 500, Fat: 25, Fibre: 10, SolubleFibre: 2.5, InsolubleFibre: 7.5,
+				Nutrients: map[string]float64{"calcium": 300, "vitamin_b12": 1},
+			},
+		},
+		{
+			name:             "per_piece",
+			ingredientAmount: 2,
+			productNV:        productNV,
+			isPiece:          true,
+			want: model.NutritionalValue{
+				EnergyValueKCAL: 400, Fat: 20, Fibre: 8, SolubleFibre: 2, InsolubleFibre: 6,
+				Nutrients: map[string]float64{"calcium": 240, "vitamin_b12": 0.8},
+			},
+		},
+		{
+			name:             "without_nutrients",
+			ingredientAmount: 50,
+			productNV:        model.NutritionalValue{EnergyValueKCAL: 100, Fat: 2},
+			want:             model.NutritionalValue{EnergyValueKCAL: 50, Fat: 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, calculateNutritionalValue(tt.ingredientAmount, tt.productNV, tt.isPiece))
+		})
+	}
+}
+
+func TestAddNutrients(t *testing.T) {
+	tests := []struct {
+		name      string
+		total     map[string]float64
+		nutrients map[string]float64
+		want      map[string]float64
+	}{
+		{
+			name: "both_empty",
+		},
+		{
+			name:      "first_nutrients",
+			nutrients: map[string]float64{"iron": 2},
+			want:      map[string]float64{"iron": 2},
+		},
+		{
+			name:      "adds_to_total",
+			total:     map[string]float64{"iron": 2, "zinc": 1},
+			nutrients: map[string]float64{"iron": 1.5, "calcium": 100},
+			want:      map[string]float64{"iron": 3.5, "zinc": 1, "calcium": 100},
+		},
+		{
+			name:  "nothing_to_add",
+			total: map[string]float64{"iron": 2},
+			want:  map[string]float64{"iron": 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, addNutrients(tt.total, tt.nutrients))
+		})
+	}
+}
+
+func TestGetDailyReferenceShares(t *testing.T) {
+	nutrients := []model.Nutrient{
+		{Code: "calcium", Unit: "mg", DailyReference: ptr(800.0)},
+		{Code: "iron", Unit: "mg", DailyReference: ptr(14.0)},
+		{Code: "chromium", Unit: "µg"},
+		{Code: "zinc", Unit: "mg", DailyReference: ptr(0.0)},
+	}
+
+	tests := []struct {
+		name string
+		nv   model.NutritionalValue
+		want map[string]float64
+	}{
+		{
+			name: "nutrients_with_daily_reference",
+			nv:   model.NutritionalValue{Nutrients: map[string]float64{"calcium": 200, "iron": 7}},
+			want: map[string]float64{"calcium": 0.25, "iron": 0.5},
+		},
+		{
+			name: "nutrients_without_daily_reference",
+			nv:   model.NutritionalValue{Nutrients: map[string]float64{"chromium": 10, "zinc": 5, "unknown": 1}},
+			want: map[string]float64{},
+		},
+		{
+			name: "no_nutrients",
+			want: map[string]float64{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, getDailyReferenceShares(tt.nv, nutrients))
+		})
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -1,15 +1,22 @@
 package recipe
 
 import (
+	"time"
+
 	"github.com/SarunasBucius/nutri-price-server/internal/model"
-	"github.com/SarunasBucius/nutri-price-server/internal/utils/umath"
+	"github.com/SarunasBucius/nutri-price-server/internal/service/recipe/pricing"
 )
 
-func calculateMealPrice(ingredients []model.Ingredient, purchasedProducts []model.PurchasedProduct) model.CalculatedMealPrice {
+func calculateMealPrice(ingredients []model.Ingredient, purchasedProducts []model.PurchasedProduct, conversions map[string]model.UnitConversion,
+	strategy model.PriceStrategy, dishDates map[int]time.Time) model.CalculatedMealPrice {
+	purchasesByProduct := groupPurchasesByName(purchasedProducts)
+	now := time.Now()
+
 	calculatedProducts := make(map[int][]model.CalculatedProductPrice, len(ingredients))
 	var totalPrice float64
 	for _, ingredient := range ingredients {
-		calculatedProduct := calculateIngredientPrice(ingredient, purchasedProducts)
+		calculatedProduct := calculateIngredientPrice(ingredient, purchasesByProduct[ingredient.Product], conversions[ingredient.Product],
+			strategy, dishDates[ingredient.RecipeID], now)
 		calculatedProducts[ingredient.RecipeID] = append(calculatedProducts[ingredient.RecipeID], calculatedProduct)
 		totalPrice += calculatedProduct.Price
 	}
@@ -29,27 +36,30 @@ func calculateMealPrice(ingredients []model.Ingredient, purchasedProducts []mode
 	}
 	return model.CalculatedMealPrice{
 		Price:             totalPrice,
+		Strategy:          strategy.Name,
 		CalculatedRecipes: calculatedNVByRecipe,
 	}
 }
 
-func calculateIngredientPrice(ingredient model.Ingredient, purchasedProducts []model.PurchasedProduct) model.CalculatedProductPrice {
-	for _, product := range purchasedProducts {
-		if product.Name != ingredient.Product {
-			continue
-		}
-
-		if product.Quantity.Unit == ingredient.Unit {
-			unroundedProductPrice := product.Price / product.Quantity.Amount * ingredient.Amount
-			productPrice := umath.RoundFloat(unroundedProductPrice, 2)
-			return model.CalculatedProductPrice{
-				Product: ingredient.Product,
-				Price:   productPrice,
-			}
+// calculateIngredientPrice prices the ingredient by purchases of the product selected by the strategy.
+func calculateIngredientPrice(ingredient model.Ingredient, purchases []model.PurchasedProduct, conversion model.UnitConversion,
+	strategy model.PriceStrategy, dishDate, now time.Time) model.CalculatedProductPrice {
+	calculated, ok := pricing.Price(model.Quantity{Unit: ingredient.Unit, Amount: ingredient.Amount}, purchases, conversion, strategy, dishDate, now)
+	if !ok {
+		return model.CalculatedProductPrice{
+			Product: ingredient.Product,
+			Message: "could not find price for the product",
 		}
 	}
-	return model.CalculatedProductPrice{
-		Product: ingredient.Product,
-		Message: "could not find price for the product",
+
+	calculated.Product = ingredient.Product
+	return calculated
+}
+
+func groupPurchasesByName(purchases []model.PurchasedProduct) map[string][]model.PurchasedProduct {
+	grouped := make(map[string][]model.PurchasedProduct)
+	for _, purchase := range purchases {
+		grouped[purchase.Name] = append(grouped[purchase.Name], purchase)
 	}
+	return grouped
 }
@@ -0,0 +1,161 @@
+package pricing
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/SarunasBucius/nutri-price-server/internal/model"
+	"github.com/SarunasBucius/nutri-price-server/internal/service/recipe/unitconv"
+	"github.com/SarunasBucius/nutri-price-server/internal/utils/umath"
+)
+
+// pricedPurchase is a purchase with the price of the ingredient amount calculated from it.
+// Amount is the purchased quantity in the ingredient unit, used as the weight of the purchase.
+type pricedPurchase struct {
+	purchase   model.PurchasedProduct
+	price      float64
+	amount     float64
+	conversion string
+}
+
+// Price calculates the price of the ingredient quantity from purchases of the product using the strategy.
+// dishDate is used by the dish date strategy, purchases up to now are used by other strategies.
+// Returns false if no purchase matches the strategy or can be converted to the ingredient unit.
+func Price(ingredient model.Quantity, purchases []model.PurchasedProduct, conversion model.UnitConversion,
+	strategy model.PriceStrategy, dishDate, now time.Time) (model.CalculatedProductPrice, bool) {
+	priced := priceIngredient(ingredient, filterPurchases(purchases, strategy, dishDate, now), conversion)
+	if len(priced) == 0 {
+		return model.CalculatedProductPrice{}, false
+	}
+
+	var price float64
+	var sources []pricedPurchase
+	switch strategy.Name {
+	case model.PriceStrategyWeightedAverage:
+		price, sources = getWeightedAverage(ingredient.Amount, priced), priced
+	case model.PriceStrategyMedian:
+		price, sources = getMedian(priced)
+	case model.PriceStrategyCheapest, model.PriceStrategyCheapestAtRetailer:
+		cheapest := slices.MinFunc(priced, func(a, b pricedPurchase) int {
+			return compareFloats(a.price, b.price)
+		})
+		price, sources = cheapest.price, []pricedPurchase{cheapest}
+	default:
+		latest := slices.MaxFunc(priced, comparePurchaseDates)
+		price, sources = latest.price, []pricedPurchase{latest}
+	}
+
+	calculated := model.CalculatedProductPrice{Price: umath.RoundFloat(price, 2)}
+	var conversions []string
+	for _, source := range sources {
+		calculated.Sources = append(calculated.Sources, model.PriceSource{
+			PurchaseID:  source.purchase.ID,
+			VarietyName: source.purchase.VarietyName,
+			Retailer:    source.purchase.Retailer,
+			Date:        source.purchase.Date.Format(time.DateOnly),
+			Price:       source.purchase.Price,
+			Quantity:    source.purchase.Quantity,
+		})
+		if source.conversion != "" && !slices.Contains(conversions, source.conversion) {
+			conversions = append(conversions, source.conversion)
+		}
+	}
+	calculated.Conversion = strings.Join(conversions, "; ")
+
+	return calculated, true
+}
+
+func filterPurchases(purchases []model.PurchasedProduct, strategy model.PriceStrategy, dishDate, now time.Time) []model.PurchasedProduct {
+	var from time.Time
+	if strategy.Days > 0 && strategy.Name != model.PriceStrategyLast && strategy.Name != model.PriceStrategyDishDate {
+		from = now.AddDate(0, 0, -strategy.Days)
+	}
+	to := now
+	if strategy.Name == model.PriceStrategyDishDate && !dishDate.IsZero() {
+		to = dishDate
+	}
+
+	var filtered []model.PurchasedProduct
+	for _, purchase := range purchases {
+		if purchase.Date.Before(from) || purchase.Date.After(to) {
+			continue
+		}
+		if strategy.Name == model.PriceStrategyCheapestAtRetailer && !strings.EqualFold(purchase.Retailer, strategy.Retailer) {
+			continue
+		}
+		filtered = append(filtered, purchase)
+	}
+	return filtered
+}
+
+// priceIngredient prices the ingredient by every purchase its quantity can be converted to.
+func priceIngredient(ingredient model.Quantity, purchases []model.PurchasedProduct, conversion model.UnitConversion) []pricedPurchase {
+	var priced []pricedPurchase
+	for _, purchase := range purchases {
+		if purchase.Quantity.Amount <= 0 {
+			continue
+		}
+		converted, description, err := unitconv.Convert(ingredient, purchase.Quantity.Unit, conversion)
+		if err != nil {
+			continue
+		}
+		purchasedAmount, _, err := unitconv.Convert(purchase.Quantity, ingredient.Unit, conversion)
+		if err != nil {
+			continue
+		}
+
+		priced = append(priced, pricedPurchase{
+			purchase:   purchase,
+			price:      purchase.Price / purchase.Quantity.Amount * converted.Amount,
+			amount:     purchasedAmount.Amount,
+			conversion: description,
+		})
+	}
+	return priced
+}
+
+// getWeightedAverage weights unit prices by purchased amounts, so a single small purchase affects the price less.
+func getWeightedAverage(ingredientAmount float64, priced []pricedPurchase) float64 {
+	var totalPrice, totalAmount float64
+	for _, p := range priced {
+		totalPrice += p.purchase.Price
+		totalAmount += p.amount
+	}
+	if totalAmount == 0 {
+		return 0
+	}
+	return totalPrice / totalAmount * ingredientAmount
+}
+
+// getMedian returns the median price and the purchases in the middle it was taken from.
+func getMedian(priced []pricedPurchase) (float64, []pricedPurchase) {
+	sorted := slices.Clone(priced)
+	slices.SortStableFunc(sorted, func(a, b pricedPurchase) int {
+		return compareFloats(a.price, b.price)
+	})
+
+	middle := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return sorted[middle].price, sorted[middle : middle+1]
+	}
+	return (sorted[middle-1].price + sorted[middle].price) / 2, sorted[middle-1 : middle+1]
+}
+
+func comparePurchaseDates(a, b pricedPurchase) int {
+	if c := a.purchase.Date.Compare(b.purchase.Date); c != 0 {
+		return c
+	}
+	return a.purchase.ID - b.purchase.ID
+}
+
+func compareFloats(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
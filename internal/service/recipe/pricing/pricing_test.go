@@ -0,0 +1,115 @@
+package pricing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SarunasBucius/nutri-price-server/internal/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrice(t *testing.T) {
+	now := time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC)
+	purchases := []model.PurchasedProduct{
+		{ID: 1, Retailer: "lidl", Price: 2, Quantity: model.Quantity{Unit: model.Grams, Amount: 1000}, Date: now.AddDate(0, 0, -60)},
+		{ID: 2, Retailer: "norfa", Price: 1.5, Quantity: model.Quantity{Unit: model.Grams, Amount: 500}, Date: now.AddDate(0, 0, -20)},
+		{ID: 3, Retailer: "lidl", Price: 0.5, Quantity: model.Quantity{Unit: model.Grams, Amount: 100}, Date: now.AddDate(0, 0, -10)},
+		{ID: 4, Retailer: "barbora", Price: 2.4, Quantity: model.Quantity{Unit: "kg", Amount: 1}, Date: now.AddDate(0, 0, -1)},
+	}
+	ingredient := model.Quantity{Unit: model.Grams, Amount: 200}
+
+	tests := []struct {
+		name        string
+		ingredient  model.Quantity
+		purchases   []model.PurchasedProduct
+		strategy    model.PriceStrategy
+		dishDate    time.Time
+		want        float64
+		wantSources []int
+		wantOK      bool
+	}{
+		{
+			name:        "last",
+			ingredient:  ingredient,
+			purchases:   purchases,
+			strategy:    model.PriceStrategy{Name: model.PriceStrategyLast},
+			want:        0.48,
+			wantSources: []int{4},
+			wantOK:      true,
+		},
+		{
+			name:        "weighted_average",
+			ingredient:  ingredient,
+			purchases:   purchases,
+			strategy:    model.PriceStrategy{Name: model.PriceStrategyWeightedAverage, Days: 30},
+			want:        0.55,
+			wantSources: []int{2, 3, 4},
+			wantOK:      true,
+		},
+		{
+			name:        "median_of_even_count",
+			ingredient:  ingredient,
+			purchases:   purchases,
+			strategy:    model.PriceStrategy{Name: model.PriceStrategyMedian},
+			want:        0.54,
+			wantSources: []int{4, 2},
+			wantOK:      true,
+		},
+		{
+			name:        "cheapest",
+			ingredient:  ingredient,
+			purchases:   purchases,
+			strategy:    model.PriceStrategy{Name: model.PriceStrategyCheapest},
+			want:        0.4,
+			wantSources: []int{1},
+			wantOK:      true,
+		},
+		{
+			name:        "cheapest_at_retailer",
+			ingredient:  ingredient,
+			purchases:   purchases,
+			strategy:    model.PriceStrategy{Name: model.PriceStrategyCheapestAtRetailer, Retailer: "Norfa"},
+			want:        0.6,
+			wantSources: []int{2},
+			wantOK:      true,
+		},
+		{
+			name:        "dish_date",
+			ingredient:  ingredient,
+			purchases:   purchases,
+			strategy:    model.PriceStrategy{Name: model.PriceStrategyDishDate},
+			dishDate:    now.AddDate(0, 0, -15),
+			want:        0.6,
+			wantSources: []int{2},
+			wantOK:      true,
+		},
+		{
+			name:       "no_purchases_in_window",
+			ingredient: ingredient,
+			purchases:  purchases[:1],
+			strategy:   model.PriceStrategy{Name: model.PriceStrategyCheapest, Days: 30},
+		},
+		{
+			name:       "inconvertible_unit",
+			ingredient: model.Quantity{Unit: model.Pieces, Amount: 1},
+			purchases:  purchases,
+			strategy:   model.PriceStrategy{Name: model.PriceStrategyLast},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Price(tt.ingredient, tt.purchases, model.UnitConversion{}, tt.strategy, tt.dishDate, now)
+			require.Equal(t, tt.wantOK, ok)
+			if !tt.wantOK {
+				return
+			}
+			require.InDelta(t, tt.want, got.Price, 1e-9)
+
+			var sources []int
+			for _, source := range got.Sources {
+				sources = append(sources, source.PurchaseID)
+			}
+			require.Equal(t, tt.wantSources, sources)
+		})
+	}
+}
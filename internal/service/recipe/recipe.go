@@ -3,28 +3,34 @@ package recipe
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/SarunasBucius/nutri-price-server/internal/model"
+	"github.com/SarunasBucius/nutri-price-server/internal/utils/uerror"
 )
 
 type Service struct {
 	ProductRepo          IProductRepository
 	NutritionalValueRepo INutritionalValueRepository
 	RecipeRepo           IRecipeRepository
+	UnitConversionRepo   IUnitConversionRepository
 }
 
-func NewRecipeService(productRepo IProductRepository, nutritionalValueRepo INutritionalValueRepository, recipeRepo IRecipeRepository) *Service {
+func NewRecipeService(productRepo IProductRepository, nutritionalValueRepo INutritionalValueRepository, recipeRepo IRecipeRepository, unitConversionRepo IUnitConversionRepository) *Service {
 	return &Service{
 		ProductRepo:          productRepo,
 		NutritionalValueRepo: nutritionalValueRepo,
 		RecipeRepo:           recipeRepo,
+		UnitConversionRepo:   unitConversionRepo,
 	}
 }
 
 type INutritionalValueRepository interface {
 	GetProductsNutritionalValueByProductNames(ctx context.Context, productNames []string) ([]model.ProductNutritionalValue, error)
+	GetReferenceFoodsNutritionalValueByProductNames(ctx context.Context, productNames []string) ([]model.ProductNutritionalValue, error)
 	InsertEmptyProducts(ctx context.Context, products []string) error
+	GetNutrients(ctx context.Context) ([]model.Nutrient, error)
 }
 
 type IRecipeRepository interface {
@@ -38,10 +44,18 @@ type IRecipeRepository interface {
 	CloneRecipes(ctx context.Context, recipeIDs []int, date string, ingredientsByRecipeID map[int]model.Ingredients) error
 	GetRecipeNamesByIDs(ctx context.Context, recipeIDs []int) (map[int]string, error)
 	GetRecipeNames(ctx context.Context) ([]model.RecipeIDAndName, error)
+	GetRecipeDishMadeDates(ctx context.Context, recipeIDs []int) (map[int]time.Time, error)
+}
+
+type IUnitConversionRepository interface {
+	GetUnitConversions(ctx context.Context) ([]model.UnitConversion, error)
+	GetUnitConversionsByProducts(ctx context.Context, products []string) (map[string]model.UnitConversion, error)
+	UpsertUnitConversion(ctx context.Context, conversion model.UnitConversion) error
+	DeleteUnitConversion(ctx context.Context, product string) error
 }
 
 type IProductRepository interface {
-	GetLastBoughtProductsByNamesOrGroups(ctx context.Context, products []string) ([]model.PurchasedProduct, error)
+	GetPurchasesByNamesOrGroups(ctx context.Context, products []string) ([]model.PurchasedProduct, error)
 }
 
 func (s *Service) InsertRecipe(ctx context.Context, recipe model.RecipeNew) error {
@@ -89,27 +103,94 @@ func (s *Service) DeleteRecipe(ctx context.Context, recipeID int) error {
 	return nil
 }
 
-func (s *Service) GetMealPrice(ctx context.Context, recipeIDs []int) (model.CalculatedMealPrice, error) {
+func (s *Service) GetMealPrice(ctx context.Context, recipeIDs []int, strategy model.PriceStrategy) (model.CalculatedMealPrice, error) {
+	strategy, err := validatePriceStrategy(strategy)
+	if err != nil {
+		return model.CalculatedMealPrice{}, err
+	}
+
 	ingredients, err := s.RecipeRepo.GetRecipesIngredients(ctx, recipeIDs)
 	if err != nil {
 		return model.CalculatedMealPrice{}, fmt.Errorf("get recipes ingredients: %w", err)
 	}
 
-	products, err := s.ProductRepo.GetLastBoughtProductsByNamesOrGroups(ctx, ingredients.GetProductNames())
+	products, err := s.ProductRepo.GetPurchasesByNamesOrGroups(ctx, ingredients.GetProductNames())
 	if err != nil {
-		return model.CalculatedMealPrice{}, fmt.Errorf("get last bought products by names: %w", err)
+		return model.CalculatedMealPrice{}, fmt.Errorf("get purchases by names: %w", err)
 	}
 
-	return calculateMealPrice(ingredients, products), nil
+	conversions, err := s.UnitConversionRepo.GetUnitConversionsByProducts(ctx, ingredients.GetProductNames())
+	if err != nil {
+		return model.CalculatedMealPrice{}, fmt.Errorf("get unit conversions by products: %w", err)
+	}
+
+	var dishDates map[int]time.Time
+	if strategy.Name == model.PriceStrategyDishDate {
+		dishDates, err = s.RecipeRepo.GetRecipeDishMadeDates(ctx, recipeIDs)
+		if err != nil {
+			return model.CalculatedMealPrice{}, fmt.Errorf("get recipe dish made dates: %w", err)
+		}
+	}
+
+	return calculateMealPrice(ingredients, products, conversions, strategy, dishDates), nil
 }
 
-func (s *Service) GetMealPriceByDate(ctx context.Context, date time.Time) (model.CalculatedMealPrice, error) {
+func (s *Service) GetMealPriceByDate(ctx context.Context, date time.Time, strategy model.PriceStrategy) (model.CalculatedMealPrice, error) {
 	recipeIDs, err := s.RecipeRepo.GetRecipeIDsByDate(ctx, date)
 	if err != nil {
 		return model.CalculatedMealPrice{}, fmt.Errorf("get recipe IDs by date: %w", err)
 	}
 
-	return s.GetMealPrice(ctx, recipeIDs)
+	return s.GetMealPrice(ctx, recipeIDs, strategy)
+}
+
+// GetIngredientPrices prices ingredients in the given order. dishDate is used by the dish date strategy.
+func (s *Service) GetIngredientPrices(ctx context.Context, ingredients model.Ingredients, strategy model.PriceStrategy, dishDate time.Time) ([]model.CalculatedProductPrice, error) {
+	strategy, err := validatePriceStrategy(strategy)
+	if err != nil {
+		return nil, err
+	}
+
+	products, err := s.ProductRepo.GetPurchasesByNamesOrGroups(ctx, ingredients.GetProductNames())
+	if err != nil {
+		return nil, fmt.Errorf("get purchases by names: %w", err)
+	}
+
+	conversions, err := s.UnitConversionRepo.GetUnitConversionsByProducts(ctx, ingredients.GetProductNames())
+	if err != nil {
+		return nil, fmt.Errorf("get unit conversions by products: %w", err)
+	}
+
+	purchasesByProduct := groupPurchasesByName(products)
+	now := time.Now()
+	prices := make([]model.CalculatedProductPrice, 0, len(ingredients))
+	for _, ingredient := range ingredients {
+		prices = append(prices, calculateIngredientPrice(ingredient, purchasesByProduct[ingredient.Product],
+			conversions[ingredient.Product], strategy, dishDate, now))
+	}
+	return prices, nil
+}
+
+// validatePriceStrategy defaults an empty strategy to the last price.
+func validatePriceStrategy(strategy model.PriceStrategy) (model.PriceStrategy, error) {
+	strategy.Retailer = strings.TrimSpace(strategy.Retailer)
+	switch strategy.Name {
+	case "":
+		strategy.Name = model.PriceStrategyLast
+	case model.PriceStrategyLast, model.PriceStrategyWeightedAverage, model.PriceStrategyMedian,
+		model.PriceStrategyCheapest, model.PriceStrategyDishDate:
+	case model.PriceStrategyCheapestAtRetailer:
+		if strategy.Retailer == "" {
+			return model.PriceStrategy{}, uerror.NewBadRequest("retailer is required for cheapest_at_retailer strategy", nil)
+		}
+	default:
+		return model.PriceStrategy{}, uerror.NewBadRequest(fmt.Sprintf("unknown price strategy %q", strategy.Name), nil)
+	}
+
+	if strategy.Days < 0 {
+		return model.PriceStrategy{}, uerror.NewBadRequest("days must not be negative", nil)
+	}
+	return strategy, nil
 }
 
 func (s *Service) GetMealNutritionalValue(ctx context.Context, recipeIDs []int) (model.CalculatedMealNutritionalValue, error) {
@@ -122,13 +203,31 @@ func (s *Service) GetMealNutritionalValue(ctx context.Context, recipeIDs []int)
 	if err != nil {
 		return model.CalculatedMealNutritionalValue{}, fmt.Errorf("get products nutritional value: %w", err)
 	}
+	// Reference foods go last, so they are used only when a product has no nutritional value in the ingredient unit.
+	referenceFoodsNutritionalValue, err := s.NutritionalValueRepo.GetReferenceFoodsNutritionalValueByProductNames(ctx, ingredients.GetProductNames())
+	if err != nil {
+		return model.CalculatedMealNutritionalValue{}, fmt.Errorf("get reference foods nutritional value: %w", err)
+	}
+	productsNutritionalValue = append(productsNutritionalValue, referenceFoodsNutritionalValue...)
 
 	recipeNamesByIDs, err := s.RecipeRepo.GetRecipeNamesByIDs(ctx, recipeIDs)
 	if err != nil {
 		return model.CalculatedMealNutritionalValue{}, fmt.Errorf("get recipe names by IDs: %w", err)
 	}
 
-	return calculateMealNutritionalValue(ingredients, productsNutritionalValue, recipeNamesByIDs), nil
+	conversions, err := s.UnitConversionRepo.GetUnitConversionsByProducts(ctx, ingredients.GetProductNames())
+	if err != nil {
+		return model.CalculatedMealNutritionalValue{}, fmt.Errorf("get unit conversions by products: %w", err)
+	}
+
+	nutrients, err := s.NutritionalValueRepo.GetNutrients(ctx)
+	if err != nil {
+		return model.CalculatedMealNutritionalValue{}, fmt.Errorf("get nutrients: %w", err)
+	}
+
+	meal := calculateMealNutritionalValue(ingredients, productsNutritionalValue, recipeNamesByIDs, conversions)
+	meal.DailyReferenceShares = getDailyReferenceShares(meal.NutritionalValue, nutrients)
+	return meal, nil
 }
 
 func (s *Service) GetMealNutritionalValueByDate(ctx context.Context, date time.Time) (model.CalculatedMealNutritionalValue, error) {
@@ -0,0 +1,117 @@
+package unitconv
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/SarunasBucius/nutri-price-server/internal/model"
+)
+
+// Kitchen units accepted in recipes in addition to grams, milliliters and pieces.
+const (
+	Kilograms   = "kg"
+	Liters      = "l"
+	Tablespoons = "tbsp"
+	Teaspoons   = "tsp"
+	Cups        = "cup"
+)
+
+// baseUnit is a unit expressed as an amount of grams, milliliters or pieces.
+type baseUnit struct {
+	unit   string
+	amount float64
+}
+
+var baseUnits = map[string]baseUnit{
+	model.Grams:       {unit: model.Grams, amount: 1},
+	model.Milliliters: {unit: model.Milliliters, amount: 1},
+	model.Pieces:      {unit: model.Pieces, amount: 1},
+	Kilograms:         {unit: model.Grams, amount: 1000},
+	Liters:            {unit: model.Milliliters, amount: 1000},
+	Tablespoons:       {unit: model.Milliliters, amount: 15},
+	Teaspoons:         {unit: model.Milliliters, amount: 5},
+	Cups:              {unit: model.Milliliters, amount: 240},
+}
+
+// Convert converts the quantity to the unit. Grams, milliliters and pieces are converted between each other
+// with the product density and piece weight. The returned description lists the applied conversion steps
+// and is empty when the quantity already is in the unit.
+func Convert(quantity model.Quantity, toUnit string, product model.UnitConversion) (model.Quantity, string, error) {
+	if quantity.Unit == toUnit {
+		return quantity, "", nil
+	}
+
+	from, ok := baseUnits[quantity.Unit]
+	if !ok {
+		return model.Quantity{}, "", fmt.Errorf("unknown unit %q", quantity.Unit)
+	}
+	to, ok := baseUnits[toUnit]
+	if !ok {
+		return model.Quantity{}, "", fmt.Errorf("unknown unit %q", toUnit)
+	}
+
+	var steps []string
+	amount := quantity.Amount * from.amount
+	if from.amount != 1 {
+		steps = append(steps, fmt.Sprintf("1 %s = %s %s", quantity.Unit, formatAmount(from.amount), from.unit))
+	}
+
+	amount, baseSteps, err := convertBase(amount, from.unit, to.unit, product)
+	if err != nil {
+		return model.Quantity{}, "", err
+	}
+	steps = append(steps, baseSteps...)
+
+	amount /= to.amount
+	if to.amount != 1 {
+		steps = append(steps, fmt.Sprintf("1 %s = %s %s", toUnit, formatAmount(to.amount), to.unit))
+	}
+
+	return model.Quantity{Unit: toUnit, Amount: amount}, strings.Join(steps, ", "), nil
+}
+
+// convertBase converts the amount between grams, milliliters and pieces.
+// Pieces and milliliters are converted through grams.
+func convertBase(amount float64, from, to string, product model.UnitConversion) (float64, []string, error) {
+	if from == to {
+		return amount, nil, nil
+	}
+
+	var steps []string
+	switch from {
+	case model.Pieces:
+		if product.PieceWeight <= 0 {
+			return 0, nil, fmt.Errorf("piece weight of %q is unknown", product.Product)
+		}
+		amount *= product.PieceWeight
+		steps = append(steps, fmt.Sprintf("1 piece = %s g", formatAmount(product.PieceWeight)))
+	case model.Milliliters:
+		if product.Density <= 0 {
+			return 0, nil, fmt.Errorf("density of %q is unknown", product.Product)
+		}
+		amount *= product.Density
+		steps = append(steps, fmt.Sprintf("density %s g/ml", formatAmount(product.Density)))
+	}
+
+	switch to {
+	case model.Pieces:
+		if product.PieceWeight <= 0 {
+			return 0, nil, fmt.Errorf("piece weight of %q is unknown", product.Product)
+		}
+		amount /= product.PieceWeight
+		steps = append(steps, fmt.Sprintf("1 piece = %s g", formatAmount(product.PieceWeight)))
+	case model.Milliliters:
+		if product.Density <= 0 {
+			return 0, nil, fmt.Errorf("density of %q is unknown", product.Product)
+		}
+		amount /= product.Density
+		steps = append(steps, fmt.Sprintf("density %s g/ml", formatAmount(product.Density)))
+	}
+
+	return amount, steps, nil
+}
+
+func formatAmount(amount float64) string {
+	return strconv.FormatFloat(amount, 'f', -1, 64)
+}
@@ -0,0 +1,79 @@
+package unitconv
+
+import (
+	"testing"
+
+	"github.com/SarunasBucius/nutri-price-server/internal/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvert(t *testing.T) {
+	oil := model.UnitConversion{Product: "olive oil", Density: 0.92}
+	egg := model.UnitConversion{Product: "eggs", PieceWeight: 60}
+
+	tests := []struct {
+		name            string
+		quantity        model.Quantity
+		toUnit          string
+		product         model.UnitConversion
+		want            model.Quantity
+		wantDescription string
+		wantErr         bool
+	}{
+		{
+			name:     "same_unit",
+			quantity: model.Quantity{Unit: model.Grams, Amount: 100},
+			toUnit:   model.Grams,
+			want:     model.Quantity{Unit: model.Grams, Amount: 100},
+		},
+		{
+			name:            "kitchen_unit",
+			quantity:        model.Quantity{Unit: Kilograms, Amount: 1.5},
+			toUnit:          model.Grams,
+			want:            model.Quantity{Unit: model.Grams, Amount: 1500},
+			wantDescription: "1 kg = 1000 grams",
+		},
+		{
+			name:            "volume_to_weight",
+			quantity:        model.Quantity{Unit: Tablespoons, Amount: 2},
+			toUnit:          model.Grams,
+			product:         oil,
+			want:            model.Quantity{Unit: model.Grams, Amount: 27.6},
+			wantDescription: "1 tbsp = 15 milliliters, density 0.92 g/ml",
+		},
+		{
+			name:            "weight_to_pieces",
+			quantity:        model.Quantity{Unit: model.Grams, Amount: 180},
+			toUnit:          model.Pieces,
+			product:         egg,
+			want:            model.Quantity{Unit: model.Pieces, Amount: 3},
+			wantDescription: "1 piece = 60 g",
+		},
+		{
+			name:     "unknown_density",
+			quantity: model.Quantity{Unit: Cups, Amount: 1},
+			toUnit:   model.Grams,
+			product:  egg,
+			wantErr:  true,
+		},
+		{
+			name:     "unknown_unit",
+			quantity: model.Quantity{Unit: "pinch", Amount: 1},
+			toUnit:   model.Grams,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, description, err := Convert(tt.quantity, tt.toUnit, tt.product)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want.Unit, got.Unit)
+			require.InDelta(t, tt.want.Amount, got.Amount, 1e-9)
+			require.Equal(t, tt.wantDescription, description)
+		})
+	}
+}
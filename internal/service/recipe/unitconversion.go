@@ -0,0 +1,40 @@
+package recipe
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/SarunasBucius/nutri-price-server/internal/model"
+	"github.com/SarunasBucius/nutri-price-server/internal/utils/uerror"
+)
+
+func (s *Service) GetUnitConversions(ctx context.Context) ([]model.UnitConversion, error) {
+	conversions, err := s.UnitConversionRepo.GetUnitConversions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get unit conversions: %w", err)
+	}
+	return conversions, nil
+}
+
+func (s *Service) UpsertUnitConversion(ctx context.Context, conversion model.UnitConversion) error {
+	conversion.Product = strings.TrimSpace(conversion.Product)
+	if conversion.Product == "" {
+		return uerror.NewBadRequest("missing product", nil)
+	}
+	if conversion.Density < 0 || conversion.PieceWeight < 0 {
+		return uerror.NewBadRequest("density and piece weight must not be negative", nil)
+	}
+
+	if err := s.UnitConversionRepo.UpsertUnitConversion(ctx, conversion); err != nil {
+		return fmt.Errorf("upsert unit conversion: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) DeleteUnitConversion(ctx context.Context, product string) error {
+	if err := s.UnitConversionRepo.DeleteUnitConversion(ctx, product); err != nil {
+		return fmt.Errorf("delete unit conversion: %w", err)
+	}
+	return nil
+}
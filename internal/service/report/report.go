@@ -0,0 +1,68 @@
+package report
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/SarunasBucius/nutri-price-server/internal/model"
+	"github.com/SarunasBucius/nutri-price-server/internal/service/report/spending"
+	"github.com/SarunasBucius/nutri-price-server/internal/utils/uerror"
+)
+
+const defaultTopMovers = 5
+
+type Service struct {
+	ReportRepo IReportRepository
+}
+
+func NewReportService(reportRepo IReportRepository) *Service {
+	return &Service{
+		ReportRepo: reportRepo,
+	}
+}
+
+type IReportRepository interface {
+	GetSpendingRows(ctx context.Context, from, to time.Time, filter model.ProductFilter) ([]model.SpendingRow, error)
+}
+
+// GetSpendingReport aggregates spending of the requested period and compares it with the period of the same length
+// right before it. Spending is bucketed by month and grouped by category unless requested otherwise.
+func (s *Service) GetSpendingReport(ctx context.Context, request model.SpendingReportRequest) (model.SpendingReport, error) {
+	if request.From.IsZero() || request.To.IsZero() || request.From.After(request.To) {
+		return model.SpendingReport{}, uerror.NewBadRequest("from date must not be after to date", nil)
+	}
+	if request.Period == "" {
+		request.Period = model.ReportPeriodMonth
+	}
+	if request.Period != model.ReportPeriodMonth && request.Period != model.ReportPeriodWeek {
+		return model.SpendingReport{}, uerror.NewBadRequest(fmt.Sprintf("unknown report period %q", request.Period), nil)
+	}
+	if request.GroupBy == "" {
+		request.GroupBy = model.ReportGroupCategory
+	}
+	groups := []string{model.ReportGroupRetailer, model.ReportGroupProduct, model.ReportGroupVariety, model.ReportGroupCategory}
+	if !slices.Contains(groups, request.GroupBy) {
+		return model.SpendingReport{}, uerror.NewBadRequest(fmt.Sprintf("unknown report grouping %q", request.GroupBy), nil)
+	}
+	if request.Movers < 0 {
+		return model.SpendingReport{}, uerror.NewBadRequest("movers must not be negative", nil)
+	}
+	if request.Movers == 0 {
+		request.Movers = defaultTopMovers
+	}
+
+	rows, err := s.ReportRepo.GetSpendingRows(ctx, request.From, request.To, request.Filter)
+	if err != nil {
+		return model.SpendingReport{}, fmt.Errorf("get spending rows: %w", err)
+	}
+
+	days := int(request.To.Sub(request.From).Hours()/24) + 1
+	previousRows, err := s.ReportRepo.GetSpendingRows(ctx, request.From.AddDate(0, 0, -days), request.From.AddDate(0, 0, -1), request.Filter)
+	if err != nil {
+		return model.SpendingReport{}, fmt.Errorf("get previous spending rows: %w", err)
+	}
+
+	return spending.Build(rows, previousRows, request), nil
+}
@@ -0,0 +1,188 @@
+package spending
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/SarunasBucius/nutri-price-server/internal/model"
+	"github.com/SarunasBucius/nutri-price-server/internal/utils/umath"
+	"github.com/SarunasBucius/nutri-price-server/internal/utils/utime"
+)
+
+// receiptKey identifies a receipt by its raw receipt id. Purchases entered without a raw receipt
+// are keyed by the retailer and the purchase date instead.
+type receiptKey struct {
+	receiptID string
+	retailer  string
+	date      time.Time
+}
+
+func getReceiptKey(row model.SpendingRow) receiptKey {
+	if row.ReceiptID != "" {
+		return receiptKey{receiptID: row.ReceiptID}
+	}
+	return receiptKey{retailer: row.Retailer, date: row.Date}
+}
+
+// total sums spending, purchases and receipts.
+type total struct {
+	spending  float64
+	purchases int
+	receipts  map[receiptKey]struct{}
+}
+
+func (t *total) add(row model.SpendingRow) {
+	if t.receipts == nil {
+		t.receipts = make(map[receiptKey]struct{})
+	}
+	t.spending += row.Price
+	t.purchases++
+	t.receipts[getReceiptKey(row)] = struct{}{}
+}
+
+func (t *total) averagePerReceipt() float64 {
+	if len(t.receipts) == 0 {
+		return 0
+	}
+	return umath.RoundFloat(t.spending/float64(len(t.receipts)), 2)
+}
+
+// Build aggregates rows of the requested period into a report and compares groups with rows of the previous period.
+func Build(rows, previousRows []model.SpendingRow, request model.SpendingReportRequest) model.SpendingReport {
+	var reportTotal total
+	periodTotals := make(map[time.Time]*total)
+	groupTotals := make(map[time.Time]map[string]*total)
+	currentByGroup := make(map[string]float64)
+	for _, row := range rows {
+		period := utime.PeriodStart(row.Date, request.Period == model.ReportPeriodMonth)
+		group := groupKey(row, request.GroupBy)
+		if periodTotals[period] == nil {
+			periodTotals[period] = &total{}
+			groupTotals[period] = make(map[string]*total)
+		}
+		if groupTotals[period][group] == nil {
+			groupTotals[period][group] = &total{}
+		}
+
+		reportTotal.add(row)
+		periodTotals[period].add(row)
+		groupTotals[period][group].add(row)
+		currentByGroup[group] += row.Price
+	}
+
+	report := model.SpendingReport{
+		From:              request.From.Format(time.DateOnly),
+		To:                request.To.Format(time.DateOnly),
+		Period:            request.Period,
+		GroupBy:           request.GroupBy,
+		Total:             umath.RoundFloat(reportTotal.spending, 2),
+		Receipts:          len(reportTotal.receipts),
+		AveragePerReceipt: reportTotal.averagePerReceipt(),
+		Periods:           make([]model.SpendingPeriod, 0, len(periodTotals)),
+	}
+
+	periods := make([]time.Time, 0, len(periodTotals))
+	for period := range periodTotals {
+		periods = append(periods, period)
+	}
+	slices.SortFunc(periods, time.Time.Compare)
+
+	for _, period := range periods {
+		periodTotal := periodTotals[period]
+		spendingPeriod := model.SpendingPeriod{
+			PeriodStart:       period.Format(time.DateOnly),
+			Total:             umath.RoundFloat(periodTotal.spending, 2),
+			Receipts:          len(periodTotal.receipts),
+			AveragePerReceipt: periodTotal.averagePerReceipt(),
+			Groups:            make([]model.SpendingGroup, 0, len(groupTotals[period])),
+		}
+		for group, groupTotal := range groupTotals[period] {
+			spendingGroup := model.SpendingGroup{
+				Group:             group,
+				Total:             umath.RoundFloat(groupTotal.spending, 2),
+				Purchases:         groupTotal.purchases,
+				Receipts:          len(groupTotal.receipts),
+				AveragePerReceipt: groupTotal.averagePerReceipt(),
+			}
+			if periodTotal.spending != 0 {
+				spendingGroup.Share = umath.RoundFloat(groupTotal.spending/periodTotal.spending, 4)
+			}
+			spendingPeriod.Groups = append(spendingPeriod.Groups, spendingGroup)
+		}
+		slices.SortFunc(spendingPeriod.Groups, func(a, b model.SpendingGroup) int {
+			if c := cmp.Compare(b.Total, a.Total); c != 0 {
+				return c
+			}
+			return cmp.Compare(a.Group, b.Group)
+		})
+		report.Periods = append(report.Periods, spendingPeriod)
+	}
+
+	previousByGroup := make(map[string]float64)
+	for _, row := range previousRows {
+		previousByGroup[groupKey(row, request.GroupBy)] += row.Price
+	}
+	report.TopMovers = getTopMovers(currentByGroup, previousByGroup, request.Movers)
+
+	return report
+}
+
+// getTopMovers returns at most limit groups with the largest absolute change of spending.
+func getTopMovers(current, previous map[string]float64, limit int) []model.SpendingMover {
+	movers := make([]model.SpendingMover, 0, len(current)+len(previous))
+	addMover := func(group string) {
+		mover := model.SpendingMover{
+			Group:         group,
+			PreviousTotal: umath.RoundFloat(previous[group], 2),
+			CurrentTotal:  umath.RoundFloat(current[group], 2),
+		}
+		mover.Change = umath.RoundFloat(mover.CurrentTotal-mover.PreviousTotal, 2)
+		if mover.PreviousTotal != 0 {
+			changePercent := umath.RoundFloat(mover.Change/mover.PreviousTotal*100, 2)
+			mover.ChangePercent = &changePercent
+		}
+		if mover.Change != 0 {
+			movers = append(movers, mover)
+		}
+	}
+	for group := range current {
+		addMover(group)
+	}
+	for group := range previous {
+		if _, ok := current[group]; !ok {
+			addMover(group)
+		}
+	}
+
+	slices.SortFunc(movers, func(a, b model.SpendingMover) int {
+		if c := cmp.Compare(math.Abs(b.Change), math.Abs(a.Change)); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Group, b.Group)
+	})
+	if limit > 0 && len(movers) > limit {
+		movers = movers[:limit]
+	}
+	return movers
+}
+
+func groupKey(row model.SpendingRow, groupBy string) string {
+	switch groupBy {
+	case model.ReportGroupRetailer:
+		return row.Retailer
+	case model.ReportGroupProduct:
+		return row.Product
+	case model.ReportGroupVariety:
+		if row.VarietyName == "" {
+			return row.Product
+		}
+		return row.VarietyName
+	default:
+		if row.Category == "" {
+			return model.UncategorisedGroup
+		}
+		return row.Category
+	}
+}
@@ -0,0 +1,100 @@
+package spending
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SarunasBucius/nutri-price-server/internal/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuild(t *testing.T) {
+	date := func(month, day int) time.Time {
+		return time.Date(2025, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	}
+	rows := []model.SpendingRow{
+		{Date: date(5, 2), Retailer: "lidl", Product: "milk", VarietyName: "milk 2.5%", Category: "Dairy", Price: 1},
+		{Date: date(5, 2), Retailer: "lidl", Product: "apples", Price: 3},
+		{Date: date(5, 9), Retailer: "norfa", Product: "milk", Category: "Dairy", Price: 2},
+		{Date: date(6, 1), Retailer: "lidl", Product: "cheese", Category: "Dairy", Price: 4},
+	}
+	previousRows := []model.SpendingRow{
+		{Date: date(3, 2), Retailer: "lidl", Product: "apples", Price: 1},
+		{Date: date(3, 2), Retailer: "lidl", Product: "candy", Category: "Sweets", Price: 2},
+		{Date: date(4, 2), Retailer: "lidl", Product: "milk", Category: "Dairy", Price: 7},
+	}
+	request := model.SpendingReportRequest{
+		From:    date(5, 1),
+		To:      date(6, 30),
+		Period:  model.ReportPeriodMonth,
+		GroupBy: model.ReportGroupCategory,
+		Movers:  2,
+	}
+
+	got := Build(rows, previousRows, request)
+
+	require.Equal(t, model.SpendingReport{
+		From:              "2025-05-01",
+		To:                "2025-06-30",
+		Period:            model.ReportPeriodMonth,
+		GroupBy:           model.ReportGroupCategory,
+		Total:             10,
+		Receipts:          3,
+		AveragePerReceipt: 3.33,
+		Periods: []model.SpendingPeriod{
+			{
+				PeriodStart: "2025-05-01", Total: 6, Receipts: 2, AveragePerReceipt: 3,
+				Groups: []model.SpendingGroup{
+					{Group: "Dairy", Total: 3, Purchases: 2, Receipts: 2, AveragePerReceipt: 1.5, Share: 0.5},
+					{Group: model.UncategorisedGroup, Total: 3, Purchases: 1, Receipts: 1, AveragePerReceipt: 3, Share: 0.5},
+				},
+			},
+			{
+				PeriodStart: "2025-06-01", Total: 4, Receipts: 1, AveragePerReceipt: 4,
+				Groups: []model.SpendingGroup{
+					{Group: "Dairy", Total: 4, Purchases: 1, Receipts: 1, AveragePerReceipt: 4, Share: 1},
+				},
+			},
+		},
+		TopMovers: []model.SpendingMover{
+			{Group: "Sweets", PreviousTotal: 2, CurrentTotal: 0, Change: -2, ChangePercent: ptr(-100.0)},
+			{Group: model.UncategorisedGroup, PreviousTotal: 1, CurrentTotal: 3, Change: 2, ChangePercent: ptr(200.0)},
+		},
+	}, got)
+}
+
+func TestBuild_groupByVarietyWeekly(t *testing.T) {
+	rows := []model.SpendingRow{
+		{Date: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), Retailer: "lidl", Product: "milk", VarietyName: "milk 2.5%", Price: 1},
+		{Date: time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC), Retailer: "lidl", Product: "bread", Price: 2},
+	}
+
+	got := Build(rows, nil, model.SpendingReportRequest{Period: model.ReportPeriodWeek, GroupBy: model.ReportGroupVariety})
+
+	require.Len(t, got.Periods, 2)
+	require.Equal(t, "2025-05-26", got.Periods[0].PeriodStart)
+	require.Equal(t, "milk 2.5%", got.Periods[0].Groups[0].Group)
+	require.Equal(t, "2025-06-02", got.Periods[1].PeriodStart)
+	require.Equal(t, "bread", got.Periods[1].Groups[0].Group)
+	require.Len(t, got.TopMovers, 2)
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestBuild_countsReceiptsByRawReceipt(t *testing.T) {
+	date := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+	rows := []model.SpendingRow{
+		{ReceiptID: "1", Date: date, Retailer: "lidl", Product: "milk", Price: 1},
+		{ReceiptID: "1", Date: date, Retailer: "lidl", Product: "bread", Price: 2},
+		{ReceiptID: "2", Date: date, Retailer: "lidl", Product: "cheese", Price: 3},
+		{Date: date, Retailer: "lidl", Product: "apples", Price: 2},
+		{Date: date, Retailer: "lidl", Product: "pears", Price: 4},
+	}
+
+	got := Build(rows, nil, model.SpendingReportRequest{Period: model.ReportPeriodMonth, GroupBy: model.ReportGroupRetailer})
+
+	require.Equal(t, 3, got.Receipts)
+	require.Equal(t, 4.0, got.AveragePerReceipt)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
@@ -16,6 +17,9 @@ type Config struct {
 	Port     string
 	DBPool   *pgxpool.Pool
 	DynamoDB *dynamodb.Client
+	// MaildirPath enables polling of a local Maildir for emailed receipts when set.
+	MaildirPath         string
+	MaildirPollInterval time.Duration
 }
 
 func LoadConfig(ctx context.Context) (Config, error) {
@@ -39,13 +43,37 @@ func LoadConfig(ctx context.Context) (Config, error) {
 		return Config{}, fmt.Errorf("init dynamodb: %w", err)
 	}
 
+	maildirPollInterval, err := getMaildirPollInterval()
+	if err != nil {
+		return Config{}, fmt.Errorf("get maildir poll interval: %w", err)
+	}
+
 	return Config{
-		Port:     port,
-		DBPool:   dbPool,
-		DynamoDB: dynamoDB,
+		Port:                port,
+		DBPool:              dbPool,
+		DynamoDB:            dynamoDB,
+		MaildirPath:         os.Getenv("MAILDIR_PATH"),
+		MaildirPollInterval: maildirPollInterval,
 	}, nil
 }
 
+func getMaildirPollInterval() (time.Duration, error) {
+	const defaultInterval = 5 * time.Minute
+
+	interval := os.Getenv("MAILDIR_POLL_INTERVAL")
+	if len(interval) == 0 {
+		return defaultInterval, nil
+	}
+	parsedInterval, err := time.ParseDuration(interval)
+	if err != nil {
+		return 0, err
+	}
+	if parsedInterval <= 0 {
+		return 0, fmt.Errorf("interval must be positive, got %s", interval)
+	}
+	return parsedInterval, nil
+}
+
 func initPostgres(ctx context.Context, dbURL string) (*pgxpool.Pool, error) {
 	dbPool, err := pgxpool.New(ctx, dbURL)
 	if err != nil {
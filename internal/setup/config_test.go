@@ -0,0 +1,37 @@
+package setup
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetMaildirPollInterval(t *testing.T) {
+	tests := []struct {
+		name     string
+		interval string
+		want     time.Duration
+		wantErr  bool
+	}{
+		{name: "default", want: 5 * time.Minute},
+		{name: "custom", interval: "30s", want: 30 * time.Second},
+		{name: "zero", interval: "0", wantErr: true},
+		{name: "negative", interval: "-1m", wantErr: true},
+		{name: "invalid", interval: "often", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("MAILDIR_POLL_INTERVAL", tt.interval)
+
+			got, err := getMaildirPollInterval()
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
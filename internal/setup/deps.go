@@ -3,10 +3,14 @@ package setup
 import (
 	"github.com/SarunasBucius/nutri-price-server/internal/api"
 	"github.com/SarunasBucius/nutri-price-server/internal/repository"
+	"github.com/SarunasBucius/nutri-price-server/internal/service/alias"
+	"github.com/SarunasBucius/nutri-price-server/internal/service/budget"
+	"github.com/SarunasBucius/nutri-price-server/internal/service/fooddata"
 	"github.com/SarunasBucius/nutri-price-server/internal/service/nutritionalvalue"
 	"github.com/SarunasBucius/nutri-price-server/internal/service/product"
 	"github.com/SarunasBucius/nutri-price-server/internal/service/receipt"
 	"github.com/SarunasBucius/nutri-price-server/internal/service/recipe"
+	"github.com/SarunasBucius/nutri-price-server/internal/service/report"
 )
 
 type handlers struct {
@@ -14,28 +18,67 @@ type handlers struct {
 	receipt *api.ReceiptAPI
 	nv      *api.NutritionalValueAPI
 	recipes *api.RecipeAPI
+	aliases *api.AliasAPI
+	reports *api.ReportAPI
+	budgets *api.BudgetAPI
 }
 
 func loadAPIHandlers(conf Config) handlers {
 	receiptRepo := repository.NewReceiptRepo(conf.DBPool)
 	productRepo := repository.NewProductRepo(conf.DBPool)
 	nvRepo := repository.NewNutritionalValueRepo(conf.DBPool)
-	recipesRepo := repository.NewRecipeRepo(conf.DBPool)
+	unitConversionRepo := repository.NewUnitConversionRepo(conf.DBPool)
 
-	receiptService := receipt.NewReceiptService(receiptRepo)
-	productService := product.NewProductService(productRepo, receiptRepo, nvRepo)
-	nvService := nutritionalvalue.NewNutritionalValueService(nvRepo)
-	recipeService := recipe.NewRecipeService(productRepo, nvRepo, recipesRepo)
+	budgetService := budget.NewBudgetService(repository.NewBudgetRepo(conf.DBPool))
+	receiptService := LoadReceiptService(conf)
+	productService := product.NewProductService(productRepo, receiptRepo, nvRepo, unitConversionRepo, budgetService)
+	nvService := LoadNutritionalValueService(conf)
+	recipeService := LoadRecipeService(conf)
+	aliasService := LoadAliasService(conf)
+	reportService := report.NewReportService(repository.NewReportRepo(conf.DBPool))
 
 	receiptAPI := api.NewReceiptAPI(receiptService)
 	productAPI := api.NewProductAPI(productService)
 	nvAPI := api.NewNutritionalValuesAPI(nvService)
 	recipeAPI := api.NewRecipeAPI(recipeService)
+	aliasAPI := api.NewAliasAPI(aliasService)
+	reportAPI := api.NewReportAPI(reportService)
+	budgetAPI := api.NewBudgetAPI(budgetService)
 
 	return handlers{
 		receipt: receiptAPI,
 		product: productAPI,
 		nv:      nvAPI,
 		recipes: recipeAPI,
+		aliases: aliasAPI,
+		reports: reportAPI,
+		budgets: budgetAPI,
 	}
 }
+
+func LoadReceiptService(conf Config) *receipt.Service {
+	receiptRepo := repository.NewReceiptRepo(conf.DBPool)
+	depositRepo := repository.NewDepositRepo(conf.DBPool)
+	return receipt.NewReceiptService(receiptRepo, depositRepo)
+}
+
+func LoadAliasService(conf Config) *alias.Service {
+	return alias.NewAliasService(repository.NewAliasRepo(conf.DBPool))
+}
+
+func LoadFoodDataService(conf Config) *fooddata.Service {
+	return fooddata.NewFoodDataService(repository.NewFoodFactRepo(conf.DBPool), repository.NewNutritionalValueRepo(conf.DBPool))
+}
+
+func LoadNutritionalValueService(conf Config) *nutritionalvalue.Service {
+	return nutritionalvalue.NewNutritionalValueService(repository.NewNutritionalValueRepo(conf.DBPool))
+}
+
+func LoadRecipeService(conf Config) *recipe.Service {
+	return recipe.NewRecipeService(
+		repository.NewProductRepo(conf.DBPool),
+		repository.NewNutritionalValueRepo(conf.DBPool),
+		repository.NewRecipeRepo(conf.DBPool),
+		repository.NewUnitConversionRepo(conf.DBPool),
+	)
+}
@@ -14,12 +14,32 @@ func LoadRouter(conf Config) *chi.Mux {
 	h := loadAPIHandlers(conf)
 
 	r.Post("/purchased-products/parse-from-receipt-text", h.receipt.ParseReceiptFromText)
+	r.Post("/purchased-products/parse-from-receipt-pdf", h.receipt.ParseReceiptFromPDF)
+	r.Post("/purchased-products/parse-from-receipt-email", h.receipt.ParseReceiptFromEmail)
+	r.Post("/purchased-products/import", h.receipt.ImportReceipts)
 	r.Post("/purchased-products/parse-from-receipt-in-db", h.receipt.ParseReceiptInDB)
+	r.Get("/purchased-products/parser-regression", h.receipt.RunParserRegression)
 	r.Get("/purchased-products/unconfirmed-receipts/summary", h.receipt.GetUnconfirmedReceiptSummaries)
-	r.Get("/purchased-products/unconfirmed-receipts/{retailerAndDate}", h.receipt.GetUnconfirmedReceipt)
+	r.Get("/purchased-products/unconfirmed-receipts/{receiptID}", h.receipt.GetUnconfirmedReceipt)
 	r.Post("/purchased-products/confirm", h.product.ConfirmPurchasedProducts)
 	r.Get("/purchased-products/last-receipt-dates", h.receipt.GetLastReceiptDates)
 	r.Get("/purchased-products/with-missing-info", h.receipt.GetProductsWithMissingInfo)
+	r.Get("/deposits/balance", h.receipt.GetDepositBalance)
+	r.Get("/products/barcode/{barcode}", h.product.GetProductByBarcode)
+	r.Post("/products/basket-comparison", h.product.CompareBasket)
+	r.Get("/products/price-index", h.product.GetPriceIndex)
+
+	r.Get("/aliases", h.aliases.GetProductAliases)
+	r.Post("/aliases/reassign", h.aliases.ReassignProductAliases)
+	r.Put("/aliases/{aliasID}", h.aliases.UpdateProductAlias)
+	r.Delete("/aliases/{aliasID}", h.aliases.DeleteProductAlias)
+
+	r.Get("/reports/spending", h.reports.GetSpendingReport)
+
+	r.Get("/budgets", h.budgets.GetBudgetStatuses)
+	r.Put("/budgets", h.budgets.UpsertBudget)
+	r.Get("/budgets/alerts", h.budgets.GetBudgetAlerts)
+	r.Delete("/budgets/{budgetID}", h.budgets.DeleteBudget)
 
 	r.Post("/nutritional-values", h.nv.InsertNutritionalValues)
 	r.Get("/nutritional-values", h.nv.GetNutritionalValues)
@@ -28,6 +48,9 @@ func LoadRouter(conf Config) *chi.Mux {
 	r.Put("/nutritional-values/{nutritionalValueID}", h.nv.UpdateNutritionalValue)
 	r.Delete("/nutritional-values/{nutritionalValueID}", h.nv.DeleteNutritionalValues)
 
+	r.Get("/nutrients", h.nv.GetNutrients)
+	r.Put("/nutrients/{code}", h.nv.UpsertNutrient)
+
 	r.Post("/recipes", h.recipes.InsertRecipe)
 	r.Get("/recipes/summary", h.recipes.GetRecipeSummaries)
 	r.Get("/recipes/names", h.recipes.GetRecipeNames)
@@ -40,5 +63,9 @@ func LoadRouter(conf Config) *chi.Mux {
 	r.Delete("/recipes/{recipeID}", h.recipes.DeleteRecipe)
 	r.Post("/recipes/clone", h.recipes.CloneRecipes)
 
+	r.Get("/unit-conversions", h.recipes.GetUnitConversions)
+	r.Put("/unit-conversions/{product}", h.recipes.UpsertUnitConversion)
+	r.Delete("/unit-conversions/{product}", h.recipes.DeleteUnitConversion)
+
 	return r
 }
@@ -18,6 +18,13 @@ func (e *APIError) Error() string {
 	return e.ActualError.Error()
 }
 
+func (e *APIError) Unwrap() error {
+	if e == nil {
+		return nil
+	}
+	return e.ActualError
+}
+
 func NewNotFound(consumerMessage string, err error) error {
 	if err == nil {
 		err = errors.New(consumerMessage)
@@ -29,6 +36,17 @@ func NewNotFound(consumerMessage string, err error) error {
 	}
 }
 
+func NewConflict(consumerMessage string, err error) error {
+	if err == nil {
+		err = errors.New(consumerMessage)
+	}
+	return &APIError{
+		ConsumerMessage: consumerMessage,
+		ActualError:     err,
+		StatusCode:      http.StatusConflict,
+	}
+}
+
 func NewBadRequest(consumerMessage string, err error) error {
 	if err == nil {
 		err = errors.New(consumerMessage)
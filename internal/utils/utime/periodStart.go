@@ -0,0 +1,12 @@
+package utime
+
+import "time"
+
+// PeriodStart returns the first day of the month of the date when monthly is set, otherwise the Monday of its week.
+func PeriodStart(date time.Time, monthly bool) time.Time {
+	date = time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	if monthly {
+		return date.AddDate(0, 0, 1-date.Day())
+	}
+	return date.AddDate(0, 0, -(int(date.Weekday())+6)%7)
+}
@@ -1,9 +1,15 @@
 package main
 
 import (
+	"archive/zip"
 	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/fs"
 	"log/slog"
 	"net/http"
+	"os"
 	"strings"
 
 	"github.com/99designs/gqlgen/graphql/handler"
@@ -12,11 +18,12 @@ import (
 	"github.com/99designs/gqlgen/graphql/handler/transport"
 	"github.com/99designs/gqlgen/graphql/playground"
 	"github.com/SarunasBucius/nutri-price-server/graph"
+	"github.com/SarunasBucius/nutri-price-server/internal/model"
+	"github.com/SarunasBucius/nutri-price-server/internal/service/fooddata"
+	"github.com/SarunasBucius/nutri-price-server/internal/service/receipt"
 	"github.com/SarunasBucius/nutri-price-server/internal/setup"
 	"github.com/SarunasBucius/nutri-price-server/migrations"
-	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/go-chi/chi/v5"
-	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/vektah/gqlparser/v2/ast"
 )
 
@@ -34,15 +41,27 @@ func main() {
 		return
 	}
 
+	if len(os.Args) > 1 {
+		if err := runSubcommand(ctx, config, os.Args[1], os.Args[2:]); err != nil {
+			slog.Error("run subcommand", "subcommand", os.Args[1], "error", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	r := setup.LoadRouter(config)
 
+	if config.MaildirPath != "" {
+		go setup.LoadReceiptService(config).PollMaildir(ctx, config.MaildirPath, config.MaildirPollInterval)
+	}
+
 	slog.InfoContext(ctx, "Listening...", "port", config.Port)
 
 	port := config.Port
 	if !strings.HasPrefix(port, ":") {
 		port = ":" + port
 	}
-	attachGraphQLRoutes(config.DBPool, r, config.DynamoDB)
+	attachGraphQLRoutes(config, r)
 
 	if err := http.ListenAndServe(port, r); err != nil {
 		slog.Error("listen and serve", "error", err)
@@ -50,10 +69,141 @@ func main() {
 	}
 }
 
-func attachGraphQLRoutes(db *pgxpool.Pool, r *chi.Mux, dynamoDB *dynamodb.Client) {
+func runSubcommand(ctx context.Context, config setup.Config, subcommand string, args []string) error {
+	switch subcommand {
+	case "import":
+		return importReceipts(ctx, config, args)
+	case "parser-regression":
+		return runParserRegression(ctx, config)
+	case "import-food-facts":
+		return importFoodFacts(ctx, config, args)
+	case "import-food-data-central":
+		return importFoodDataCentral(ctx, config, args)
+	default:
+		return fmt.Errorf("unknown subcommand %q", subcommand)
+	}
+}
+
+// runParserRegression re-parses stored receipts and writes the accuracy report to stdout as JSON.
+func runParserRegression(ctx context.Context, config setup.Config) error {
+	report, err := setup.LoadReceiptService(config).RunParserRegression(ctx)
+	if err != nil {
+		return err
+	}
+	return writeJSON(report)
+}
+
+// importReceipts runs the import subcommand: import [-concurrency n] [-retailer name] <zip file or directory>.
+// The report is written to stdout as JSON.
+func importReceipts(ctx context.Context, config setup.Config, args []string) error {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	concurrency := flags.Int("concurrency", 4, "number of receipts imported at a time")
+	retailerHint := flags.String("retailer", "", "retailer of all receipts, detected per receipt when empty")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return fmt.Errorf("expected a zip file or directory to import")
+	}
+	path := flags.Arg(0)
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("stat %s: %w", path, err)
+	}
+
+	var files []model.ReceiptFile
+	if info.IsDir() {
+		files, err = receipt.ReadReceiptDir(path)
+	} else {
+		var archive []byte
+		archive, err = os.ReadFile(path)
+		if err == nil {
+			files, err = receipt.ReadReceiptZip(archive)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("read receipt files: %w", err)
+	}
+
+	report := setup.LoadReceiptService(config).ImportReceipts(ctx, files, *retailerHint, *concurrency)
+	return writeJSON(report)
+}
+
+// importFoodFacts runs the import-food-facts subcommand: import-food-facts [-format jsonl|csv] <export file>.
+// The format is detected from the file extension when not given. The report is written to stdout as JSON.
+func importFoodFacts(ctx context.Context, config setup.Config, args []string) error {
+	flags := flag.NewFlagSet("import-food-facts", flag.ContinueOnError)
+	format := flags.String("format", "", "export format, jsonl or csv")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return fmt.Errorf("expected an Open Food Facts export file to import")
+	}
+
+	export, detectedFormat, err := fooddata.OpenExport(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	defer export.Close()
+	if *format == "" {
+		*format = detectedFormat
+	}
+
+	report, err := setup.LoadFoodDataService(config).ImportOpenFoodFacts(ctx, export, *format)
+	if err != nil {
+		return err
+	}
+	return writeJSON(report)
+}
+
+// importFoodDataCentral runs the import-food-data-central subcommand: import-food-data-central <zip file or directory>.
+// The release is a FoodData Central CSV download, such as the Foundation or SR Legacy foods. The report is written to stdout as JSON.
+func importFoodDataCentral(ctx context.Context, config setup.Config, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("expected a FoodData Central zip file or directory to import")
+	}
+	path := args[0]
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("stat %s: %w", path, err)
+	}
+
+	var release fs.FS
+	if info.IsDir() {
+		release = os.DirFS(path)
+	} else {
+		archive, err := zip.OpenReader(path)
+		if err != nil {
+			return fmt.Errorf("open %s: %w", path, err)
+		}
+		defer archive.Close()
+		release = archive
+	}
+
+	report, err := setup.LoadFoodDataService(config).ImportFoodDataCentral(ctx, release)
+	if err != nil {
+		return err
+	}
+	return writeJSON(report)
+}
+
+func writeJSON(v any) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+func attachGraphQLRoutes(config setup.Config, r *chi.Mux) {
 	srv := handler.New(graph.NewExecutableSchema(graph.Config{Resolvers: &graph.Resolver{
-		DB:       db,
-		DynamoDB: dynamoDB,
+		DB:                      config.DBPool,
+		DynamoDB:                config.DynamoDB,
+		AliasService:            setup.LoadAliasService(config),
+		RecipeService:           setup.LoadRecipeService(config),
+		FoodDataService:         setup.LoadFoodDataService(config),
+		NutritionalValueService: setup.LoadNutritionalValueService(config),
 	}}))
 
 	srv.AddTransport(transport.Options{})
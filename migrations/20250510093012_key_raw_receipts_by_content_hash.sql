@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE raw_receipts ADD COLUMN id SERIAL PRIMARY KEY;
+ALTER TABLE raw_receipts ADD COLUMN purchase_time TEXT NOT NULL DEFAULT '';
+ALTER TABLE raw_receipts ADD COLUMN receipt_number TEXT NOT NULL DEFAULT '';
+ALTER TABLE raw_receipts ADD COLUMN content_hash TEXT;
+UPDATE raw_receipts SET content_hash = encode(sha256(convert_to(receipt, 'UTF8')), 'hex');
+ALTER TABLE raw_receipts ALTER COLUMN content_hash SET NOT NULL;
+ALTER TABLE raw_receipts DROP CONSTRAINT raw_receipts_purchase_date_retailer_key;
+ALTER TABLE raw_receipts ADD CONSTRAINT raw_receipts_content_hash_key UNIQUE (content_hash);
+-- +goose StatementEnd
@@ -0,0 +1,7 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE purchases ADD COLUMN full_price NUMERIC(6, 2) NOT NULL DEFAULT 0;
+ALTER TABLE purchases ADD COLUMN discount NUMERIC(6, 2) NOT NULL DEFAULT 0;
+ALTER TABLE purchases ADD COLUMN discount_type TEXT NOT NULL DEFAULT '';
+UPDATE purchases SET full_price = price;
+-- +goose StatementEnd
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS deposits (
+    id SERIAL PRIMARY KEY,
+    purchase_id INT,
+    raw_receipt_id INT UNIQUE,
+    retailer TEXT NOT NULL DEFAULT '',
+    deposit_date DATE NOT NULL,
+    amount NUMERIC(6, 2) NOT NULL,
+    kind TEXT NOT NULL,
+    FOREIGN KEY (purchase_id) REFERENCES purchases(id) ON DELETE CASCADE,
+    FOREIGN KEY (raw_receipt_id) REFERENCES raw_receipts(id) ON DELETE CASCADE
+);
+-- +goose StatementEnd
@@ -0,0 +1,5 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE raw_receipts ADD COLUMN message_id TEXT;
+ALTER TABLE raw_receipts ADD CONSTRAINT raw_receipts_message_id_key UNIQUE (message_id);
+-- +goose StatementEnd
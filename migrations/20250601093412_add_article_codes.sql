@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE purchases ADD COLUMN article_code TEXT NOT NULL DEFAULT '';
+
+CREATE TABLE IF NOT EXISTS product_codes (
+    code TEXT PRIMARY KEY,
+    product_id INT NOT NULL,
+    variety_name TEXT NOT NULL DEFAULT '',
+    FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE CASCADE
+);
+-- +goose StatementEnd
@@ -0,0 +1,8 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS unit_conversions (
+    product TEXT PRIMARY KEY,
+    density NUMERIC(6, 3) NOT NULL DEFAULT 0,
+    piece_weight NUMERIC(9, 3) NOT NULL DEFAULT 0
+);
+-- +goose StatementEnd
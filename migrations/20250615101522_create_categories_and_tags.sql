@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS categories (
+    id SERIAL PRIMARY KEY,
+    name TEXT NOT NULL,
+    parent_id INT,
+    FOREIGN KEY (parent_id) REFERENCES categories(id) ON DELETE CASCADE,
+    UNIQUE NULLS NOT DISTINCT (parent_id, name)
+);
+
+ALTER TABLE products ADD COLUMN category_id INT REFERENCES categories(id) ON DELETE SET NULL;
+
+CREATE TABLE IF NOT EXISTS product_tags (
+    product_id INT NOT NULL,
+    tag TEXT NOT NULL,
+    FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE CASCADE,
+    PRIMARY KEY (product_id, tag)
+);
+
+-- category_subtree returns ids of the category and all of its descendants.
+CREATE OR REPLACE FUNCTION category_subtree(root_id INT) RETURNS TABLE (id INT) AS $$
+    WITH RECURSIVE subtree AS (
+        SELECT categories.id FROM categories WHERE categories.id = root_id
+        UNION ALL
+        SELECT categories.id FROM categories JOIN subtree ON categories.parent_id = subtree.id
+    )
+    SELECT subtree.id FROM subtree
+$$ LANGUAGE SQL STABLE;
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS budgets (
+    id SERIAL PRIMARY KEY,
+    month DATE NOT NULL,
+    category_id INT,
+    retailer TEXT NOT NULL DEFAULT '',
+    amount NUMERIC(9, 2) NOT NULL,
+    alert_threshold NUMERIC(4, 3) NOT NULL DEFAULT 0.9,
+    FOREIGN KEY (category_id) REFERENCES categories(id) ON DELETE CASCADE,
+    CONSTRAINT budgets_scope_key UNIQUE NULLS NOT DISTINCT (month, category_id, retailer),
+    CHECK (category_id IS NULL OR retailer = '')
+);
+
+CREATE TABLE IF NOT EXISTS budget_alerts (
+    id SERIAL PRIMARY KEY,
+    budget_id INT NOT NULL UNIQUE,
+    receipt_id TEXT NOT NULL DEFAULT '',
+    amount NUMERIC(9, 2) NOT NULL,
+    threshold NUMERIC(4, 3) NOT NULL,
+    spent NUMERIC(9, 2) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (budget_id) REFERENCES budgets(id) ON DELETE CASCADE
+);
+-- +goose StatementEnd
@@ -0,0 +1,44 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS nutrients (
+    code TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    unit TEXT NOT NULL,
+    daily_reference NUMERIC(9, 3)
+);
+
+-- Daily reference values are the EU nutrient reference values for adults.
+INSERT INTO nutrients (code, name, unit, daily_reference) VALUES
+    ('vitamin_a', 'Vitamin A', 'µg', 800),
+    ('vitamin_d', 'Vitamin D', 'µg', 5),
+    ('vitamin_e', 'Vitamin E', 'mg', 12),
+    ('vitamin_k', 'Vitamin K', 'µg', 75),
+    ('vitamin_c', 'Vitamin C', 'mg', 80),
+    ('thiamin', 'Thiamin', 'mg', 1.1),
+    ('riboflavin', 'Riboflavin', 'mg', 1.4),
+    ('niacin', 'Niacin', 'mg', 16),
+    ('vitamin_b6', 'Vitamin B6', 'mg', 1.4),
+    ('folate', 'Folate', 'µg', 200),
+    ('vitamin_b12', 'Vitamin B12', 'µg', 2.5),
+    ('biotin', 'Biotin', 'µg', 50),
+    ('pantothenic_acid', 'Pantothenic acid', 'mg', 6),
+    ('potassium', 'Potassium', 'mg', 2000),
+    ('chloride', 'Chloride', 'mg', 800),
+    ('calcium', 'Calcium', 'mg', 800),
+    ('phosphorus', 'Phosphorus', 'mg', 700),
+    ('magnesium', 'Magnesium', 'mg', 375),
+    ('iron', 'Iron', 'mg', 14),
+    ('zinc', 'Zinc', 'mg', 10),
+    ('copper', 'Copper', 'mg', 1),
+    ('manganese', 'Manganese', 'mg', 2),
+    ('fluoride', 'Fluoride', 'mg', 3.5),
+    ('selenium', 'Selenium', 'µg', 55),
+    ('chromium', 'Chromium', 'µg', 40),
+    ('molybdenum', 'Molybdenum', 'µg', 50),
+    ('iodine', 'Iodine', 'µg', 150)
+ON CONFLICT (code) DO NOTHING;
+
+-- Nutrient amounts are keyed by nutrient code, so new nutrients only need a catalogue entry.
+ALTER TABLE nutritional_values ADD COLUMN IF NOT EXISTS nutrients JSONB NOT NULL DEFAULT '{}';
+ALTER TABLE nutritional_values_v2 ADD COLUMN IF NOT EXISTS nutrients JSONB NOT NULL DEFAULT '{}';
+-- +goose StatementEnd
@@ -0,0 +1,44 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE nutritional_values_v2
+    ADD COLUMN IF NOT EXISTS soluble_fibre NUMERIC(6, 3) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS insoluble_fibre NUMERIC(6, 3) NOT NULL DEFAULT 0;
+
+-- Legacy nutritional values are keyed by product name. Rows without a unit are placeholders of recipe ingredients.
+CREATE TEMPORARY TABLE legacy_nutritional_values ON COMMIT DROP AS
+SELECT DISTINCT ON (product, measurement_unit) *
+FROM nutritional_values
+WHERE measurement_unit <> ''
+ORDER BY product, measurement_unit, id DESC;
+
+-- Fill in fields missing from v2 nutritional values of the same product and unit.
+UPDATE nutritional_values_v2
+SET soluble_fibre = COALESCE(legacy.soluble_fibre, 0),
+    insoluble_fibre = COALESCE(legacy.insoluble_fibre, 0),
+    nutrients = legacy.nutrients || nutritional_values_v2.nutrients
+FROM products, legacy_nutritional_values legacy
+WHERE products.id = nutritional_values_v2.product_id
+    AND legacy.product = products.name
+    AND legacy.measurement_unit = nutritional_values_v2.unit;
+
+-- Copy legacy nutritional values of existing products that have none in v2. Legacy values of names that are not
+-- products are left in the legacy table. Varieties are unique per product, so the first legacy unit is copied with
+-- the product name as the variety name and other units with the unit appended, e.g. "Eggs (pcs)".
+INSERT INTO nutritional_values_v2 (
+    product_id, variety_name, unit, energy_value_kcal, fat, saturated_fat, carbohydrate, carbohydrate_sugars,
+    fibre, soluble_fibre, insoluble_fibre, protein, salt, nutrients
+)
+SELECT
+    products.id,
+    CASE
+        WHEN ROW_NUMBER() OVER (PARTITION BY products.id ORDER BY legacy.id) = 1 THEN products.name
+        ELSE products.name || ' (' || legacy.measurement_unit || ')'
+    END,
+    legacy.measurement_unit, legacy.energy_value_kcal, legacy.fat, legacy.saturated_fat,
+    legacy.carbohydrate, legacy.carbohydrate_sugars, legacy.fibre, COALESCE(legacy.soluble_fibre, 0),
+    COALESCE(legacy.insoluble_fibre, 0), legacy.protein, legacy.salt, legacy.nutrients
+FROM legacy_nutritional_values legacy
+JOIN products ON products.name = legacy.product
+WHERE NOT EXISTS (SELECT 1 FROM nutritional_values_v2 WHERE nutritional_values_v2.product_id = products.id)
+ON CONFLICT (variety_name, product_id) DO NOTHING;
+-- +goose StatementEnd
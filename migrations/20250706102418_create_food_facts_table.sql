@@ -0,0 +1,36 @@
+-- +goose Up
+-- +goose StatementBegin
+-- food_facts are products imported from external food databases, with nutritional values per 100 g or ml.
+CREATE TABLE IF NOT EXISTS food_facts (
+    id SERIAL PRIMARY KEY,
+    source TEXT NOT NULL,
+    source_id TEXT NOT NULL,
+    barcode TEXT NOT NULL DEFAULT '',
+    name TEXT NOT NULL,
+    brands TEXT NOT NULL DEFAULT '',
+    unit TEXT NOT NULL,
+    energy_value_kcal NUMERIC(7, 2) NOT NULL DEFAULT 0,
+    fat NUMERIC(9, 3) NOT NULL DEFAULT 0,
+    saturated_fat NUMERIC(9, 3) NOT NULL DEFAULT 0,
+    carbohydrate NUMERIC(9, 3) NOT NULL DEFAULT 0,
+    carbohydrate_sugars NUMERIC(9, 3) NOT NULL DEFAULT 0,
+    fibre NUMERIC(9, 3) NOT NULL DEFAULT 0,
+    soluble_fibre NUMERIC(9, 3) NOT NULL DEFAULT 0,
+    insoluble_fibre NUMERIC(9, 3) NOT NULL DEFAULT 0,
+    protein NUMERIC(9, 3) NOT NULL DEFAULT 0,
+    salt NUMERIC(9, 3) NOT NULL DEFAULT 0,
+    nutrients JSONB NOT NULL DEFAULT '{}',
+    imported_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    search_vector TSVECTOR GENERATED ALWAYS AS (to_tsvector('simple', name || ' ' || brands)) STORED,
+    UNIQUE (source, source_id)
+);
+
+CREATE INDEX IF NOT EXISTS food_facts_barcode_idx ON food_facts (barcode) WHERE barcode <> '';
+CREATE INDEX IF NOT EXISTS food_facts_search_vector_idx ON food_facts USING GIN (search_vector);
+
+-- Provenance of nutritional values filled from food facts. Source is empty for values entered by hand.
+ALTER TABLE nutritional_values_v2
+    ADD COLUMN IF NOT EXISTS source TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS source_id TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS sourced_at TIMESTAMPTZ;
+-- +goose StatementEnd
@@ -0,0 +1,5 @@
+-- +goose Up
+-- +goose StatementBegin
+-- The reference food is the default nutritional value of product varieties that have none of their own.
+ALTER TABLE products ADD COLUMN IF NOT EXISTS reference_food_id INT REFERENCES food_facts(id) ON DELETE SET NULL;
+-- +goose StatementEnd
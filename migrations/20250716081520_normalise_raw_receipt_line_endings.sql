@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Receipts are stored with LF line endings, so CRLF copies of a receipt get the same content hash.
+-- Receipts whose LF copy is already stored are left as they are.
+WITH normalised AS (
+    SELECT DISTINCT ON (content_hash) id, receipt, content_hash
+    FROM (
+        SELECT id, REPLACE(receipt, E'\r', '') AS receipt,
+            encode(sha256(convert_to(REPLACE(receipt, E'\r', ''), 'UTF8')), 'hex') AS content_hash
+        FROM raw_receipts
+        WHERE receipt LIKE E'%\r%'
+    ) AS receipts
+    WHERE NOT EXISTS (SELECT 1 FROM raw_receipts stored WHERE stored.content_hash = receipts.content_hash)
+    ORDER BY content_hash, id
+)
+UPDATE raw_receipts
+SET receipt = normalised.receipt, content_hash = normalised.content_hash
+FROM normalised
+WHERE raw_receipts.id = normalised.id;
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Article codes other than EAN barcodes are internal to the retailer, e.g. 7 digit Lidl codes,
+-- so they are keyed together with it. EAN barcodes are stored with an empty retailer.
+ALTER TABLE product_codes ADD COLUMN retailer TEXT NOT NULL DEFAULT '';
+
+-- Stored codes that are not EAN-8 or EAN-13 long get the retailer of their latest purchase.
+UPDATE product_codes
+SET retailer = latest_purchases.retailer
+FROM (
+    SELECT DISTINCT ON (article_code) article_code, retailer
+    FROM purchases
+    WHERE article_code <> ''
+    ORDER BY article_code, purchase_date DESC
+) AS latest_purchases
+WHERE product_codes.code = latest_purchases.article_code
+    AND product_codes.code !~ '^([0-9]{8}|[0-9]{13})$';
+
+ALTER TABLE product_codes DROP CONSTRAINT product_codes_pkey;
+ALTER TABLE product_codes ADD PRIMARY KEY (retailer, code);
+-- +goose StatementEnd
@@ -0,0 +1,5 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Purchases remember the raw receipt they were confirmed from, purchases entered without a receipt have none.
+ALTER TABLE purchases ADD COLUMN IF NOT EXISTS raw_receipt_id INT REFERENCES raw_receipts(id) ON DELETE SET NULL;
+-- +goose StatementEnd